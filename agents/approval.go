@@ -0,0 +1,236 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	// ErrInterrupted is wrapped by the InterruptError returned when a run is interrupted for the
+	// approval of actions.
+	ErrInterrupted = errors.New("agent interrupted for approval")
+	// ErrInvalidDecisions is returned by Resume if the decisions do not match the actions awaiting
+	// approval.
+	ErrInvalidDecisions = errors.New("invalid decisions")
+	// ErrNoInterrupt is returned by Resume without an interrupt to resume.
+	ErrNoInterrupt = errors.New("no interrupt to resume")
+)
+
+// Interrupt is the state of a run interrupted for the approval of actions. It can be encoded to
+// JSON, to resume the run in another process.
+type Interrupt struct {
+	// Inputs are the inputs of the run.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps taken before the interruption.
+	Steps []schema.AgentStep `json:"steps"`
+	// Actions are the actions of the interrupted plan. None of them has been run.
+	Actions []schema.AgentAction `json:"actions"`
+	// Pending holds the indexes in Actions of the actions awaiting approval.
+	Pending []int `json:"pending"`
+	// Iteration is the iteration of the interrupted plan.
+	Iteration int `json:"iteration"`
+}
+
+// PendingActions returns the actions awaiting approval.
+func (i *Interrupt) PendingActions() []schema.AgentAction {
+	actions := make([]schema.AgentAction, 0, len(i.Pending))
+	for _, index := range i.Pending {
+		actions = append(actions, i.Actions[index])
+	}
+	return actions
+}
+
+// InterruptError is returned by the executor when a run is interrupted for the approval of
+// actions. The run is continued with Executor.Resume.
+type InterruptError struct {
+	Interrupt *Interrupt
+}
+
+func (e *InterruptError) Error() string {
+	names := make([]string, 0, len(e.Interrupt.Pending))
+	for _, action := range e.Interrupt.PendingActions() {
+		names = append(names, action.Tool)
+	}
+	return fmt.Sprintf("%s: %s", ErrInterrupted, strings.Join(names, ", "))
+}
+
+func (e *InterruptError) Unwrap() error {
+	return ErrInterrupted
+}
+
+// Decision is the decision of a human on an action awaiting approval.
+type Decision struct {
+	// Approved tells whether the tool of the action is called.
+	Approved bool `json:"approved"`
+	// ToolInput replaces the input of an approved action when not empty.
+	ToolInput string `json:"tool_input,omitempty"`
+	// Feedback is given to the agent in the observation of a rejected action.
+	Feedback string `json:"feedback,omitempty"`
+}
+
+// Approve returns the decision approving an action.
+func Approve() Decision {
+	return Decision{Approved: true}
+}
+
+// ApproveWithInput returns the decision approving an action with another input for its tool.
+func ApproveWithInput(toolInput string) Decision {
+	return Decision{Approved: true, ToolInput: toolInput}
+}
+
+// Reject returns the decision rejecting an action, with feedback for the agent.
+func Reject(feedback string) Decision {
+	return Decision{Feedback: feedback}
+}
+
+// ApprovalForTools returns a function for WithApproval requiring the approval of the actions of
+// the tools with the given names.
+func ApprovalForTools(names ...string) func(action schema.AgentAction) bool {
+	return func(action schema.AgentAction) bool {
+		return slices.ContainsFunc(names, func(name string) bool {
+			return strings.EqualFold(name, action.Tool)
+		})
+	}
+}
+
+// approvalInterrupt returns the interrupt for the actions of a plan when some need approval.
+func (e *Executor) approvalInterrupt(
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	actions []schema.AgentAction,
+) *Interrupt {
+	if e.NeedsApproval == nil {
+		return nil
+	}
+
+	var pending []int
+	for i, action := range actions {
+		if e.NeedsApproval(action) {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	return &Interrupt{
+		Inputs:  inputs,
+		Steps:   slices.Clone(steps),
+		Actions: slices.Clone(actions),
+		Pending: pending,
+	}
+}
+
+// Resume continues a run interrupted for approval with the decisions on the pending actions, in
+// order. The actions of the interrupted plan are run, except the rejected ones whose observation
+// is the rejection, and the run continues as with Call. The run can be interrupted again.
+//
+// Resume handles the memory and callbacks of the executor as chains.Call does.
+func (e *Executor) Resume(
+	ctx context.Context,
+	interrupt *Interrupt,
+	decisions []Decision,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
+	if interrupt == nil {
+		return nil, ErrNoInterrupt
+	}
+	if len(decisions) != len(interrupt.Pending) {
+		return nil, fmt.Errorf("%w: %d decisions for %d pending actions",
+			ErrInvalidDecisions, len(decisions), len(interrupt.Pending))
+	}
+
+	inputs := make(map[string]any, len(interrupt.Inputs))
+	for key, value := range interrupt.Inputs {
+		inputs[key] = value
+	}
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleChainStart(ctx, inputs)
+	}
+	outputs, err := e.resume(ctx, interrupt, decisions, options...)
+	if err != nil {
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleChainError(ctx, err)
+		}
+		return outputs, err
+	}
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleChainEnd(ctx, outputs)
+	}
+
+	// The memory saves the inputs of the run, not the ones it loaded.
+	for _, key := range e.Memory.MemoryVariables(ctx) {
+		delete(inputs, key)
+	}
+	if err := e.Memory.SaveContext(ctx, inputs, outputs); err != nil {
+		return outputs, err
+	}
+
+	return outputs, nil
+}
+
+func (e *Executor) resume(
+	ctx context.Context,
+	interrupt *Interrupt,
+	decisions []Decision,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
+	// Rejected actions are not run, the others are run together as the plan
+	// would have been.
+	rejections := make(map[int]schema.AgentStep, len(decisions))
+	actions := slices.Clone(interrupt.Actions)
+	for i, index := range interrupt.Pending {
+		if index < 0 || index >= len(actions) {
+			return nil, fmt.Errorf("%w: no action %d in the plan", ErrInvalidDecisions, index)
+		}
+		decision := decisions[i]
+		if !decision.Approved {
+			rejections[index] = schema.AgentStep{Action: actions[index], Observation: rejectionObservation(decision)}
+			continue
+		}
+		if decision.ToolInput != "" {
+			actions[index].ToolInput = decision.ToolInput
+		}
+	}
+
+	approved := make([]schema.AgentAction, 0, len(actions))
+	for i, action := range actions {
+		if _, ok := rejections[i]; !ok {
+			approved = append(approved, action)
+		}
+	}
+	results, err := e.doActions(ctx, nil, getNameToTool(e.Agent.GetTools()), approved)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := slices.Clone(interrupt.Steps)
+	for i := range actions {
+		if step, ok := rejections[i]; ok {
+			steps = append(steps, step)
+			continue
+		}
+		steps = append(steps, results[0])
+		results = results[1:]
+	}
+
+	// The decisions are not applied twice if the process stops now.
+	if err := e.saveCheckpoint(ctx, interrupt.Inputs, steps, interrupt.Iteration+1, nil); err != nil {
+		return nil, err
+	}
+
+	return e.run(ctx, interrupt.Inputs, steps, interrupt.Iteration+1, options...)
+}
+
+func rejectionObservation(decision Decision) string {
+	if decision.Feedback == "" {
+		return "The user rejected this action."
+	}
+	return "The user rejected this action: " + decision.Feedback
+}
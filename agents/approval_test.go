@@ -0,0 +1,131 @@
+package agents_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// newOpsAgent returns an agent restarting a service and searching the logs,
+// then finishing with the observations.
+func newOpsAgent(ts ...tools.Tool) *testAgent {
+	return &testAgent{
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+		tools:      ts,
+		plan: func(_ context.Context, intermediateSteps []schema.AgentStep, _ map[string]string) ([]schema.AgentAction, *schema.AgentFinish, error) { //nolint:lll
+			if len(intermediateSteps) == 0 {
+				return []schema.AgentAction{
+					{Tool: "restart", ToolInput: "api"},
+					{Tool: "search", ToolInput: "errors"},
+				}, nil, nil
+			}
+			observations := make([]string, 0, len(intermediateSteps))
+			for _, step := range intermediateSteps {
+				observations = append(observations, step.Observation)
+			}
+			output := strings.Join(observations, "; ")
+			return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": output}}, nil
+		},
+	}
+}
+
+// recordingTool records its inputs.
+type recordingTool struct {
+	name   string
+	inputs []string
+}
+
+func (t *recordingTool) Name() string        { return t.name }
+func (t *recordingTool) Description() string { return "Records its inputs." }
+
+func (t *recordingTool) Call(_ context.Context, input string) (string, error) {
+	t.inputs = append(t.inputs, input)
+	return t.name + " " + input, nil
+}
+
+func interruptRun(t *testing.T) (*agents.Interrupt, *recordingTool, *recordingTool) {
+	t.Helper()
+
+	restart, search := &recordingTool{name: "restart"}, &recordingTool{name: "search"}
+	executor := agents.NewExecutor(newOpsAgent(restart, search),
+		agents.WithApproval(agents.ApprovalForTools("Restart")))
+
+	_, err := chains.Call(context.Background(), executor, map[string]any{"input": "Fix the api"})
+	require.ErrorIs(t, err, agents.ErrInterrupted)
+	var interruptErr *agents.InterruptError
+	require.True(t, errors.As(err, &interruptErr))
+	require.Empty(t, restart.inputs)
+	require.Empty(t, search.inputs)
+	require.Equal(t, []schema.AgentAction{{Tool: "restart", ToolInput: "api"}}, interruptErr.Interrupt.PendingActions())
+
+	// The run is resumed from its JSON encoding, as in another process.
+	data, err := json.Marshal(interruptErr.Interrupt)
+	require.NoError(t, err)
+	var interrupt agents.Interrupt
+	require.NoError(t, json.Unmarshal(data, &interrupt))
+	return &interrupt, restart, search
+}
+
+// chainEventsHandler records the chain callbacks.
+type chainEventsHandler struct {
+	callbacks.SimpleHandler
+	events []string
+}
+
+func (h *chainEventsHandler) HandleChainStart(_ context.Context, inputs map[string]any) {
+	h.events = append(h.events, "start "+inputs["input"].(string)) //nolint:forcetypeassert
+}
+
+func (h *chainEventsHandler) HandleChainEnd(_ context.Context, outputs map[string]any) {
+	h.events = append(h.events, "end "+outputs["output"].(string)) //nolint:forcetypeassert
+}
+
+func (h *chainEventsHandler) HandleChainError(_ context.Context, err error) {
+	h.events = append(h.events, "error "+err.Error())
+}
+
+func TestExecutorResume(t *testing.T) {
+	t.Parallel()
+
+	interrupt, restart, search := interruptRun(t)
+	handler := &chainEventsHandler{}
+	executor := agents.NewExecutor(newOpsAgent(restart, search),
+		agents.WithApproval(agents.ApprovalForTools("restart")),
+		agents.WithCallbacksHandler(handler))
+
+	_, err := executor.Resume(context.Background(), nil, nil)
+	require.ErrorIs(t, err, agents.ErrNoInterrupt)
+
+	out, err := executor.Resume(context.Background(), interrupt, []agents.Decision{agents.ApproveWithInput("api-v2")})
+	require.NoError(t, err)
+	require.Equal(t, "restart api-v2; search errors", out["output"])
+	require.Equal(t, []string{"api-v2"}, restart.inputs)
+	require.Equal(t, []string{"errors"}, search.inputs)
+	// The run is a chain, as with chains.Call.
+	require.Equal(t, []string{"start Fix the api", "end restart api-v2; search errors"}, handler.events)
+}
+
+func TestExecutorResumeRejected(t *testing.T) {
+	t.Parallel()
+
+	interrupt, restart, search := interruptRun(t)
+	executor := agents.NewExecutor(newOpsAgent(restart, search))
+
+	_, err := executor.Resume(context.Background(), interrupt, nil)
+	require.ErrorIs(t, err, agents.ErrInvalidDecisions)
+
+	out, err := executor.Resume(context.Background(), interrupt, []agents.Decision{agents.Reject("not during peak hours")})
+	require.NoError(t, err)
+	require.Equal(t, "The user rejected this action: not during peak hours; search errors", out["output"])
+	require.Empty(t, restart.inputs)
+}
@@ -0,0 +1,257 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/cost"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	// ErrBudgetExceeded is returned by the executor when a run exceeds its
+	// budget.
+	ErrBudgetExceeded = errors.New("agent budget exceeded")
+	// ErrUsageNotReported is returned by the executor when the budget limits
+	// tokens or cost, but the usage or cost of the responses of the model of
+	// the agent is not known.
+	ErrUsageNotReported = errors.New("agent usage not reported")
+)
+
+// _finalAnswerInstruction asks the model for a final answer when a run is
+// stopped before the agent finishes.
+const _finalAnswerInstruction = "You cannot use tools anymore. " +
+	"Give your best final answer to the original input with the information gathered so far, " +
+	"and tell what is missing if it is incomplete."
+
+// Budget limits the resources used by a run of an executor. Zero values are no
+// limits.
+//
+// Tokens and cost are those of the responses of the model of the agent. The
+// tool calling and OpenAI functions agents report them to the executor, which
+// prices them with Prices. For other agents, set a cost.Handler as the
+// callbacks handler of the model: the responses it prices are added to the
+// cost.Accumulator of the context, or to a new one for the run. Runs fail
+// with ErrUsageNotReported when the agent has planned but no usage was
+// reported either way, or when MaxCost is set and a response has no price.
+//
+// The budget is checked before every iteration, so the iteration exceeding it
+// completes, except for MaxDuration, which cancels the model or tool calls in
+// progress.
+type Budget struct {
+	// MaxTokens is the maximum number of tokens of the responses of the run.
+	MaxTokens int
+	// MaxCost is the maximum cost of the responses of the run in US dollars.
+	MaxCost float64
+	// Prices prices the responses reported by the agent. The default is
+	// cost.DefaultTable.
+	Prices *cost.Table
+	// MaxDuration is the maximum wall-clock time of the run. The context of
+	// the calls of the run is canceled when it is reached.
+	MaxDuration time.Duration
+	// MaxToolCalls holds the maximum number of calls of tools by name. A plan
+	// calling a tool more than allowed exceeds the budget, and its actions
+	// are not run.
+	MaxToolCalls map[string]int
+	// ForceFinalAnswer makes the executor ask the agent for a final answer
+	// with the steps taken so far when the budget or MaxIterations is
+	// reached, rather than failing with ErrBudgetExceeded or ErrNotFinished.
+	// The agent must implement FinalAnswerer.
+	ForceFinalAnswer bool
+}
+
+// FinalAnswerer is implemented by agents able to give a final answer from the
+// steps taken so far without using tools, when the executor stops a run.
+type FinalAnswerer interface {
+	FinalAnswer(
+		ctx context.Context,
+		intermediateSteps []schema.AgentStep,
+		inputs map[string]string,
+		options ...chains.ChainCallOption,
+	) (*schema.AgentFinish, error)
+}
+
+// budgetUsage is the usage of the budget of a run.
+type budgetUsage struct {
+	budget Budget
+	start  time.Time
+	// planned is whether the agent has planned in the run.
+	planned bool
+	// reported holds the totals of the responses reported by the agent.
+	reported cost.Accumulator
+	acc      *cost.Accumulator
+	// initial are the totals of the accumulator when the run started.
+	initial cost.Totals
+}
+
+type budgetUsageKey struct{}
+
+// startBudget starts the usage of the budget of a run. The returned context
+// has the budget usage the agent reports its responses to, and the
+// accumulator cost handlers add the tokens and cost of the run to.
+func (e *Executor) startBudget(ctx context.Context) (context.Context, *budgetUsage) {
+	usage := &budgetUsage{budget: e.Budget, start: time.Now()}
+	if !usage.measured() {
+		return ctx, usage
+	}
+	if usage.budget.Prices == nil {
+		usage.budget.Prices = cost.DefaultTable()
+	}
+	usage.acc = cost.FromContext(ctx)
+	if usage.acc == nil {
+		ctx, usage.acc = cost.WithAccumulator(ctx)
+	}
+	usage.initial = usage.acc.Totals()
+	return context.WithValue(ctx, budgetUsageKey{}, usage), usage
+}
+
+// reportResponse adds the usage of a response of the model of an agent to the
+// budget of the run of ctx, if it limits tokens or cost.
+func reportResponse(ctx context.Context, res *llms.ContentResponse) {
+	if usage, ok := ctx.Value(budgetUsageKey{}).(*budgetUsage); ok {
+		usage.reported.Add(usage.budget.Prices.ResponseTotals(res))
+	}
+}
+
+// withDeadline returns a context canceled with a cause wrapping
+// ErrBudgetExceeded when the run reaches its MaxDuration, if any.
+func (u *budgetUsage) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.budget.MaxDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	cause := fmt.Errorf("%w: ran for %s of %s", ErrBudgetExceeded, u.budget.MaxDuration, u.budget.MaxDuration)
+	return context.WithDeadlineCause(ctx, u.start.Add(u.budget.MaxDuration), cause)
+}
+
+// expired returns the error wrapping ErrBudgetExceeded of a run whose context
+// was canceled because it reached its MaxDuration, or err otherwise.
+func expired(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) && errors.Is(context.Cause(ctx), ErrBudgetExceeded) {
+		return context.Cause(ctx)
+	}
+	return err
+}
+
+// measured is whether the budget limits the tokens or cost of the run.
+func (u *budgetUsage) measured() bool {
+	return u.budget.MaxTokens > 0 || u.budget.MaxCost > 0
+}
+
+// totals returns the totals of the run: those of the responses reported by
+// the agent, if any, or those added to the accumulator during the run.
+func (u *budgetUsage) totals() cost.Totals {
+	if reported := u.reported.Totals(); reported.Requests > 0 {
+		return reported
+	}
+	totals := u.acc.Totals()
+	return cost.Totals{
+		Requests: totals.Requests - u.initial.Requests,
+		Unpriced: totals.Unpriced - u.initial.Unpriced,
+		Usage:    llms.Usage{TotalTokens: totals.Usage.TotalTokens - u.initial.Usage.TotalTokens},
+		Cost:     totals.Cost - u.initial.Cost,
+	}
+}
+
+// check returns an error wrapping ErrBudgetExceeded when the run has used its
+// tokens, cost or time, or ErrUsageNotReported when they cannot be measured.
+func (u *budgetUsage) check() error {
+	if u.budget.MaxDuration > 0 {
+		if elapsed := time.Since(u.start); elapsed >= u.budget.MaxDuration {
+			return fmt.Errorf("%w: ran for %s of %s", ErrBudgetExceeded, elapsed.Round(time.Millisecond), u.budget.MaxDuration)
+		}
+	}
+	if !u.measured() {
+		return nil
+	}
+
+	totals := u.totals()
+	if u.planned && totals.Requests == 0 && totals.Usage.TotalTokens == 0 && totals.Cost == 0 {
+		return fmt.Errorf("%w: the budget limits tokens or cost, but the agent reports no responses; "+
+			"set a cost.Handler as the callbacks handler of its model", ErrUsageNotReported)
+	}
+	if u.budget.MaxCost > 0 && totals.Unpriced > 0 {
+		return fmt.Errorf("%w: the cost of %d responses is unknown", ErrUsageNotReported, totals.Unpriced)
+	}
+	tokens := totals.Usage.TotalTokens
+	if u.budget.MaxTokens > 0 && tokens >= u.budget.MaxTokens {
+		return fmt.Errorf("%w: used %d tokens of %d", ErrBudgetExceeded, tokens, u.budget.MaxTokens)
+	}
+	if u.budget.MaxCost > 0 && totals.Cost >= u.budget.MaxCost {
+		return fmt.Errorf("%w: spent $%.4f of $%.4f", ErrBudgetExceeded, totals.Cost, u.budget.MaxCost)
+	}
+	return nil
+}
+
+// checkToolCalls returns an error wrapping ErrBudgetExceeded when the actions
+// of a plan call a tool more times than allowed, counting the calls of the
+// steps taken.
+func (b Budget) checkToolCalls(steps []schema.AgentStep, actions []schema.AgentAction) error {
+	if len(b.MaxToolCalls) == 0 {
+		return nil
+	}
+
+	calls := make(map[string]int)
+	for _, step := range steps {
+		calls[strings.ToUpper(step.Action.Tool)]++
+	}
+	for _, action := range actions {
+		calls[strings.ToUpper(action.Tool)]++
+	}
+	for name, limit := range b.MaxToolCalls {
+		if n := calls[strings.ToUpper(name)]; n > limit {
+			return fmt.Errorf("%w: %d calls of tool %s of %d", ErrBudgetExceeded, n, name, limit)
+		}
+	}
+	return nil
+}
+
+// stop ends a run stopped before the agent finished. When the budget forces a
+// final answer and the agent can give one, the run finishes with it. The run
+// fails with cause otherwise.
+func (e *Executor) stop(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	cause error,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
+	answerer, ok := e.Agent.(FinalAnswerer)
+	if !e.Budget.ForceFinalAnswer || !ok {
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleAgentFinish(ctx, schema.AgentFinish{
+				ReturnValues: map[string]any{"output": cause.Error()},
+			})
+		}
+		if err := e.deleteCheckpoint(ctx); err != nil {
+			return nil, err
+		}
+		return e.getReturn(
+			&schema.AgentFinish{ReturnValues: make(map[string]any)},
+			steps,
+		), cause
+	}
+
+	finish, err := answerer.FinalAnswer(ctx, steps, inputs, options...)
+	if err != nil {
+		return nil, fmt.Errorf("final answer after %w: %w", cause, err)
+	}
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleAgentFinish(ctx, *finish)
+	}
+	return e.getReturn(finish, steps), e.deleteCheckpoint(ctx)
+}
+
+// finalAnswerText returns the answer in the output of a text agent asked for
+// a final answer, which follows the last answer prefix if the model repeated
+// it.
+func finalAnswerText(output, prefix string) string {
+	if i := strings.LastIndex(output, prefix); i >= 0 {
+		output = output[i+len(prefix):]
+	}
+	return strings.TrimSpace(output)
+}
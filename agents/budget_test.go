@@ -0,0 +1,266 @@
+package agents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/cost"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// newPricedAgent returns a counting agent whose plans use tokens, as a
+// cost.Handler of its model would report them.
+func newPricedAgent(ts ...tools.Tool) *testAgent {
+	agent := newCountingAgent(ts...)
+	count := agent.plan
+	agent.plan = func(ctx context.Context, intermediateSteps []schema.AgentStep, inputs map[string]string) ([]schema.AgentAction, *schema.AgentFinish, error) { //nolint:lll
+		if acc := cost.FromContext(ctx); acc != nil {
+			acc.Add(cost.Totals{Requests: 1, Usage: llms.Usage{TotalTokens: 100}, Cost: 0.01})
+		}
+		return count(ctx, intermediateSteps, inputs)
+	}
+	return agent
+}
+
+func TestExecutorBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		budget  agents.Budget
+		calls   int
+		wantErr string
+	}{
+		{
+			name:    "tool calls",
+			budget:  agents.Budget{MaxToolCalls: map[string]int{"Search": 2}},
+			calls:   2,
+			wantErr: "3 calls of tool Search of 2",
+		},
+		{
+			name:    "tokens",
+			budget:  agents.Budget{MaxTokens: 150},
+			calls:   2,
+			wantErr: "used 200 tokens of 150",
+		},
+		{
+			name:    "cost",
+			budget:  agents.Budget{MaxCost: 0.01},
+			calls:   1,
+			wantErr: "spent $0.0100 of $0.0100",
+		},
+		{
+			name:    "duration",
+			budget:  agents.Budget{MaxDuration: 1},
+			wantErr: "ran for",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			search := &crashingTool{}
+			agent := newPricedAgent(search)
+			executor := agents.NewExecutor(agent,
+				agents.WithBudget(tc.budget),
+				agents.WithReturnIntermediateSteps(),
+			)
+			out, err := chains.Call(context.Background(), executor, map[string]any{"input": "foo"})
+			require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+			require.ErrorContains(t, err, tc.wantErr)
+			require.Equal(t, tc.calls, search.calls)
+			require.Len(t, out["intermediateSteps"], tc.calls)
+		})
+	}
+}
+
+func TestExecutorBudgetContextAccumulator(t *testing.T) {
+	t.Parallel()
+
+	// The tokens used before the run do not count.
+	ctx, acc := cost.WithAccumulator(context.Background())
+	acc.Add(cost.Totals{Usage: llms.Usage{TotalTokens: 1000}})
+
+	agent := newPricedAgent(&crashingTool{})
+	executor := agents.NewExecutor(agent, agents.WithBudget(agents.Budget{MaxTokens: 500}))
+	out, err := chains.Run(ctx, executor, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "3 steps", out)
+	require.Equal(t, 1400, acc.Totals().Usage.TotalTokens)
+}
+
+func TestExecutorBudgetReportedUsage(t *testing.T) {
+	t.Parallel()
+
+	searchCall := func(id string) *llms.ContentResponse {
+		return &llms.ContentResponse{
+			Choices: []*llms.ContentChoice{{
+				ToolCalls: []llms.ToolCall{toolCallPart(id, "search", `{"__arg1": "a"}`)},
+				Usage:     llms.NewUsage(60, 40),
+			}},
+			Model: "small",
+		}
+	}
+	prices := cost.NewTable("test", map[string]cost.Price{"small": {Input: 1000, Output: 1000}})
+
+	tests := []struct {
+		name    string
+		budget  agents.Budget
+		calls   int
+		wantErr string
+	}{
+		{
+			name:    "tokens",
+			budget:  agents.Budget{MaxTokens: 150},
+			calls:   2,
+			wantErr: "used 200 tokens of 150",
+		},
+		{
+			name:    "cost",
+			budget:  agents.Budget{MaxCost: 0.15, Prices: prices},
+			calls:   2,
+			wantErr: "spent $0.2000 of $0.1500",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// No cost.Handler is set: the agent reports its responses.
+			search := &recordingTool{name: "search"}
+			model := &toolCallingModel{responses: []*llms.ContentResponse{searchCall("1"), searchCall("2"), searchCall("3")}}
+			executor := agents.NewExecutor(agents.NewToolCallingAgent(model, []tools.Tool{search}),
+				agents.WithBudget(tc.budget))
+			_, err := chains.Run(context.Background(), executor, "foo")
+			require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+			require.ErrorContains(t, err, tc.wantErr)
+			require.Len(t, search.inputs, tc.calls)
+		})
+	}
+}
+
+func TestExecutorBudgetUsageNotReported(t *testing.T) {
+	t.Parallel()
+
+	// The agent does not report its responses and no cost.Handler adds them.
+	executor := agents.NewExecutor(newCountingAgent(&crashingTool{}),
+		agents.WithBudget(agents.Budget{MaxTokens: 1000}))
+	_, err := chains.Run(context.Background(), executor, "foo")
+	require.ErrorIs(t, err, agents.ErrUsageNotReported)
+
+	// The model has no price.
+	model := &toolCallingModel{responses: []*llms.ContentResponse{{
+		Choices: []*llms.ContentChoice{{
+			ToolCalls: []llms.ToolCall{toolCallPart("1", "search", `{"__arg1": "a"}`)},
+			Usage:     llms.NewUsage(60, 40),
+		}},
+		Model: "unknown",
+	}}}
+	executor = agents.NewExecutor(agents.NewToolCallingAgent(model, []tools.Tool{&recordingTool{name: "search"}}),
+		agents.WithBudget(agents.Budget{MaxCost: 1}))
+	_, err = chains.Run(context.Background(), executor, "foo")
+	require.ErrorIs(t, err, agents.ErrUsageNotReported)
+	require.ErrorContains(t, err, "the cost of 1 responses is unknown")
+}
+
+func TestExecutorForceFinalAnswer(t *testing.T) {
+	t.Parallel()
+
+	search := &recordingTool{name: "search"}
+	model := &toolCallingModel{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("1", "search", `{"__arg1": "a"}`)}}}},
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("2", "search", `{"__arg1": "a"}`)}}}},
+		{Choices: []*llms.ContentChoice{{Content: "Search keeps failing, the answer is unknown."}}},
+	}}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(model, []tools.Tool{search}),
+		agents.WithBudget(agents.Budget{MaxToolCalls: map[string]int{"search": 1}, ForceFinalAnswer: true}))
+
+	out, err := chains.Run(context.Background(), executor, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "Search keeps failing, the answer is unknown.", out)
+	require.Equal(t, []string{"a"}, search.inputs)
+
+	require.Equal(t, "none", model.options[2].ToolChoice)
+	require.Len(t, model.options[2].Tools, 1)
+	messages := model.messages[2]
+	require.Len(t, messages, 5)
+	require.Equal(t, llms.ChatMessageTypeHuman, messages[4].Role)
+}
+
+func TestExecutorForceFinalAnswerMaxIterations(t *testing.T) {
+	t.Parallel()
+
+	action := llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		Content: "Thought: I should search.\nAction: search\nAction Input: a",
+	}}}
+	model := &toolCallingModel{responses: []*llms.ContentResponse{&action, &action}}
+	agent := agents.NewOneShotAgent(model, []tools.Tool{&recordingTool{name: "search"}})
+
+	executor := agents.NewExecutor(agent, agents.WithMaxIterations(2))
+	_, err := chains.Run(context.Background(), executor, "foo")
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+
+	model.responses = []*llms.ContentResponse{
+		&action,
+		&action,
+		{Choices: []*llms.ContentChoice{{Content: " It is unknown."}}},
+	}
+	model.messages = nil
+	executor = agents.NewExecutor(agent, agents.WithMaxIterations(2),
+		agents.WithBudget(agents.Budget{ForceFinalAnswer: true}))
+	out, err := chains.Run(context.Background(), executor, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "It is unknown.", out)
+
+	prompt := model.messages[2][0].Parts[0].(llms.TextContent).Text
+	require.True(t, strings.HasSuffix(prompt, "Final Answer:"), prompt)
+}
+
+// hangingTool blocks the calls after the first one until their context is
+// done.
+type hangingTool struct {
+	calls int
+}
+
+func (t *hangingTool) Name() string        { return "search" }
+func (t *hangingTool) Description() string { return "Searches." }
+
+func (t *hangingTool) Call(ctx context.Context, input string) (string, error) {
+	t.calls++
+	if t.calls > 1 {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	return "result " + input, nil
+}
+
+func TestExecutorBudgetMaxDurationCancelsCalls(t *testing.T) {
+	t.Parallel()
+
+	executor := agents.NewExecutor(newCountingAgent(&hangingTool{}),
+		agents.WithBudget(agents.Budget{MaxDuration: 50 * time.Millisecond}),
+		agents.WithReturnIntermediateSteps(),
+	)
+	out, err := chains.Call(context.Background(), executor, map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+	require.NotErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, out["intermediateSteps"], 1)
+
+	model := &toolCallingModel{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("1", "search", `{"__arg1": "a"}`)}}}},
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("2", "search", `{"__arg1": "b"}`)}}}},
+		{Choices: []*llms.ContentChoice{{Content: "The answer is a."}}},
+	}}
+	executor = agents.NewExecutor(agents.NewToolCallingAgent(model, []tools.Tool{&hangingTool{}}),
+		agents.WithBudget(agents.Budget{MaxDuration: 50 * time.Millisecond, ForceFinalAnswer: true}))
+	answer, err := chains.Run(context.Background(), executor, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "The answer is a.", answer)
+}
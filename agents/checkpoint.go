@@ -0,0 +1,201 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmc/langchaingo/internal/runstore"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrCheckpointNotFound is returned by checkpointers when there is no checkpoint for a run. It is
+// also graph.ErrCheckpointNotFound.
+var ErrCheckpointNotFound = runstore.ErrNotFound
+
+// Checkpoint is a snapshot of an agent run, saved by the executor after every iteration.
+type Checkpoint struct {
+	// RunID identifies the run.
+	RunID string `json:"run_id"`
+	// Inputs are the inputs of the run, without the memory variables.
+	Inputs map[string]string `json:"inputs"`
+	// Memory holds the memory variables loaded for the run.
+	Memory map[string]string `json:"memory,omitempty"`
+	// Steps are the steps taken so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// Iteration is the number of iterations done.
+	Iteration int `json:"iteration"`
+	// Interrupt is the state of the run when it is interrupted for approval.
+	Interrupt *Interrupt `json:"interrupt,omitempty"`
+	// Time is the time of the checkpoint.
+	Time time.Time `json:"time"`
+}
+
+// Checkpointer stores the checkpoints of agent runs, so that runs can be resumed by another
+// process. Checkpointers must be safe for concurrent use.
+type Checkpointer interface {
+	// Save saves the checkpoint of a run, replacing the previous one.
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+	// Load returns the checkpoint of a run, or ErrCheckpointNotFound.
+	Load(ctx context.Context, runID string) (*Checkpoint, error)
+	// Delete deletes the checkpoint of a run. Deleting a missing checkpoint is not an error.
+	Delete(ctx context.Context, runID string) error
+}
+
+// ContextWithRunID returns a context identifying the run of an executor. Executors with a
+// Checkpointer save the checkpoints of the run under this ID, and resume the run from its
+// checkpoint when it has one. It is the same ID as with graph.ContextWithRunID.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return runstore.ContextWithRunID(ctx, runID)
+}
+
+// RunIDFromContext returns the ID of the run set with ContextWithRunID.
+func RunIDFromContext(ctx context.Context) string {
+	return runstore.RunIDFromContext(ctx)
+}
+
+// checkpoint returns the checkpoint to resume the run of the context from, if any.
+func (e *Executor) checkpoint(ctx context.Context) (*Checkpoint, error) {
+	runID := RunIDFromContext(ctx)
+	if e.Checkpointer == nil || runID == "" {
+		return nil, nil
+	}
+	checkpoint, err := e.Checkpointer.Load(ctx, runID)
+	if errors.Is(err, ErrCheckpointNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// saveCheckpoint saves the checkpoint of the run of the context.
+func (e *Executor) saveCheckpoint(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	iteration int,
+	interrupt *Interrupt,
+) error {
+	runID := RunIDFromContext(ctx)
+	if e.Checkpointer == nil || runID == "" {
+		return nil
+	}
+
+	checkpoint := &Checkpoint{
+		RunID:     runID,
+		Inputs:    make(map[string]string, len(inputs)),
+		Steps:     steps,
+		Iteration: iteration,
+		Interrupt: interrupt,
+		Time:      time.Now(),
+	}
+	memoryKeys := make(map[string]bool)
+	if e.Memory != nil {
+		for _, key := range e.Memory.MemoryVariables(ctx) {
+			memoryKeys[key] = true
+		}
+	}
+	for key, value := range inputs {
+		if !memoryKeys[key] {
+			checkpoint.Inputs[key] = value
+			continue
+		}
+		if checkpoint.Memory == nil {
+			checkpoint.Memory = make(map[string]string)
+		}
+		checkpoint.Memory[key] = value
+	}
+
+	if err := e.Checkpointer.Save(ctx, checkpoint); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// deleteCheckpoint deletes the checkpoint of the run of the context once it is done.
+func (e *Executor) deleteCheckpoint(ctx context.Context) error {
+	runID := RunIDFromContext(ctx)
+	if e.Checkpointer == nil || runID == "" {
+		return nil
+	}
+	if err := e.Checkpointer.Delete(ctx, runID); err != nil {
+		return fmt.Errorf("delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// runInputs returns the inputs of the run of a checkpoint, with the memory variables.
+func (c *Checkpoint) runInputs() map[string]string {
+	inputs := make(map[string]string, len(c.Inputs)+len(c.Memory))
+	for key, value := range c.Inputs {
+		inputs[key] = value
+	}
+	for key, value := range c.Memory {
+		inputs[key] = value
+	}
+	return inputs
+}
+
+// MemoryCheckpointer is a Checkpointer keeping checkpoints in memory.
+type MemoryCheckpointer struct {
+	store *runstore.Memory[Checkpoint]
+}
+
+var _ Checkpointer = (*MemoryCheckpointer)(nil)
+
+// NewMemoryCheckpointer creates a new MemoryCheckpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{store: runstore.NewMemory[Checkpoint]()}
+}
+
+// Save saves the checkpoint of a run.
+func (m *MemoryCheckpointer) Save(_ context.Context, checkpoint *Checkpoint) error {
+	return m.store.Save(checkpoint.RunID, checkpoint)
+}
+
+// Load returns the checkpoint of a run.
+func (m *MemoryCheckpointer) Load(_ context.Context, runID string) (*Checkpoint, error) {
+	return m.store.Load(runID)
+}
+
+// Delete deletes the checkpoint of a run.
+func (m *MemoryCheckpointer) Delete(_ context.Context, runID string) error {
+	m.store.Delete(runID)
+	return nil
+}
+
+// FileCheckpointer is a Checkpointer keeping checkpoints as JSON files in a directory.
+type FileCheckpointer struct {
+	store runstore.File[Checkpoint]
+}
+
+var _ Checkpointer = FileCheckpointer{}
+
+// NewFileCheckpointer creates a new FileCheckpointer keeping checkpoints in dir, which is created
+// if needed.
+func NewFileCheckpointer(dir string) (FileCheckpointer, error) {
+	store, err := runstore.NewFile[Checkpoint](dir)
+	if err != nil {
+		return FileCheckpointer{}, err
+	}
+	return FileCheckpointer{store: store}, nil
+}
+
+// Save saves the checkpoint of a run. The file is replaced atomically, so that a crash does not
+// leave a partial checkpoint.
+func (f FileCheckpointer) Save(_ context.Context, checkpoint *Checkpoint) error {
+	return f.store.Save(checkpoint.RunID, checkpoint)
+}
+
+// Load returns the checkpoint of a run.
+func (f FileCheckpointer) Load(_ context.Context, runID string) (*Checkpoint, error) {
+	return f.store.Load(runID)
+}
+
+// Delete deletes the checkpoint of a run.
+func (f FileCheckpointer) Delete(_ context.Context, runID string) error {
+	return f.store.Delete(runID)
+}
@@ -0,0 +1,143 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func TestCheckpointers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	file, err := agents.NewFileCheckpointer(t.TempDir())
+	require.NoError(t, err)
+
+	for name, c := range map[string]agents.Checkpointer{
+		"memory": agents.NewMemoryCheckpointer(),
+		"file":   file,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := c.Load(ctx, "run/1")
+			require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+
+			checkpoint := &agents.Checkpoint{
+				RunID:     "run/1",
+				Inputs:    map[string]string{"input": "foo"},
+				Steps:     []schema.AgentStep{{Action: schema.AgentAction{Tool: "search"}, Observation: "bar"}},
+				Iteration: 1,
+			}
+			require.NoError(t, c.Save(ctx, checkpoint))
+			got, err := c.Load(ctx, "run/1")
+			require.NoError(t, err)
+			require.Equal(t, checkpoint.Steps, got.Steps)
+			require.Equal(t, 1, got.Iteration)
+
+			require.NoError(t, c.Delete(ctx, "run/1"))
+			require.NoError(t, c.Delete(ctx, "run/1"))
+			_, err = c.Load(ctx, "run/1")
+			require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+		})
+	}
+}
+
+// newCountingAgent returns an agent searching three times, then finishing
+// with the number of steps.
+func newCountingAgent(ts ...tools.Tool) *testAgent {
+	return &testAgent{
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+		tools:      ts,
+		plan: func(_ context.Context, intermediateSteps []schema.AgentStep, _ map[string]string) ([]schema.AgentAction, *schema.AgentFinish, error) { //nolint:lll
+			if len(intermediateSteps) < 3 {
+				return []schema.AgentAction{{Tool: "search", ToolInput: fmt.Sprint(len(intermediateSteps))}}, nil, nil
+			}
+			output := fmt.Sprintf("%d steps", len(intermediateSteps))
+			return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": output}}, nil
+		},
+	}
+}
+
+// crashingTool fails the call with the given number.
+type crashingTool struct {
+	calls   int
+	crashAt int
+}
+
+func (t *crashingTool) Name() string        { return "search" }
+func (t *crashingTool) Description() string { return "Searches." }
+
+func (t *crashingTool) Call(_ context.Context, input string) (string, error) {
+	t.calls++
+	if t.calls == t.crashAt {
+		return "", errors.New("worker stopped")
+	}
+	return "result " + input, nil
+}
+
+func TestExecutorCheckpoint(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+	checkpointer := agents.NewMemoryCheckpointer()
+
+	// The worker stops during the second iteration.
+	agent := newCountingAgent(&crashingTool{crashAt: 2})
+	executor := agents.NewExecutor(agent, agents.WithCheckpointer(checkpointer), agents.WithMaxIterations(4))
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "foo"})
+	require.ErrorContains(t, err, "worker stopped")
+
+	checkpoint, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, 1, checkpoint.Iteration)
+	require.Equal(t, map[string]string{"input": "foo"}, checkpoint.Inputs)
+	require.Len(t, checkpoint.Steps, 1)
+
+	// Another worker resumes the run from the second iteration.
+	agent = newCountingAgent(&crashingTool{})
+	executor = agents.NewExecutor(agent, agents.WithCheckpointer(checkpointer), agents.WithMaxIterations(4))
+	out, err := chains.Run(ctx, executor, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "3 steps", out)
+	require.Equal(t, 3, agent.numPlanCalls)
+
+	_, err = checkpointer.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
+
+func TestExecutorCheckpointInterrupt(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+	checkpointer := agents.NewMemoryCheckpointer()
+	newExecutor := func() *agents.Executor {
+		return agents.NewExecutor(newCountingAgent(&crashingTool{}),
+			agents.WithCheckpointer(checkpointer),
+			agents.WithApproval(func(action schema.AgentAction) bool { return action.ToolInput == "1" }),
+		)
+	}
+
+	_, err := chains.Call(ctx, newExecutor(), map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrInterrupted)
+
+	// The interrupted run stays interrupted in another process, which
+	// resumes it from the checkpoint.
+	_, err = chains.Call(ctx, newExecutor(), map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrInterrupted)
+	checkpoint, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint.Interrupt)
+
+	out, err := newExecutor().Resume(ctx, checkpoint.Interrupt, []agents.Decision{agents.Approve()})
+	require.NoError(t, err)
+	require.Equal(t, "3 steps", out["output"])
+	_, err = checkpointer.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
@@ -38,7 +38,15 @@ type ConversationalAgent struct {
 	CallbacksHandler callbacks.Handler
 }
 
-var _ Agent = (*ConversationalAgent)(nil)
+var (
+	_ Agent         = (*ConversationalAgent)(nil)
+	_ FinalAnswerer = (*ConversationalAgent)(nil)
+)
+
+// _conversationalFinalAnswerThought ends the scratchpad when the agent is
+// asked for a final answer.
+const _conversationalFinalAnswerThought = " Do I need to use a tool? No, I cannot use tools anymore.\n" +
+	_conversationalFinalAnswerAction
 
 func NewConversationalAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ConversationalAgent {
 	options := conversationalDefaultOptions()
@@ -65,12 +73,47 @@ func (a *ConversationalAgent) Plan(
 	inputs map[string]string,
 	options ...chains.ChainCallOption,
 ) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	output, err := a.predict(ctx, inputs, constructScratchPad(intermediateSteps), options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.parseOutput(output)
+}
+
+// FinalAnswer asks the model for a final answer with the steps taken so far,
+// without using tools.
+func (a *ConversationalAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) (*schema.AgentFinish, error) {
+	scratchPad := constructScratchPad(intermediateSteps) + _conversationalFinalAnswerThought
+	output, err := a.predict(ctx, inputs, scratchPad, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{a.OutputKey: finalAnswerText(output, _conversationalFinalAnswerAction)},
+		Log:          output,
+	}, nil
+}
+
+// predict runs the chain of the agent with the inputs and scratchpad.
+func (a *ConversationalAgent) predict(
+	ctx context.Context,
+	inputs map[string]string,
+	scratchPad string,
+	options ...chains.ChainCallOption,
+) (string, error) {
 	fullInputs := make(map[string]any, len(inputs))
 	for key, value := range inputs {
 		fullInputs[key] = value
 	}
 
-	fullInputs["agent_scratchpad"] = constructScratchPad(intermediateSteps)
+	fullInputs["agent_scratchpad"] = scratchPad
 
 	var stream func(ctx context.Context, chunk []byte) error
 
@@ -95,10 +138,10 @@ func (a *ConversationalAgent) Plan(
 		predictOptions...,
 	)
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
 
-	return a.parseOutput(output)
+	return output, nil
 }
 
 func (a *ConversationalAgent) GetInputKeys() []string {
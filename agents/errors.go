@@ -1,6 +1,12 @@
 package agents
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/schema"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -20,6 +26,11 @@ var (
 	// ErrInvalidChainReturnType is returned if the internal chain of the agent returns a value in the
 	// "text" filed that is not a string.
 	ErrInvalidChainReturnType = errors.New("agent chain did not return a string")
+
+	// ErrStopRun is wrapped by the errors of tools stopping the run of the executor, such as
+	// handoffs to other agents. The executor returns them without handling them with its
+	// ToolErrorHandler.
+	ErrStopRun = errors.New("run stopped by tool")
 )
 
 // ParserErrorHandler is the struct used to handle parse errors from the agent in the executor. If
@@ -38,3 +49,36 @@ func NewParserErrorHandler(formatFunc func(string) string) *ParserErrorHandler {
 		Formatter: formatFunc,
 	}
 }
+
+// ToolErrorHandler is the struct used to handle errors of tool calls in the executor. Without a
+// ToolErrorHandler, the first error of a tool aborts the run. With one, failed calls are retried
+// and the error is then given to the Handle function, which can turn it into an observation so
+// that the agent has the possibility to recover from it.
+type ToolErrorHandler struct {
+	// Retries is the number of times a failed call is retried before its error is handled.
+	Retries int
+	// Handle returns the observation for the error of the call of an action. The run is aborted
+	// when it returns an error. If nil the run is aborted with the error of the tool.
+	Handle func(ctx context.Context, action schema.AgentAction, err error) (string, error)
+}
+
+// NewToolErrorHandler creates a new tool error handler retrying failed calls the given number of
+// times before calling the handle function.
+func NewToolErrorHandler(retries int, handle func(context.Context, schema.AgentAction, error) (string, error)) *ToolErrorHandler { //nolint:lll
+	return &ToolErrorHandler{
+		Retries: retries,
+		Handle:  handle,
+	}
+}
+
+// AbortOnToolError is a handle function for a ToolErrorHandler aborting the run with the error of
+// the tool.
+func AbortOnToolError(_ context.Context, _ schema.AgentAction, err error) (string, error) {
+	return "", err
+}
+
+// ObserveToolError is a handle function for a ToolErrorHandler giving the error of the tool to the
+// agent as the observation.
+func ObserveToolError(_ context.Context, _ schema.AgentAction, err error) (string, error) {
+	return fmt.Sprintf("tool failed: %s", err), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/tools"
+	"golang.org/x/sync/errgroup"
 )
 
 const _intermediateStepsOutputKey = "intermediateSteps"
@@ -20,9 +21,28 @@ type Executor struct {
 	Memory           schema.Memory
 	CallbacksHandler callbacks.Handler
 	ErrorHandler     *ParserErrorHandler
+	// ToolErrorHandler handles the errors of tool calls. The run is aborted
+	// with the first error of a tool when it is nil.
+	ToolErrorHandler *ToolErrorHandler
 
 	MaxIterations           int
 	ReturnIntermediateSteps bool
+	// MaxParallelToolCalls is the maximum number of tool calls of a plan run
+	// concurrently. The actions of a plan are run one at a time when it is
+	// less than 2.
+	MaxParallelToolCalls int
+	// NeedsApproval reports whether an action needs the approval of a human
+	// before its tool is called. The run is interrupted when a plan has such
+	// actions, and continued with Resume.
+	NeedsApproval func(action schema.AgentAction) bool
+	// Checkpointer saves the state of runs identified with ContextWithRunID
+	// after every iteration, and resumes them from it.
+	Checkpointer Checkpointer
+	// Budget limits the tokens, cost, time and tool calls of runs.
+	Budget Budget
+
+	// emit receives the events of runs streamed with Stream.
+	emit func(event Event)
 }
 
 var (
@@ -44,34 +64,94 @@ func NewExecutor(agent Agent, opts ...Option) *Executor {
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
 		ErrorHandler:            options.errorHandler,
+		ToolErrorHandler:        options.toolErrorHandler,
+		MaxParallelToolCalls:    options.maxParallelToolCalls,
+		NeedsApproval:           options.needsApproval,
+		Checkpointer:            options.checkpointer,
+		Budget:                  options.budget,
 	}
 }
 
+// Call runs the agent. When the executor has a Checkpointer and the context a
+// run ID with a checkpoint, the run is resumed from the checkpoint, with its
+// inputs and memory rather than the given ones. A run interrupted for approval
+// returns its InterruptError again until it is resumed with Resume.
 func (e *Executor) Call(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
 	inputs, err := inputsToString(inputValues)
 	if err != nil {
 		return nil, err
 	}
-	nameToTool := getNameToTool(e.Agent.GetTools())
 
-	steps := make([]schema.AgentStep, 0)
-	for i := 0; i < e.MaxIterations; i++ {
+	checkpoint, err := e.checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint != nil {
+		if checkpoint.Interrupt != nil {
+			return nil, &InterruptError{Interrupt: checkpoint.Interrupt}
+		}
+		return e.run(ctx, checkpoint.runInputs(), checkpoint.Steps, checkpoint.Iteration, options...)
+	}
+
+	return e.run(ctx, inputs, make([]schema.AgentStep, 0), 0, options...)
+}
+
+// run runs the iterations of the agent from the given one.
+func (e *Executor) run(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	iteration int,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
+	nameToTool := getNameToTool(e.Agent.GetTools())
+	ctx, budget := e.startBudget(ctx)
+	// The calls of the run use runCtx, so that a run stopped when it reaches
+	// its MaxDuration can still finish with ctx.
+	runCtx, cancel := budget.withDeadline(ctx)
+	defer cancel()
+
+	var err error
+	for i := iteration; i < e.MaxIterations; i++ {
+		if err := expired(runCtx, runCtx.Err()); err != nil {
+			if errors.Is(err, ErrBudgetExceeded) {
+				return e.stop(ctx, inputs, steps, err, options...)
+			}
+			return nil, err
+		}
+		if err := budget.check(); err != nil {
+			if errors.Is(err, ErrUsageNotReported) {
+				return nil, err
+			}
+			return e.stop(ctx, inputs, steps, err, options...)
+		}
 		var finish map[string]any
-		steps, finish, err = e.doIteration(ctx, steps, nameToTool, inputs, options...)
-		if finish != nil || err != nil {
+		e.event(Event{Type: EventPlanStart, Iteration: i})
+		steps, finish, err = e.doIteration(runCtx, steps, nameToTool, inputs, options...)
+		err = expired(runCtx, err)
+		budget.planned = true
+		var interruptErr *InterruptError
+		if errors.As(err, &interruptErr) {
+			interruptErr.Interrupt.Iteration = i
+			if err := e.saveCheckpoint(ctx, inputs, steps, i, interruptErr.Interrupt); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, ErrBudgetExceeded) {
+			return e.stop(ctx, inputs, steps, err, options...)
+		}
+		if err != nil {
 			return finish, err
 		}
+		if finish != nil {
+			return finish, e.deleteCheckpoint(ctx)
+		}
+		if err := e.saveCheckpoint(ctx, inputs, steps, i+1, nil); err != nil {
+			return nil, err
+		}
 	}
 
-	if e.CallbacksHandler != nil {
-		e.CallbacksHandler.HandleAgentFinish(ctx, schema.AgentFinish{
-			ReturnValues: map[string]any{"output": ErrNotFinished.Error()},
-		})
-	}
-	return e.getReturn(
-		&schema.AgentFinish{ReturnValues: make(map[string]any)},
-		steps,
-	), ErrNotFinished
+	return e.stop(ctx, inputs, steps, ErrNotFinished, options...)
 }
 
 func (e *Executor) doIteration( // nolint
@@ -107,14 +187,39 @@ func (e *Executor) doIteration( // nolint
 		return steps, e.getReturn(finish, steps), nil
 	}
 
+	if err := e.Budget.checkToolCalls(steps, actions); err != nil {
+		return steps, nil, err
+	}
+
+	if interrupt := e.approvalInterrupt(inputs, steps, actions); interrupt != nil {
+		return steps, nil, &InterruptError{Interrupt: interrupt}
+	}
+
+	steps, err = e.doActions(ctx, steps, nameToTool, actions)
+	return steps, nil, err
+}
+
+// doActions runs the actions of a plan, concurrently when the executor has
+// MaxParallelToolCalls.
+func (e *Executor) doActions(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+) ([]schema.AgentStep, error) {
+	if e.MaxParallelToolCalls > 1 && len(actions) > 1 {
+		return e.doParallelActions(ctx, steps, nameToTool, actions)
+	}
+
+	var err error
 	for _, action := range actions {
 		steps, err = e.doAction(ctx, steps, nameToTool, action)
 		if err != nil {
-			return steps, nil, err
+			return steps, err
 		}
 	}
 
-	return steps, nil, nil
+	return steps, nil
 }
 
 func (e *Executor) doAction(
@@ -126,24 +231,130 @@ func (e *Executor) doAction(
 	if e.CallbacksHandler != nil {
 		e.CallbacksHandler.HandleAgentAction(ctx, action)
 	}
+	e.event(Event{Type: EventToolCall, Action: &action})
+
+	step, err := e.runAction(ctx, nameToTool, action)
+	if err != nil {
+		return steps, err
+	}
 
+	return append(steps, step), nil
+}
+
+// doParallelActions runs the actions of a plan concurrently, with at most
+// MaxParallelToolCalls tool calls at a time. The calls of tools that are not
+// concurrency safe run one at a time, in order. The steps are added in the
+// order of the actions, and the first error of a tool cancels the other calls.
+func (e *Executor) doParallelActions(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+) ([]schema.AgentStep, error) {
+	if e.CallbacksHandler != nil {
+		for _, action := range actions {
+			e.CallbacksHandler.HandleAgentAction(ctx, action)
+		}
+	}
+	for _, action := range actions {
+		e.event(Event{Type: EventToolCall, Action: &action})
+	}
+
+	results := make([]schema.AgentStep, len(actions))
+	var concurrent, sequential []int
+	for i, action := range actions {
+		tool, ok := nameToTool[strings.ToUpper(action.Tool)]
+		if ok && !tools.IsConcurrencySafe(tool) {
+			sequential = append(sequential, i)
+			continue
+		}
+		concurrent = append(concurrent, i)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(e.MaxParallelToolCalls)
+	if len(sequential) > 0 {
+		g.Go(func() error {
+			for _, i := range sequential {
+				step, err := e.runAction(gctx, nameToTool, actions[i])
+				if err != nil {
+					return err
+				}
+				results[i] = step
+			}
+			return nil
+		})
+	}
+	for _, i := range concurrent {
+		g.Go(func() error {
+			step, err := e.runAction(gctx, nameToTool, actions[i])
+			results[i] = step
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return steps, err
+	}
+
+	return append(steps, results...), nil
+}
+
+// runAction calls the tool of an action and returns the step with its
+// observation. Failed calls are handled by the ToolErrorHandler.
+func (e *Executor) runAction(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	action schema.AgentAction,
+) (schema.AgentStep, error) {
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
 	if !ok {
-		return append(steps, schema.AgentStep{
+		observation := fmt.Sprintf("%s is not a valid tool, try another one", action.Tool)
+		e.event(Event{Type: EventToolResult, Action: &action, Observation: observation})
+		return schema.AgentStep{
 			Action:      action,
-			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+			Observation: observation,
+		}, nil
 	}
 
-	observation, err := tool.Call(ctx, strings.TrimSuffix(action.ToolInput, "\nObservation:"))
+	input := strings.TrimSuffix(action.ToolInput, "\nObservation:")
+	observation, err := e.callTool(ctx, tool, input)
+	if err != nil && e.ToolErrorHandler != nil && !errors.Is(err, ErrStopRun) {
+		for i := 0; i < e.ToolErrorHandler.Retries && err != nil && ctx.Err() == nil; i++ {
+			observation, err = e.callTool(ctx, tool, input)
+		}
+		// Errors of the context abort the run, as the agent cannot recover
+		// from them.
+		if err != nil && ctx.Err() == nil && e.ToolErrorHandler.Handle != nil {
+			observation, err = e.ToolErrorHandler.Handle(ctx, action, err)
+		}
+	}
 	if err != nil {
-		return nil, err
+		return schema.AgentStep{}, err
 	}
+	e.event(Event{Type: EventToolResult, Action: &action, Observation: observation})
 
-	return append(steps, schema.AgentStep{
+	return schema.AgentStep{
 		Action:      action,
 		Observation: observation,
-	}), nil
+	}, nil
+}
+
+// callTool calls a tool, firing the tool callbacks.
+func (e *Executor) callTool(ctx context.Context, tool tools.Tool, input string) (string, error) {
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleToolStart(ctx, input)
+	}
+
+	output, err := tool.Call(ctx, input)
+
+	if e.CallbacksHandler != nil {
+		if err != nil {
+			e.CallbacksHandler.HandleToolError(ctx, err)
+		} else {
+			e.CallbacksHandler.HandleToolEnd(ctx, output)
+		}
+	}
+	return output, err
 }
 
 func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
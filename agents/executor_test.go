@@ -2,12 +2,18 @@ package agents_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/internal/httprr"
 	"github.com/tmc/langchaingo/llms/openai"
@@ -24,6 +30,8 @@ type testAgent struct {
 	inputKeys  []string
 	outputKeys []string
 	tools      []tools.Tool
+	// plan, if set, returns the plans instead of actions, finish and err.
+	plan func(ctx context.Context, intermediateSteps []schema.AgentStep, inputs map[string]string) ([]schema.AgentAction, *schema.AgentFinish, error) //nolint:lll
 
 	recordedIntermediateSteps []schema.AgentStep
 	recordedInputs            map[string]string
@@ -31,7 +39,7 @@ type testAgent struct {
 }
 
 func (a *testAgent) Plan(
-	_ context.Context,
+	ctx context.Context,
 	intermediateSteps []schema.AgentStep,
 	inputs map[string]string,
 	_ ...chains.ChainCallOption,
@@ -40,6 +48,9 @@ func (a *testAgent) Plan(
 	a.recordedInputs = inputs
 	a.numPlanCalls++
 
+	if a.plan != nil {
+		return a.plan(ctx, intermediateSteps, inputs)
+	}
 	return a.actions, a.finish, a.err
 }
 
@@ -245,3 +256,147 @@ func TestExecutorTrimsObservationSuffix(t *testing.T) {
 	// Verify that the tool received the input with "\nObservation:" trimmed off
 	require.Equal(t, "test input", receivedInput, "Tool should receive input with \\nObservation: suffix trimmed")
 }
+
+// concurrencyTool records the maximum number of its calls in flight.
+type concurrencyTool struct {
+	name     string
+	inFlight *atomic.Int32
+	max      *atomic.Int32
+}
+
+func (t concurrencyTool) Name() string        { return t.name }
+func (t concurrencyTool) Description() string { return "Records concurrent calls." }
+
+func (t concurrencyTool) Call(_ context.Context, input string) (string, error) {
+	n := t.inFlight.Add(1)
+	defer t.inFlight.Add(-1)
+	for {
+		m := t.max.Load()
+		if n <= m || t.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return t.name + ": " + input, nil
+}
+
+func TestExecutorParallelToolCalls(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight, unsafeInFlight, maxUnsafeInFlight atomic.Int32
+	search := concurrencyTool{name: "search", inFlight: &inFlight, max: &maxInFlight}
+	counter := tools.Sequential(concurrencyTool{name: "counter", inFlight: &unsafeInFlight, max: &maxUnsafeInFlight})
+
+	var actions []schema.AgentAction
+	for _, tool := range []string{"search", "counter", "search", "search", "counter", "search"} {
+		actions = append(actions, schema.AgentAction{Tool: tool, ToolInput: strconv.Itoa(len(actions))})
+	}
+	a := &testAgent{actions: actions, tools: []tools.Tool{search, counter}}
+	executor := agents.NewExecutor(a,
+		agents.WithMaxIterations(1),
+		agents.WithReturnIntermediateSteps(),
+		agents.WithParallelToolCalls(3),
+	)
+
+	out, err := chains.Call(context.Background(), executor, nil)
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	steps, ok := out["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, len(actions))
+	for i, step := range steps {
+		require.Equal(t, actions[i], step.Action)
+		require.Equal(t, actions[i].Tool+": "+strconv.Itoa(i), step.Observation)
+	}
+	// One worker runs the calls of the unsafe tool, the others the searches.
+	require.Equal(t, int32(2), maxInFlight.Load())
+	require.Equal(t, int32(1), maxUnsafeInFlight.Load())
+}
+
+// flakyTool fails its first calls.
+type flakyTool struct {
+	failures int
+	calls    int
+}
+
+func (t *flakyTool) Name() string        { return "flaky" }
+func (t *flakyTool) Description() string { return "Fails its first calls." }
+
+func (t *flakyTool) Call(_ context.Context, input string) (string, error) {
+	t.calls++
+	if t.calls <= t.failures {
+		return "", errors.New("connection reset")
+	}
+	return "ok: " + input, nil
+}
+
+// toolEventsHandler records the tool callbacks.
+type toolEventsHandler struct {
+	callbacks.SimpleHandler
+	events []string
+}
+
+func (h *toolEventsHandler) HandleToolStart(_ context.Context, input string) {
+	h.events = append(h.events, "start "+input)
+}
+
+func (h *toolEventsHandler) HandleToolEnd(_ context.Context, output string) {
+	h.events = append(h.events, "end "+output)
+}
+
+func (h *toolEventsHandler) HandleToolError(_ context.Context, err error) {
+	h.events = append(h.events, "error "+err.Error())
+}
+
+func TestExecutorToolErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	run := func(tool *flakyTool, opts ...agents.Option) ([]schema.AgentStep, *toolEventsHandler, error) {
+		handler := &toolEventsHandler{}
+		a := &testAgent{
+			actions: []schema.AgentAction{{Tool: "flaky", ToolInput: "x"}},
+			tools:   []tools.Tool{tool},
+		}
+		opts = append(opts,
+			agents.WithMaxIterations(1),
+			agents.WithReturnIntermediateSteps(),
+			agents.WithCallbacksHandler(handler),
+		)
+		out, err := chains.Call(context.Background(), agents.NewExecutor(a, opts...), nil)
+		steps, _ := out["intermediateSteps"].([]schema.AgentStep)
+		return steps, handler, err
+	}
+
+	// Without a handler the error aborts the run.
+	_, handler, err := run(&flakyTool{failures: 1})
+	require.ErrorContains(t, err, "connection reset")
+	require.Equal(t, []string{"start x", "error connection reset"}, handler.events)
+
+	// Retries.
+	steps, handler, err := run(&flakyTool{failures: 2},
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(2, agents.AbortOnToolError)))
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.Equal(t, "ok: x", steps[0].Observation)
+	require.Equal(t, []string{
+		"start x", "error connection reset", "start x", "error connection reset", "start x", "end ok: x",
+	}, handler.events)
+
+	tool := &flakyTool{failures: 3}
+	_, _, err = run(tool, agents.WithToolErrorHandler(agents.NewToolErrorHandler(1, agents.AbortOnToolError)))
+	require.ErrorContains(t, err, "connection reset")
+	require.Equal(t, 2, tool.calls)
+
+	// Errors given as observations.
+	steps, _, err = run(&flakyTool{failures: 1},
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(0, agents.ObserveToolError)))
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.Equal(t, "tool failed: connection reset", steps[0].Observation)
+
+	// Custom handler.
+	steps, _, err = run(&flakyTool{failures: 1},
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(0,
+			func(_ context.Context, action schema.AgentAction, err error) (string, error) {
+				return fmt.Sprintf("%s(%s) failed: %s", action.Tool, action.ToolInput, err), nil
+			})))
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.Equal(t, "flaky(x) failed: connection reset", steps[0].Observation)
+}
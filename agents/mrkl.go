@@ -35,7 +35,15 @@ type OneShotZeroAgent struct {
 	CallbacksHandler callbacks.Handler
 }
 
-var _ Agent = (*OneShotZeroAgent)(nil)
+var (
+	_ Agent         = (*OneShotZeroAgent)(nil)
+	_ FinalAnswerer = (*OneShotZeroAgent)(nil)
+)
+
+// _mrklFinalAnswerThought ends the scratchpad when the agent is asked for a
+// final answer.
+const _mrklFinalAnswerThought = "Thought: I cannot use tools anymore, " +
+	"so I give my best final answer with what I know.\n" + _finalAnswerAction
 
 // NewOneShotAgent creates a new OneShotZeroAgent with the given LLM model, tools,
 // and options. It returns a pointer to the created agent. The opts parameter
@@ -65,12 +73,47 @@ func (a *OneShotZeroAgent) Plan(
 	inputs map[string]string,
 	options ...chains.ChainCallOption,
 ) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	output, err := a.predict(ctx, inputs, constructMrklScratchPad(intermediateSteps), options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.parseOutput(output)
+}
+
+// FinalAnswer asks the model for a final answer with the steps taken so far,
+// without using tools.
+func (a *OneShotZeroAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) (*schema.AgentFinish, error) {
+	scratchPad := constructMrklScratchPad(intermediateSteps) + _mrklFinalAnswerThought
+	output, err := a.predict(ctx, inputs, scratchPad, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{a.OutputKey: finalAnswerText(output, _finalAnswerAction)},
+		Log:          output,
+	}, nil
+}
+
+// predict runs the chain of the agent with the inputs and scratchpad.
+func (a *OneShotZeroAgent) predict(
+	ctx context.Context,
+	inputs map[string]string,
+	scratchPad string,
+	options ...chains.ChainCallOption,
+) (string, error) {
 	fullInputs := make(map[string]any, len(inputs))
 	for key, value := range inputs {
 		fullInputs[key] = value
 	}
 
-	fullInputs["agent_scratchpad"] = constructMrklScratchPad(intermediateSteps)
+	fullInputs["agent_scratchpad"] = scratchPad
 
 	var stream func(ctx context.Context, chunk []byte) error
 
@@ -95,10 +138,10 @@ func (a *OneShotZeroAgent) Plan(
 		predictOptions...,
 	)
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
 
-	return a.parseOutput(output)
+	return output, nil
 }
 
 func (a *OneShotZeroAgent) GetInputKeys() []string {
@@ -1,6 +1,7 @@
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -56,10 +57,16 @@ func toolNames(tools []tools.Tool) string {
 	return tn.String()
 }
 
-func toolDescriptions(tools []tools.Tool) string {
+func toolDescriptions(toolList []tools.Tool) string {
 	var ts strings.Builder
-	for _, tool := range tools {
-		ts.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name(), tool.Description()))
+	for _, tool := range toolList {
+		ts.WriteString(fmt.Sprintf("- %s: %s", tool.Name(), tool.Description()))
+		if st, ok := tool.(tools.SchemaTool); ok {
+			if schema, err := json.Marshal(st.Schema()); err == nil {
+				ts.WriteString(fmt.Sprintf(" The input must be JSON matching this schema: %s", schema))
+			}
+		}
+		ts.WriteString("\n")
 	}
 
 	return ts.String()
@@ -0,0 +1,183 @@
+package multiagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const (
+	_defaultMaxHandoffs = 10
+
+	_handoffToolPrefix = "transfer_to_"
+	_agentOutputKey    = "agent"
+)
+
+// HandoffError is returned by the tools of Handoff to stop the run of the agent
+// calling them, so that Handoffs transfers the conversation to the target.
+type HandoffError struct {
+	// Target is the name of the agent the conversation is handed off to.
+	Target string
+	// Message is the message of the agent handing the conversation off.
+	Message string
+}
+
+func (e *HandoffError) Error() string {
+	return "handoff to " + e.Target
+}
+
+// Unwrap returns agents.ErrStopRun, so that executors stop the run.
+func (e *HandoffError) Unwrap() error {
+	return agents.ErrStopRun
+}
+
+// Handoff returns a tool for an agent to hand the conversation off to the
+// agent with the given name in Handoffs. The tool is named transfer_to_ and
+// the name of the target, and its input is a message for the target.
+func Handoff(name, description string) tools.Tool { //nolint:ireturn
+	return handoffTool{target: name, description: description}
+}
+
+type handoffTool struct {
+	target      string
+	description string
+}
+
+var _ tools.Tool = handoffTool{}
+
+func (t handoffTool) Name() string {
+	return _handoffToolPrefix + t.target
+}
+
+func (t handoffTool) Description() string {
+	return fmt.Sprintf("Hands the conversation off to %s. %s "+
+		"The input is a message for it, summarizing the request of the user.", t.target, t.description)
+}
+
+func (t handoffTool) Call(_ context.Context, input string) (string, error) {
+	return "", &HandoffError{Target: t.target, Message: input}
+}
+
+// Handoffs is a chain running agents that hand the conversation off to one
+// another. The first agent runs with the input, and an agent calling a tool
+// returned by Handoff stops for the target to continue with the history of the
+// conversation. The outputs hold the output of the last agent, its name under
+// "agent" and the combined trace of the runs under "trace".
+type Handoffs struct {
+	// Agents are the agents of the run. The first one starts the run.
+	Agents []*Agent
+	// MaxHandoffs is the maximum number of handoffs of a run.
+	MaxHandoffs int
+	// Memory is the memory of the chain. The agents keep their own memory in
+	// their executors.
+	Memory    schema.Memory
+	OutputKey string
+}
+
+var _ chains.Chain = &Handoffs{}
+
+// NewHandoffs creates a new chain of agents handing the conversation off to
+// one another, starting with the first one.
+func NewHandoffs(agents ...*Agent) *Handoffs {
+	return &Handoffs{
+		Agents:      agents,
+		MaxHandoffs: _defaultMaxHandoffs,
+		Memory:      memory.NewSimple(),
+		OutputKey:   "output",
+	}
+}
+
+// Call runs the agents from the first one until one of them answers.
+func (h *Handoffs) Call(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+	input, err := inputString(inputValues)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.Agents) == 0 {
+		return nil, fmt.Errorf("%w: no agents", ErrUnknownAgent)
+	}
+
+	ctx, trace := withTrace(ctx)
+	start := trace.len()
+
+	current, agentInput := h.Agents[0], input
+	for handoffs := 0; ; handoffs++ {
+		outputs, err := current.run(ctx, map[string]any{"input": agentInput}, options...)
+		var handoff *HandoffError
+		if !errors.As(err, &handoff) {
+			if err != nil {
+				return nil, fmt.Errorf("agent %s: %w", current.Name, err)
+			}
+			output, err := current.output(outputs)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{
+				h.OutputKey:     output,
+				_agentOutputKey: current.Name,
+				_traceOutputKey: trace.since(start),
+			}, nil
+		}
+
+		if handoffs >= h.MaxHandoffs {
+			return nil, fmt.Errorf("%w: %d", ErrTooManyHandoffs, handoffs+1)
+		}
+		next := h.agent(handoff.Target)
+		if next == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAgent, handoff.Target)
+		}
+		agentInput = handoffInput(input, trace.since(start), current.Name, next.Name, handoff.Message)
+		current = next
+	}
+}
+
+func (h *Handoffs) agent(name string) *Agent {
+	for _, agent := range h.Agents {
+		if strings.EqualFold(agent.Name, name) {
+			return agent
+		}
+	}
+	return nil
+}
+
+// handoffInput returns the input of the agent a conversation is handed off
+// to, with the history of the conversation.
+func handoffInput(input string, entries []TraceEntry, from, to, message string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The conversation so far:\nUser: %s\n", input)
+	for _, entry := range entries {
+		event := entry.Event
+		switch {
+		case event.Type == agents.EventToolCall && strings.HasPrefix(event.Action.Tool, _handoffToolPrefix):
+			fmt.Fprintf(&b, "%s handed the conversation off to %s: %s\n", entry.Agent,
+				strings.TrimPrefix(event.Action.Tool, _handoffToolPrefix), event.Action.ToolInput)
+		case event.Type == agents.EventToolResult:
+			fmt.Fprintf(&b, "%s called %s with %s: %s\n", entry.Agent,
+				event.Action.Tool, event.Action.ToolInput, event.Observation)
+		}
+	}
+	fmt.Fprintf(&b, "\nYou are %s. %s handed the conversation off to you with this message: %s\n"+
+		"Continue the conversation and answer the user.", to, from, message)
+	return b.String()
+}
+
+// GetInputKeys returns the input key of the conversation.
+func (h *Handoffs) GetInputKeys() []string {
+	return []string{"input"}
+}
+
+// GetOutputKeys returns the keys of the output, the last agent and the trace.
+func (h *Handoffs) GetOutputKeys() []string {
+	return []string{h.OutputKey, _agentOutputKey, _traceOutputKey}
+}
+
+func (h *Handoffs) GetMemory() schema.Memory { //nolint:ireturn
+	return h.Memory
+}
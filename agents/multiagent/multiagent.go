@@ -0,0 +1,182 @@
+// Package multiagent composes agent executors into multi-agent runs.
+//
+// A Supervisor is an agent routing tasks to member agents, which it calls as
+// tools. Handoffs runs agents which transfer the conversation to one another
+// with the tools returned by Handoff, as a triage agent handing billing
+// questions off to a billing agent.
+//
+// Every agent keeps its own tools, prompt and memory. The outputs of a
+// multi-agent run hold the combined trace of the runs of its agents under
+// "trace".
+package multiagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const _traceOutputKey = "trace"
+
+var (
+	// ErrUnknownAgent is returned when a handoff targets an agent that is not part of the run.
+	ErrUnknownAgent = errors.New("unknown agent")
+	// ErrTooManyHandoffs is returned when the agents of a run hand the conversation off more than
+	// allowed.
+	ErrTooManyHandoffs = errors.New("too many handoffs")
+)
+
+// Agent is an agent executor with a name and a description, for other agents
+// to route tasks or hand conversations off to it. The name is used as the name
+// of tools, so it should only have letters, digits, underscores and dashes.
+type Agent struct {
+	Name        string
+	Description string
+	Executor    *agents.Executor
+}
+
+// NewAgent creates a new named agent running with an executor.
+func NewAgent(name, description string, executor *agents.Executor) *Agent {
+	return &Agent{
+		Name:        name,
+		Description: description,
+		Executor:    executor,
+	}
+}
+
+// Tool returns a tool running the agent with its input, whose observation is
+// the output of the agent.
+func (a *Agent) Tool() tools.Tool { //nolint:ireturn
+	return agentTool{agent: a}
+}
+
+// run runs the agent with the input values, adding the events of the run to
+// the trace of the context, and returns its outputs.
+func (a *Agent) run(
+	ctx context.Context,
+	inputValues map[string]any,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
+	trace := traceFromContext(ctx)
+	for event := range a.Executor.Stream(ctx, inputValues, options...) {
+		switch event.Type {
+		case agents.EventPlanStart, agents.EventToken:
+			continue
+		case agents.EventError:
+			var handoff *HandoffError
+			if !errors.As(event.Err, &handoff) {
+				trace.add(a.Name, event)
+			}
+			return nil, event.Err
+		case agents.EventFinish:
+			trace.add(a.Name, event)
+			return event.Output, nil
+		default:
+			trace.add(a.Name, event)
+		}
+	}
+	// The iteration only ends early when the context is done.
+	return nil, ctx.Err()
+}
+
+// output returns the output of the agent in its outputs.
+func (a *Agent) output(outputs map[string]any) (string, error) {
+	outputKeys := a.Executor.GetOutputKeys()
+	if len(outputKeys) == 0 {
+		return "", fmt.Errorf("agent %s: %w", a.Name, agents.ErrAgentNoReturn)
+	}
+	output, ok := outputs[outputKeys[0]].(string)
+	if !ok {
+		return "", fmt.Errorf("agent %s: %w", a.Name, agents.ErrInvalidChainReturnType)
+	}
+	return output, nil
+}
+
+type agentTool struct {
+	agent *Agent
+}
+
+var _ tools.Tool = agentTool{}
+
+func (t agentTool) Name() string {
+	return t.agent.Name
+}
+
+func (t agentTool) Description() string {
+	return t.agent.Description + " The input is the task for the agent, with the context it needs."
+}
+
+func (t agentTool) Call(ctx context.Context, input string) (string, error) {
+	outputs, err := t.agent.run(ctx, map[string]any{"input": input})
+	if err != nil {
+		return "", fmt.Errorf("agent %s: %w", t.agent.Name, err)
+	}
+	return t.agent.output(outputs)
+}
+
+// TraceEntry is an event of the run of an agent in a multi-agent run.
+type TraceEntry struct {
+	// Agent is the name of the agent.
+	Agent string
+	// Event is the event of its run. Plan starts and tokens are left out.
+	Event agents.Event
+}
+
+// trace collects the entries of a multi-agent run, from the goroutines of the
+// runs of its agents.
+type trace struct {
+	mu      sync.Mutex
+	entries []TraceEntry
+}
+
+type traceKey struct{}
+
+// withTrace returns a context collecting the trace of a multi-agent run.
+// Nested runs add their entries to the trace of the outer run.
+func withTrace(ctx context.Context) (context.Context, *trace) {
+	if t := traceFromContext(ctx); t != nil {
+		return ctx, t
+	}
+	t := &trace{}
+	return context.WithValue(ctx, traceKey{}, t), t
+}
+
+func traceFromContext(ctx context.Context) *trace {
+	t, _ := ctx.Value(traceKey{}).(*trace)
+	return t
+}
+
+func (t *trace) add(agent string, event agents.Event) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, TraceEntry{Agent: agent, Event: event})
+}
+
+// since returns the entries added after the first n.
+func (t *trace) since(n int) []TraceEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceEntry(nil), t.entries[n:]...)
+}
+
+func (t *trace) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
+
+func inputString(inputValues map[string]any) (string, error) {
+	input, ok := inputValues["input"].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: input", agents.ErrExecutorInputNotString)
+	}
+	return input, nil
+}
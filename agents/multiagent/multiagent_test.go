@@ -0,0 +1,159 @@
+package multiagent_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/agents/multiagent"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// scriptedModel responds with its responses in order.
+type scriptedModel struct {
+	responses []*llms.ContentResponse
+}
+
+func (m *scriptedModel) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+func (m *scriptedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// scriptedAgent calls its tool with its input the first time, then finishes
+// with the observation, or with its input when it has no tool.
+type scriptedAgent struct {
+	tool   tools.Tool
+	inputs []string
+}
+
+func (a *scriptedAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	_ ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(intermediateSteps) == 0 {
+		a.inputs = append(a.inputs, inputs["input"])
+	}
+	if a.tool != nil && len(intermediateSteps) == 0 {
+		return []schema.AgentAction{{Tool: a.tool.Name(), ToolInput: inputs["input"]}}, nil, nil
+	}
+	output := inputs["input"]
+	if len(intermediateSteps) > 0 {
+		output = intermediateSteps[0].Observation
+	}
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": output}}, nil
+}
+
+func (a *scriptedAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *scriptedAgent) GetOutputKeys() []string { return []string{"output"} }
+
+func (a *scriptedAgent) GetTools() []tools.Tool {
+	if a.tool == nil {
+		return nil
+	}
+	return []tools.Tool{a.tool}
+}
+
+type refundTool struct{}
+
+func (refundTool) Name() string        { return "refund" }
+func (refundTool) Description() string { return "Refunds an order." }
+func (refundTool) Call(_ context.Context, input string) (string, error) {
+	return "refunded " + input, nil
+}
+
+func traceSummary(t *testing.T, out map[string]any) []string {
+	t.Helper()
+	entries, ok := out["trace"].([]multiagent.TraceEntry)
+	require.True(t, ok)
+	summary := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		line := entry.Agent + " " + string(entry.Event.Type)
+		if entry.Event.Action != nil {
+			line += " " + entry.Event.Action.Tool
+		}
+		summary = append(summary, line)
+	}
+	return summary
+}
+
+func TestSupervisor(t *testing.T) {
+	t.Parallel()
+
+	billing := multiagent.NewAgent("billing", "Handles refunds and invoices.",
+		agents.NewExecutor(&scriptedAgent{tool: refundTool{}}))
+	support := multiagent.NewAgent("tech_support", "Fixes technical issues.",
+		agents.NewExecutor(&scriptedAgent{}))
+	model := &scriptedModel{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{{
+			ID: "1", Type: "function",
+			FunctionCall: &llms.FunctionCall{Name: "billing", Arguments: `{"__arg1": "order 7"}`},
+		}}}}},
+		{Choices: []*llms.ContentChoice{{Content: "Your order 7 was refunded."}}},
+	}}
+	supervisor := multiagent.NewSupervisor(model, []*multiagent.Agent{billing, support})
+
+	out, err := chains.Call(context.Background(), supervisor, map[string]any{"input": "Refund order 7"})
+	require.NoError(t, err)
+	require.Equal(t, "Your order 7 was refunded.", out["output"])
+	require.Equal(t, []string{
+		"supervisor tool_call billing",
+		"billing tool_call refund",
+		"billing tool_result refund",
+		"billing finish",
+		"supervisor tool_result billing",
+		"supervisor finish",
+	}, traceSummary(t, out))
+
+	entries, _ := out["trace"].([]multiagent.TraceEntry)
+	require.Equal(t, "refunded order 7", entries[4].Event.Observation)
+}
+
+func TestHandoffs(t *testing.T) {
+	t.Parallel()
+
+	triageAgent := &scriptedAgent{tool: multiagent.Handoff("billing", "Handles refunds.")}
+	// Handoffs are not handled as tool errors.
+	triage := multiagent.NewAgent("triage", "Triages requests.", agents.NewExecutor(triageAgent,
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(2, agents.ObserveToolError))))
+	billingAgent := &scriptedAgent{}
+	billing := multiagent.NewAgent("billing", "Handles refunds.", agents.NewExecutor(billingAgent))
+
+	out, err := chains.Call(context.Background(), multiagent.NewHandoffs(triage, billing),
+		map[string]any{"input": "I want a refund"})
+	require.NoError(t, err)
+	require.Equal(t, "billing", out["agent"])
+	require.Equal(t, []string{"triage tool_call transfer_to_billing", "billing finish"}, traceSummary(t, out))
+
+	require.Len(t, billingAgent.inputs, 1)
+	input := billingAgent.inputs[0]
+	require.Equal(t, input, out["output"])
+	require.True(t, strings.HasPrefix(input, "The conversation so far:\nUser: I want a refund\n"), input)
+	require.Contains(t, input, "triage handed the conversation off to billing: I want a refund\n")
+	require.Contains(t, input, "You are billing. triage handed the conversation off to you")
+}
+
+func TestHandoffsErrors(t *testing.T) {
+	t.Parallel()
+
+	ping := multiagent.NewAgent("ping", "", agents.NewExecutor(&scriptedAgent{tool: multiagent.Handoff("pong", "")}))
+	pong := multiagent.NewAgent("pong", "", agents.NewExecutor(&scriptedAgent{tool: multiagent.Handoff("ping", "")}))
+	handoffs := multiagent.NewHandoffs(ping, pong)
+	handoffs.MaxHandoffs = 3
+	_, err := chains.Call(context.Background(), handoffs, map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, multiagent.ErrTooManyHandoffs)
+
+	_, err = chains.Call(context.Background(), multiagent.NewHandoffs(ping), map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, multiagent.ErrUnknownAgent)
+}
@@ -0,0 +1,85 @@
+package multiagent
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const _supervisorSystemMessage = `You are a supervisor managing a team of agents.
+Route each part of the task to the agent best suited for it, by calling the tool with its name.
+Give agents all the context they need, as they do not see the conversation.
+When the task is done, answer the user with the results of the agents.`
+
+// Supervisor is a chain running a supervisor agent, which routes the task of
+// its input to member agents exposed to it as tools. The outputs are those of
+// the supervisor, with the combined trace of the runs of the supervisor and
+// its members under "trace".
+type Supervisor struct {
+	// Name is the name of the supervisor in the trace.
+	Name string
+	// Executor runs the supervisor agent.
+	Executor *agents.Executor
+	Members  []*Agent
+	// Memory is the memory of the chain. The supervisor and the members keep
+	// their own memory in their executors.
+	Memory schema.Memory
+}
+
+var _ chains.Chain = &Supervisor{}
+
+// NewSupervisor creates a new supervisor running a tool calling agent with the
+// model, whose tools are the members. The options apply to the agent and its
+// executor. The system message tells the model to route tasks to the members,
+// and can be replaced with agents.WithSystemMessage.
+func NewSupervisor(llm llms.Model, members []*Agent, opts ...agents.Option) *Supervisor {
+	memberTools := make([]tools.Tool, 0, len(members))
+	for _, member := range members {
+		memberTools = append(memberTools, member.Tool())
+	}
+	opts = append([]agents.Option{agents.WithSystemMessage(_supervisorSystemMessage)}, opts...)
+
+	return &Supervisor{
+		Name:     "supervisor",
+		Executor: agents.NewExecutor(agents.NewToolCallingAgent(llm, memberTools, opts...), opts...),
+		Members:  members,
+		Memory:   memory.NewSimple(),
+	}
+}
+
+// Call runs the supervisor.
+func (s *Supervisor) Call(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+	ctx, trace := withTrace(ctx)
+	start := trace.len()
+
+	supervisor := &Agent{Name: s.Name, Executor: s.Executor}
+	outputs, err := supervisor.run(ctx, inputValues, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs = maps.Clone(outputs)
+	outputs[_traceOutputKey] = trace.since(start)
+	return outputs, nil
+}
+
+// GetInputKeys returns the input keys of the supervisor agent.
+func (s *Supervisor) GetInputKeys() []string {
+	return s.Executor.GetInputKeys()
+}
+
+// GetOutputKeys returns the output keys of the supervisor agent and the trace.
+func (s *Supervisor) GetOutputKeys() []string {
+	return append(slices.Clone(s.Executor.GetOutputKeys()), _traceOutputKey)
+}
+
+func (s *Supervisor) GetMemory() schema.Memory { //nolint:ireturn
+	return s.Memory
+}
@@ -31,7 +31,10 @@ type OpenAIFunctionsAgent struct {
 	CallbacksHandler callbacks.Handler
 }
 
-var _ Agent = (*OpenAIFunctionsAgent)(nil)
+var (
+	_ Agent         = (*OpenAIFunctionsAgent)(nil)
+	_ FinalAnswerer = (*OpenAIFunctionsAgent)(nil)
+)
 
 // NewOpenAIFunctionsAgent creates a new OpenAIFunctionsAgent.
 func NewOpenAIFunctionsAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *OpenAIFunctionsAgent {
@@ -52,17 +55,7 @@ func NewOpenAIFunctionsAgent(llm llms.Model, tools []tools.Tool, opts ...Option)
 func (o *OpenAIFunctionsAgent) functions() []llms.FunctionDefinition {
 	res := make([]llms.FunctionDefinition, 0)
 	for _, tool := range o.Tools {
-		res = append(res, llms.FunctionDefinition{
-			Name:        tool.Name(),
-			Description: tool.Description(),
-			Parameters: map[string]any{
-				"properties": map[string]any{
-					"__arg1": map[string]string{"title": "__arg1", "type": "string"},
-				},
-				"required": []string{"__arg1"},
-				"type":     "object",
-			},
-		})
+		res = append(res, tools.FunctionDefinition(tool))
 	}
 	return res
 }
@@ -74,24 +67,74 @@ func (o *OpenAIFunctionsAgent) Plan(
 	inputs map[string]string,
 	options ...chains.ChainCallOption,
 ) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	mcList, err := o.messages(intermediateSteps, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Build LLM call options, including user-provided options
+	llmOptions := []llms.CallOption{llms.WithFunctions(o.functions()), llms.WithStreamingFunc(o.stream())}
+	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+
+	result, err := o.LLM.GenerateContent(ctx, mcList, llmOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	reportResponse(ctx, result)
+
+	return o.ParseOutput(result)
+}
+
+// FinalAnswer asks the model for a final answer with the steps taken so far,
+// without letting it call functions.
+func (o *OpenAIFunctionsAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) (*schema.AgentFinish, error) {
+	mcList, err := o.messages(intermediateSteps, inputs)
+	if err != nil {
+		return nil, err
+	}
+	mcList = append(mcList, llms.TextParts(llms.ChatMessageTypeHuman, _finalAnswerInstruction))
+
+	llmOptions := []llms.CallOption{
+		llms.WithFunctions(o.functions()),
+		llms.WithToolChoice("none"),
+		llms.WithStreamingFunc(o.stream()),
+	}
+	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+
+	result, err := o.LLM.GenerateContent(ctx, mcList, llmOptions...)
+	if err != nil {
+		return nil, err
+	}
+	reportResponse(ctx, result)
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{o.OutputKey: result.Choices[0].Content},
+		Log:          result.Choices[0].Content,
+	}, nil
+}
+
+// messages returns the messages of the prompt with the scratchpad.
+func (o *OpenAIFunctionsAgent) messages(
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]llms.MessageContent, error) {
 	fullInputs := make(map[string]any, len(inputs))
 	for key, value := range inputs {
 		fullInputs[key] = value
 	}
 	fullInputs[agentScratchpad] = o.constructScratchPad(intermediateSteps)
 
-	var stream func(ctx context.Context, chunk []byte) error
-
-	if o.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk []byte) error {
-			o.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
-
 	prompt, err := o.Prompt.FormatPrompt(fullInputs)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	mcList := make([]llms.MessageContent, len(prompt.Messages()))
@@ -149,16 +192,17 @@ func (o *OpenAIFunctionsAgent) Plan(
 		mcList[i] = mc
 	}
 
-	// Build LLM call options, including user-provided options
-	llmOptions := []llms.CallOption{llms.WithFunctions(o.functions()), llms.WithStreamingFunc(stream)}
-	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+	return mcList, nil
+}
 
-	result, err := o.LLM.GenerateContent(ctx, mcList, llmOptions...)
-	if err != nil {
-		return nil, nil, err
+func (o *OpenAIFunctionsAgent) stream() func(ctx context.Context, chunk []byte) error {
+	if o.CallbacksHandler == nil {
+		return nil
+	}
+	return func(ctx context.Context, chunk []byte) error {
+		o.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+		return nil
 	}
-
-	return o.ParseOutput(result)
 }
 
 func (o *OpenAIFunctionsAgent) GetInputKeys() []string {
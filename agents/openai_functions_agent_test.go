@@ -209,3 +209,41 @@ func TestOpenAIFunctionsAgent_ParseOutput_MultipleToolCalls(t *testing.T) {
 		t.Errorf("expected 2 actions, got %d", len(actions))
 	}
 }
+
+// functionsModel records the functions of each request and finishes.
+type functionsModel struct {
+	functions []llms.FunctionDefinition
+}
+
+func (m *functionsModel) GenerateContent(_ context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.functions = opts.Functions
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "done"}}}, nil
+}
+
+func (m *functionsModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestOpenAIFunctionsAgent_SchemaToolParameters(t *testing.T) {
+	t.Parallel()
+
+	type weatherInput struct {
+		City string `json:"city"`
+	}
+	weather := tools.NewFunc("weather", "Gets the weather.", func(_ context.Context, in weatherInput) (string, error) {
+		return "sunny in " + in.City, nil
+	})
+	model := &functionsModel{}
+	agent := agents.NewOpenAIFunctionsAgent(model, []tools.Tool{weather, tools.Calculator{}})
+
+	_, finish, err := agent.Plan(context.Background(), nil, map[string]string{"input": "Weather in Paris?"})
+	require.NoError(t, err)
+	require.NotNil(t, finish)
+	require.Len(t, model.functions, 2)
+	require.Equal(t, weather.Schema(), model.functions[0].Parameters)
+	require.Contains(t, model.functions[1].Parameters, "properties")
+}
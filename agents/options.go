@@ -2,6 +2,7 @@ package agents
 
 import (
 	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/memory"
 	"github.com/tmc/langchaingo/prompts"
 	"github.com/tmc/langchaingo/schema"
@@ -13,14 +14,23 @@ type Options struct {
 	memory                  schema.Memory
 	callbacksHandler        callbacks.Handler
 	errorHandler            *ParserErrorHandler
+	toolErrorHandler        *ToolErrorHandler
+	needsApproval           func(schema.AgentAction) bool
+	checkpointer            Checkpointer
+	budget                  Budget
 	maxIterations           int
+	maxParallelToolCalls    int
 	returnIntermediateSteps bool
 	outputKey               string
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
 
-	// openai
+	// plan and execute
+	replanner    llms.Model
+	maxPlanSteps int
+
+	// openai and tool calling
 	systemMessage string
 	extraMessages []prompts.MessageFormatter
 }
@@ -62,6 +72,22 @@ func openAIFunctionsDefaultOptions() Options {
 	}
 }
 
+func toolCallingDefaultOptions() Options {
+	return Options{
+		systemMessage: "You are a helpful AI assistant.",
+		outputKey:     _defaultOutputKey,
+	}
+}
+
+func planAndExecuteDefaultOptions() Options {
+	return Options{
+		maxIterations: _defaultMaxIterations,
+		maxPlanSteps:  _defaultMaxPlanSteps,
+		outputKey:     _defaultOutputKey,
+		memory:        memory.NewSimple(),
+	}
+}
+
 func (co Options) getMrklPrompt(tools []tools.Tool) prompts.PromptTemplate {
 	if co.prompt.Template != "" {
 		return co.prompt
@@ -96,6 +122,16 @@ func WithMaxIterations(iterations int) Option {
 	}
 }
 
+// WithParallelToolCalls is an option for making the executor run the tool calls
+// of a plan concurrently, with at most maxWorkers calls at a time. The calls of
+// tools that are not concurrency safe, as reported by tools.IsConcurrencySafe,
+// still run one at a time.
+func WithParallelToolCalls(maxWorkers int) Option {
+	return func(co *Options) {
+		co.maxParallelToolCalls = maxWorkers
+	}
+}
+
 // WithOutputKey is an option for setting the output key of the agent.
 func WithOutputKey(outputKey string) Option {
 	return func(co *Options) {
@@ -161,6 +197,69 @@ func WithParserErrorHandler(errorHandler *ParserErrorHandler) Option {
 	}
 }
 
+// WithToolErrorHandler is an option for setting a tool error handler to an executor.
+func WithToolErrorHandler(errorHandler *ToolErrorHandler) Option {
+	return func(co *Options) {
+		co.toolErrorHandler = errorHandler
+	}
+}
+
+// WithApproval is an option for making the executor interrupt the run before calling the tools of
+// the actions needing the approval of a human. See ApprovalForTools and Executor.Resume.
+func WithApproval(needsApproval func(action schema.AgentAction) bool) Option {
+	return func(co *Options) {
+		co.needsApproval = needsApproval
+	}
+}
+
+// WithCheckpointer is an option for making the executor save checkpoints of the runs identified
+// with ContextWithRunID, and resume them from their checkpoint.
+func WithCheckpointer(checkpointer Checkpointer) Option {
+	return func(co *Options) {
+		co.checkpointer = checkpointer
+	}
+}
+
+// WithBudget is an option for limiting the resources of the runs of an executor.
+func WithBudget(budget Budget) Option {
+	return func(co *Options) {
+		co.budget = budget
+	}
+}
+
+// WithReplanner is an option for setting the model revising the plan of a
+// plan-and-execute chain. The planner revises it by default.
+func WithReplanner(llm llms.Model) Option {
+	return func(co *Options) {
+		co.replanner = llm
+	}
+}
+
+// WithMaxPlanSteps is an option for setting the max number of steps a
+// plan-and-execute chain carries out.
+func WithMaxPlanSteps(steps int) Option {
+	return func(co *Options) {
+		co.maxPlanSteps = steps
+	}
+}
+
+// WithSystemMessage is an option for setting the system message of the prompt
+// used by chat agents, such as the tool calling agent.
+func WithSystemMessage(msg string) Option {
+	return func(co *Options) {
+		co.systemMessage = msg
+	}
+}
+
+// WithExtraMessages is an option for adding messages between the system message
+// and the input in the prompt used by chat agents, such as the tool calling
+// agent.
+func WithExtraMessages(extraMessages []prompts.MessageFormatter) Option {
+	return func(co *Options) {
+		co.extraMessages = extraMessages
+	}
+}
+
 type OpenAIOption struct{}
 
 func NewOpenAIOption() OpenAIOption {
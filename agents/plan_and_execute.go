@@ -0,0 +1,289 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_defaultMaxPlanSteps = 10
+
+	_planOutputKey      = "plan"
+	_planStepsOutputKey = "steps"
+
+	_defaultPlannerTemplate = `Let's first understand the objective and devise a plan to reach it.
+Write the plan as a numbered list of steps, one step per line, and nothing else.
+Each step must be a task that can be done on its own with the tools below.
+The result of the last step must be the answer to the objective. Do not add superfluous steps.
+
+Tools:
+{{.tool_descriptions}}
+Objective: {{.input}}`
+
+	_defaultReplannerTemplate = `For the given objective, update the plan.
+
+Objective: {{.input}}
+
+The plan was:
+{{.plan}}
+
+The steps done so far and their results:
+{{.past_steps}}
+If the results are enough to answer the objective, respond with "Final Answer:" followed by the answer.
+Otherwise write the remaining steps as a numbered list, one step per line, without the steps already done.`
+
+	_defaultStepTemplate = `Objective: {{.input}}
+{{if .past_steps}}
+The steps done so far and their results:
+{{.past_steps}}{{end}}
+Do this step of the plan to reach the objective, and reply with its result: {{.step}}`
+)
+
+// _planStepRe matches the steps of a numbered list.
+var _planStepRe = regexp.MustCompile(`(?m)^\s*\d+[.)]\s+(.+?)\s*$`)
+
+// PlanStep is a step of a plan carried out by PlanAndExecute, with its result.
+type PlanStep struct {
+	Step   string `json:"step"`
+	Result string `json:"result"`
+}
+
+// PlanAndExecute is a chain planning the steps to reach the objective of its
+// input, then carrying them out one by one. A planner model writes a numbered
+// plan, an executor runs an agent with tools for each step, and a replanner
+// model revises the remaining steps after each result, until it gives the
+// final answer.
+//
+// The outputs hold the final answer, the initial plan under "plan" as a
+// []string, and the steps done with their results under "steps" as a
+// []PlanStep.
+type PlanAndExecute struct {
+	Planner   llms.Model
+	Replanner llms.Model
+	// StepExecutor runs the agent carrying out each step. It has no memory,
+	// as the steps done so far are given in its input.
+	StepExecutor     *Executor
+	Memory           schema.Memory
+	CallbacksHandler callbacks.Handler
+
+	// PlannerPrompt formats the request of the plan from the input and
+	// the tool_descriptions of the agent.
+	PlannerPrompt prompts.PromptTemplate
+	// ReplannerPrompt formats the request of the remaining steps from the
+	// input, the plan and the past_steps with their results.
+	ReplannerPrompt prompts.PromptTemplate
+	// StepPrompt formats the input of the step executor from the input, the
+	// step and the past_steps with their results.
+	StepPrompt prompts.PromptTemplate
+
+	// MaxSteps is the maximum number of steps carried out.
+	MaxSteps  int
+	OutputKey string
+}
+
+var (
+	_ chains.Chain           = &PlanAndExecute{}
+	_ callbacks.HandlerHaver = &PlanAndExecute{}
+)
+
+// NewPlanAndExecute creates a new plan-and-execute chain, with a model writing
+// and revising the plan and an agent carrying out the steps. The options
+// apply to the chain and to the executor of the agent, except the memory.
+func NewPlanAndExecute(planner llms.Model, agent Agent, opts ...Option) *PlanAndExecute {
+	options := planAndExecuteDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	stepExecutor := NewExecutor(agent, opts...)
+	stepExecutor.Memory = memory.NewSimple()
+	stepExecutor.ReturnIntermediateSteps = false
+
+	replanner := options.replanner
+	if replanner == nil {
+		replanner = planner
+	}
+
+	return &PlanAndExecute{
+		Planner:          planner,
+		Replanner:        replanner,
+		StepExecutor:     stepExecutor,
+		Memory:           options.memory,
+		CallbacksHandler: options.callbacksHandler,
+		PlannerPrompt:    options.getPlannerPrompt(agent),
+		ReplannerPrompt:  prompts.NewPromptTemplate(_defaultReplannerTemplate, []string{"input", "plan", "past_steps"}),
+		StepPrompt:       prompts.NewPromptTemplate(_defaultStepTemplate, []string{"input", "step", "past_steps"}),
+		MaxSteps:         options.maxPlanSteps,
+		OutputKey:        options.outputKey,
+	}
+}
+
+// Call plans the steps to reach the objective of the input and carries them
+// out. ErrNotFinished is returned with the plan and the steps done when the
+// replanner gives no final answer within MaxSteps steps.
+func (p *PlanAndExecute) Call(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+	inputs, err := inputsToString(inputValues)
+	if err != nil {
+		return nil, err
+	}
+	llmOptions := chains.GetLLMCallOptions(options...)
+
+	output, err := p.predict(ctx, p.Planner, p.PlannerPrompt, map[string]any{"input": inputs["input"]}, llmOptions)
+	if err != nil {
+		return nil, fmt.Errorf("plan: %w", err)
+	}
+	plan := parsePlan(output)
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("%w: no steps in plan: %s", ErrUnableToParseOutput, output)
+	}
+
+	remaining := plan
+	steps := make([]PlanStep, 0, len(plan))
+	for len(steps) < p.MaxSteps {
+		result, err := p.doStep(ctx, inputs["input"], remaining[0], steps, options)
+		if err != nil {
+			return p.outputs("", plan, steps), fmt.Errorf("step %d: %w", len(steps)+1, err)
+		}
+		steps = append(steps, PlanStep{Step: remaining[0], Result: result})
+
+		output, err := p.predict(ctx, p.Replanner, p.ReplannerPrompt, map[string]any{
+			"input":      inputs["input"],
+			"plan":       formatPlan(plan),
+			"past_steps": formatPlanSteps(steps),
+		}, llmOptions)
+		if err != nil {
+			return p.outputs("", plan, steps), fmt.Errorf("replan: %w", err)
+		}
+		if strings.Contains(output, _finalAnswerAction) {
+			return p.outputs(finalAnswerText(output, _finalAnswerAction), plan, steps), nil
+		}
+		// A replanner with nothing left to do answers with the last result.
+		remaining = parsePlan(output)
+		if len(remaining) == 0 {
+			return p.outputs(result, plan, steps), nil
+		}
+	}
+
+	return p.outputs("", plan, steps), ErrNotFinished
+}
+
+// doStep runs the step executor for a step of the plan and returns its result.
+func (p *PlanAndExecute) doStep(
+	ctx context.Context,
+	input, step string,
+	steps []PlanStep,
+	options []chains.ChainCallOption,
+) (string, error) {
+	stepInput, err := p.StepPrompt.Format(map[string]any{
+		"input":      input,
+		"step":       step,
+		"past_steps": formatPlanSteps(steps),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	outputs, err := chains.Call(ctx, p.StepExecutor, map[string]any{"input": stepInput}, options...)
+	if err != nil {
+		return "", err
+	}
+	outputKeys := p.StepExecutor.GetOutputKeys()
+	if len(outputKeys) == 0 {
+		return "", ErrAgentNoReturn
+	}
+	result, ok := outputs[outputKeys[0]].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidChainReturnType, outputKeys[0])
+	}
+	return result, nil
+}
+
+func (p *PlanAndExecute) predict(
+	ctx context.Context,
+	llm llms.Model,
+	prompt prompts.PromptTemplate,
+	values map[string]any,
+	options []llms.CallOption,
+) (string, error) {
+	text, err := prompt.Format(values)
+	if err != nil {
+		return "", err
+	}
+	return llms.GenerateFromSinglePrompt(ctx, llm, text, options...)
+}
+
+func (p *PlanAndExecute) outputs(answer string, plan []string, steps []PlanStep) map[string]any {
+	return map[string]any{
+		p.OutputKey:         answer,
+		_planOutputKey:      plan,
+		_planStepsOutputKey: steps,
+	}
+}
+
+// GetInputKeys returns the input key of the objective.
+func (p *PlanAndExecute) GetInputKeys() []string {
+	return []string{"input"}
+}
+
+// GetOutputKeys returns the keys of the answer, the plan and the steps.
+func (p *PlanAndExecute) GetOutputKeys() []string {
+	return []string{p.OutputKey, _planOutputKey, _planStepsOutputKey}
+}
+
+func (p *PlanAndExecute) GetMemory() schema.Memory { //nolint:ireturn
+	return p.Memory
+}
+
+func (p *PlanAndExecute) GetCallbackHandler() callbacks.Handler { //nolint:ireturn
+	return p.CallbacksHandler
+}
+
+func (co Options) getPlannerPrompt(agent Agent) prompts.PromptTemplate {
+	if co.prompt.Template != "" {
+		return co.prompt
+	}
+
+	return prompts.PromptTemplate{
+		Template:       _defaultPlannerTemplate,
+		TemplateFormat: prompts.TemplateFormatGoTemplate,
+		InputVariables: []string{"input"},
+		PartialVariables: map[string]any{
+			"tool_descriptions": toolDescriptions(agent.GetTools()),
+		},
+	}
+}
+
+// parsePlan returns the steps of a numbered list.
+func parsePlan(text string) []string {
+	matches := _planStepRe.FindAllStringSubmatch(text, -1)
+	steps := make([]string, 0, len(matches))
+	for _, match := range matches {
+		steps = append(steps, match[1])
+	}
+	return steps
+}
+
+func formatPlan(plan []string) string {
+	var b strings.Builder
+	for i, step := range plan {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step)
+	}
+	return b.String()
+}
+
+func formatPlanSteps(steps []PlanStep) string {
+	var b strings.Builder
+	for i, step := range steps {
+		fmt.Fprintf(&b, "%d. %s\nResult: %s\n", i+1, step.Step, step.Result)
+	}
+	return b.String()
+}
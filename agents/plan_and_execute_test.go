@@ -0,0 +1,98 @@
+package agents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// newStepAgent returns an agent searching the step of its input, then
+// finishing with the observation.
+func newStepAgent(ts ...tools.Tool) *testAgent {
+	return &testAgent{
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+		tools:      ts,
+		plan: func(_ context.Context, intermediateSteps []schema.AgentStep, inputs map[string]string) ([]schema.AgentAction, *schema.AgentFinish, error) { //nolint:lll
+			if len(intermediateSteps) == 0 {
+				_, step, _ := strings.Cut(inputs["input"], "reply with its result: ")
+				return []schema.AgentAction{{Tool: "search", ToolInput: step}}, nil, nil
+			}
+			output := intermediateSteps[0].Observation
+			return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": output}}, nil
+		},
+	}
+}
+
+func textResponses(texts ...string) []*llms.ContentResponse {
+	responses := make([]*llms.ContentResponse, 0, len(texts))
+	for _, text := range texts {
+		responses = append(responses, &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: text}}})
+	}
+	return responses
+}
+
+func promptText(t *testing.T, messages []llms.MessageContent) string {
+	t.Helper()
+	require.Len(t, messages, 1)
+	text, ok := messages[0].Parts[0].(llms.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func TestPlanAndExecute(t *testing.T) {
+	t.Parallel()
+
+	search := &recordingTool{name: "search"}
+	planner := &toolCallingModel{responses: textResponses(
+		"Plan:\n1. Find the population of France.\n2. Halve it.",
+	)}
+	replanner := &toolCallingModel{responses: textResponses(
+		"1. Halve the population found.",
+		"Final Answer: 34 million",
+	)}
+	chain := agents.NewPlanAndExecute(planner, newStepAgent(search),
+		agents.WithReplanner(replanner))
+
+	out, err := chains.Call(context.Background(), chain, map[string]any{"input": "Half of the French?"})
+	require.NoError(t, err)
+	require.Equal(t, "34 million", out["output"])
+	require.Equal(t, []string{"Find the population of France.", "Halve it."}, out["plan"])
+	require.Equal(t, []agents.PlanStep{
+		{Step: "Find the population of France.", Result: "search Find the population of France."},
+		{Step: "Halve the population found.", Result: "search Halve the population found."},
+	}, out["steps"])
+
+	require.Contains(t, promptText(t, planner.messages[0]), "- search: Records its inputs.")
+	prompt := promptText(t, replanner.messages[1])
+	require.Contains(t, prompt, "1. Find the population of France.\n2. Halve it.\n")
+	require.Contains(t, prompt, "2. Halve the population found.\nResult: search Halve the population found.\n")
+}
+
+func TestPlanAndExecuteEnd(t *testing.T) {
+	t.Parallel()
+
+	newChain := func(replies ...string) *agents.PlanAndExecute {
+		planner := &toolCallingModel{responses: textResponses("1. Search.\n2. Search again.")}
+		replanner := &toolCallingModel{responses: textResponses(replies...)}
+		return agents.NewPlanAndExecute(planner, newStepAgent(&recordingTool{name: "search"}),
+			agents.WithReplanner(replanner), agents.WithMaxPlanSteps(2))
+	}
+
+	// The last result is the answer when no steps remain.
+	out, err := chains.Call(context.Background(), newChain("Nothing left to do."), map[string]any{"input": "foo"})
+	require.NoError(t, err)
+	require.Equal(t, "search Search.", out["output"])
+
+	out, err = chains.Call(context.Background(), newChain("1. Search again.", "1. Search more."),
+		map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.Len(t, out["steps"], 2)
+}
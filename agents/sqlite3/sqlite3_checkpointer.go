@@ -0,0 +1,125 @@
+// Package sqlite3 adds support for
+// agent run checkpoints using sqlite3.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+	"github.com/tmc/langchaingo/agents"
+)
+
+// DefaultTableName sets a default table name.
+const DefaultTableName = "langchaingo_checkpoints"
+
+// DefaultSchema sets a default schema to be run after connecting.
+const DefaultSchema = `CREATE TABLE IF NOT EXISTS %s (
+		run_id TEXT PRIMARY KEY,
+		data TEXT NOT NULL,
+		updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`
+
+// SqliteCheckpointer is a struct that stores agent run checkpoints.
+type SqliteCheckpointer struct {
+	// DB is the database connection.
+	DB *sql.DB
+	// DBAddress is the address or file path for connecting the db.
+	DBAddress string
+	// TableName is the name of the checkpoints table.
+	TableName string
+	// Schema defines a initial schema to be run.
+	Schema []byte
+}
+
+// Statically assert that SqliteCheckpointer implement the checkpointer interface.
+var _ agents.Checkpointer = &SqliteCheckpointer{}
+
+// NewSqliteCheckpointer creates a new SqliteCheckpointer using checkpointer options, connecting
+// to the db and running the schema.
+func NewSqliteCheckpointer(ctx context.Context, options ...SqliteCheckpointerOption) (*SqliteCheckpointer, error) {
+	c := &SqliteCheckpointer{}
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.TableName == "" {
+		c.TableName = DefaultTableName
+	}
+	if c.Schema == nil {
+		c.Schema = []byte(fmt.Sprintf(DefaultSchema, c.TableName))
+	}
+	if c.DBAddress == "" {
+		c.DBAddress = ":memory:"
+	}
+	if c.DB == nil {
+		db, err := sql.Open("sqlite3", c.DBAddress)
+		if err != nil {
+			return nil, err
+		}
+		if c.DBAddress == ":memory:" {
+			// Each connection has its own in-memory database.
+			db.SetMaxOpenConns(1)
+		}
+		c.DB = db
+	}
+
+	if _, err := c.DB.ExecContext(ctx, string(c.Schema)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save saves the checkpoint of a run, replacing the previous one.
+func (c *SqliteCheckpointer) Save(ctx context.Context, checkpoint *agents.Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	querytpl := []string{
+		"INSERT INTO ",
+		" (run_id, data) VALUES (?, ?) ON CONFLICT(run_id) DO UPDATE SET data = excluded.data, updated = CURRENT_TIMESTAMP;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+	_, err = c.DB.ExecContext(ctx, query, checkpoint.RunID, string(data))
+	return err
+}
+
+// Load returns the checkpoint of a run, or agents.ErrCheckpointNotFound.
+func (c *SqliteCheckpointer) Load(ctx context.Context, runID string) (*agents.Checkpoint, error) {
+	querytpl := []string{
+		"SELECT data FROM ",
+		" WHERE run_id = ?;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+
+	var data string
+	err := c.DB.QueryRowContext(ctx, query, runID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, agents.ErrCheckpointNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint agents.Checkpoint
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Delete deletes the checkpoint of a run.
+func (c *SqliteCheckpointer) Delete(ctx context.Context, runID string) error {
+	querytpl := []string{
+		"DELETE FROM ",
+		" WHERE run_id = ?;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+	_, err := c.DB.ExecContext(ctx, query, runID)
+	return err
+}
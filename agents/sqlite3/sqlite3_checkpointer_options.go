@@ -0,0 +1,39 @@
+package sqlite3
+
+import "database/sql"
+
+// SqliteCheckpointerOption is a function for creating new
+// checkpointers with other than the default values.
+type SqliteCheckpointerOption func(c *SqliteCheckpointer)
+
+// WithDB is an option for NewSqliteCheckpointer for adding
+// a database connection.
+func WithDB(db *sql.DB) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.DB = db
+	}
+}
+
+// WithDBAddress is an option for NewSqliteCheckpointer for
+// specifying an address or file path for when connecting the db.
+func WithDBAddress(addr string) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.DBAddress = addr
+	}
+}
+
+// WithTableName is an option for NewSqliteCheckpointer for
+// setting the name of the checkpoints table.
+func WithTableName(name string) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.TableName = name
+	}
+}
+
+// WithSchema is an option for NewSqliteCheckpointer for
+// running a schema when connected. Useful for migrations for example.
+func WithSchema(schema []byte) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.Schema = schema
+	}
+}
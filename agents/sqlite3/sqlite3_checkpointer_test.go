@@ -0,0 +1,42 @@
+package sqlite3_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/agents/sqlite3"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestSqliteCheckpointer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c, err := sqlite3.NewSqliteCheckpointer(ctx, sqlite3.WithDBAddress(filepath.Join(t.TempDir(), "checkpoints.db")))
+	require.NoError(t, err)
+
+	_, err = c.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+
+	checkpoint := &agents.Checkpoint{
+		RunID:     "run",
+		Inputs:    map[string]string{"input": "foo"},
+		Steps:     []schema.AgentStep{{Action: schema.AgentAction{Tool: "search", ToolInput: "foo"}, Observation: "bar"}},
+		Iteration: 1,
+	}
+	require.NoError(t, c.Save(ctx, checkpoint))
+	checkpoint.Iteration = 2
+	require.NoError(t, c.Save(ctx, checkpoint))
+
+	got, err := c.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, checkpoint.Steps, got.Steps)
+	require.Equal(t, 2, got.Iteration)
+
+	require.NoError(t, c.Delete(ctx, "run"))
+	_, err = c.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
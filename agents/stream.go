@@ -0,0 +1,75 @@
+package agents
+
+import (
+	"context"
+	"iter"
+	"slices"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/internal/runstore"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// EventType is the type of an event of a streamed run.
+type EventType string
+
+const (
+	// EventPlanStart is sent when the agent starts planning an iteration.
+	EventPlanStart EventType = "plan_start"
+	// EventToken is sent for each chunk of text streamed by the model.
+	EventToken EventType = "token"
+	// EventToolCall is sent when the tool of an action is about to be called.
+	EventToolCall EventType = "tool_call"
+	// EventToolResult is sent with the observation of an action.
+	EventToolResult EventType = "tool_result"
+	// EventFinish is the last event of a run giving a final answer.
+	EventFinish EventType = "finish"
+	// EventError is the last event of a run failing.
+	EventError EventType = "error"
+)
+
+// Event is an event of a run streamed with Executor.Stream.
+type Event struct {
+	Type EventType
+	// Iteration is the iteration started, for EventPlanStart.
+	Iteration int
+	// Token is the chunk of text, for EventToken.
+	Token string
+	// Action is the action, for EventToolCall and EventToolResult.
+	Action *schema.AgentAction
+	// Observation is the observation of the action, for EventToolResult.
+	Observation string
+	// Output holds the outputs of the run, for EventFinish.
+	Output map[string]any
+	// Err is the error of the run, for EventError.
+	Err error
+}
+
+// Stream runs the agent as chains.Call does, and returns an iterator over the
+// events of the run as they happen. The last event is an EventFinish or an
+// EventError. Tokens are streamed with chains.WithStreamingFunc, added to the
+// options. Stopping the iteration cancels the run.
+func (e *Executor) Stream(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) iter.Seq[Event] { //nolint:lll
+	return runstore.Stream(ctx, func(ctx context.Context, emit func(Event)) {
+		executor := *e
+		executor.emit = emit
+		options := append(slices.Clone(options), chains.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			emit(Event{Type: EventToken, Token: string(chunk)})
+			return nil
+		}))
+
+		outputs, err := chains.Call(ctx, &executor, inputValues, options...)
+		if err != nil {
+			emit(Event{Type: EventError, Err: err})
+			return
+		}
+		emit(Event{Type: EventFinish, Output: outputs})
+	})
+}
+
+// event sends an event of the run to Stream.
+func (e *Executor) event(event Event) {
+	if e.emit != nil {
+		e.emit(event)
+	}
+}
@@ -0,0 +1,77 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func TestExecutorStream(t *testing.T) {
+	t.Parallel()
+
+	model := &toolCallingModel{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{
+			{Content: "Let me compute."},
+			{ToolCalls: []llms.ToolCall{toolCallPart("1", "calculator", `{"__arg1": "1 + 2"}`)}},
+		}},
+		{Choices: []*llms.ContentChoice{{Content: "It is 3."}}},
+	}}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(model, []tools.Tool{tools.Calculator{}}))
+
+	var types []agents.EventType
+	var events []agents.Event
+	for event := range executor.Stream(context.Background(), map[string]any{"input": "1 + 2?"}) {
+		types = append(types, event.Type)
+		events = append(events, event)
+	}
+	require.Equal(t, []agents.EventType{
+		agents.EventPlanStart,
+		agents.EventToken,
+		agents.EventToolCall,
+		agents.EventToolResult,
+		agents.EventPlanStart,
+		agents.EventToken,
+		agents.EventFinish,
+	}, types)
+	require.Equal(t, 1, events[4].Iteration)
+	require.Equal(t, "Let me compute.", events[1].Token)
+	require.Equal(t, "calculator", events[2].Action.Tool)
+	require.Equal(t, "1 + 2", events[2].Action.ToolInput)
+	require.Equal(t, "3", events[3].Observation)
+	require.Equal(t, "It is 3.", events[6].Output["output"])
+}
+
+func TestExecutorStreamError(t *testing.T) {
+	t.Parallel()
+
+	a := &testAgent{err: errors.New("planning failed")}
+	var last agents.Event
+	for event := range agents.NewExecutor(a).Stream(context.Background(), nil) {
+		last = event
+	}
+	require.Equal(t, agents.EventError, last.Type)
+	require.ErrorContains(t, last.Err, "planning failed")
+}
+
+func TestExecutorStreamBreak(t *testing.T) {
+	t.Parallel()
+
+	model := &toolCallingModel{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("1", "calculator", `{"__arg1": "1"}`)}}}},
+		{Choices: []*llms.ContentChoice{{Content: "Done."}}},
+	}}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(model, []tools.Tool{tools.Calculator{}}))
+
+	for event := range executor.Stream(context.Background(), map[string]any{"input": "1?"}) {
+		if event.Type == agents.EventToolCall {
+			break
+		}
+	}
+	// The run stopped without planning again.
+	require.Len(t, model.responses, 1)
+}
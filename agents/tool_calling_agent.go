@@ -0,0 +1,337 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ToolCallingAgent is an Agent using the native tool calls of the model. It
+// works with any model supporting llms.WithTools, such as the anthropic,
+// googleai, bedrock, mistral and openai models.
+//
+// The intermediate steps are sent to the model after the prompt, as one AI
+// message holding the text and tool calls of each response of the model,
+// followed by one tool message for each call holding its observation.
+type ToolCallingAgent struct {
+	// LLM is the model called with the prompt, the intermediate steps and the
+	// tools.
+	LLM llms.Model
+	// Prompt is the prompt the intermediate steps are added to.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// Output key is the key where the final output is placed.
+	OutputKey string
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var (
+	_ Agent         = (*ToolCallingAgent)(nil)
+	_ FinalAnswerer = (*ToolCallingAgent)(nil)
+)
+
+// NewToolCallingAgent creates a new ToolCallingAgent. The prompt is made of
+// the system message, the extra messages and the "input" value, set with
+// WithSystemMessage and WithExtraMessages.
+func NewToolCallingAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ToolCallingAgent {
+	options := toolCallingDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &ToolCallingAgent{
+		LLM:              llm,
+		Prompt:           createToolCallingPrompt(options),
+		Tools:            tools,
+		OutputKey:        options.outputKey,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// Plan decides what action to take or returns the final result of the input.
+func (a *ToolCallingAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	messages, err := a.messages(intermediateSteps, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	llmOptions := []llms.CallOption{llms.WithTools(tools.LLMTools(a.Tools)), llms.WithStreamingFunc(a.stream())}
+	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+
+	result, err := a.LLM.GenerateContent(ctx, messages, llmOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	reportResponse(ctx, result)
+
+	actions, finish, err := a.ParseOutput(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Some providers, such as googleai, do not identify tool calls. They get
+	// an ID unique in the run, as each action makes one step.
+	for i := range actions {
+		if actions[i].ToolID == "" {
+			actions[i].ToolID = fmt.Sprintf("call_%d", len(intermediateSteps)+i)
+		}
+	}
+	return actions, finish, nil
+}
+
+// FinalAnswer asks the model for a final answer with the steps taken so far,
+// without letting it call tools. The tools are still given, as some providers
+// reject tool calls in the history of requests without them.
+func (a *ToolCallingAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) (*schema.AgentFinish, error) {
+	messages, err := a.messages(intermediateSteps, inputs)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, _finalAnswerInstruction))
+
+	llmOptions := []llms.CallOption{
+		llms.WithTools(tools.LLMTools(a.Tools)),
+		llms.WithToolChoice("none"),
+		llms.WithStreamingFunc(a.stream()),
+	}
+	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+
+	result, err := a.LLM.GenerateContent(ctx, messages, llmOptions...)
+	if err != nil {
+		return nil, err
+	}
+	reportResponse(ctx, result)
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	// Tool calls the model makes anyway are ignored.
+	var text strings.Builder
+	for _, choice := range result.Choices {
+		text.WriteString(choice.Content)
+	}
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{a.OutputKey: text.String()},
+		Log:          text.String(),
+	}, nil
+}
+
+// messages returns the messages of the prompt followed by the scratchpad.
+func (a *ToolCallingAgent) messages(
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]llms.MessageContent, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]llms.MessageContent, 0, len(prompt.Messages())+2*len(intermediateSteps)+1)
+	for _, msg := range prompt.Messages() {
+		messages = append(messages, llms.TextParts(msg.GetType(), msg.GetContent()))
+	}
+	return append(messages, a.constructScratchPad(intermediateSteps)...), nil
+}
+
+func (a *ToolCallingAgent) stream() func(ctx context.Context, chunk []byte) error {
+	if a.CallbacksHandler == nil {
+		return nil
+	}
+	return func(ctx context.Context, chunk []byte) error {
+		a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+		return nil
+	}
+}
+
+// ParseOutput returns the actions for the tool calls of a response, or the
+// finish when the model calls no tools. Some providers return each block of
+// content as a choice, so the text and tool calls of all choices make up the
+// response.
+func (a *ToolCallingAgent) ParseOutput(contentResp *llms.ContentResponse) (
+	[]schema.AgentAction, *schema.AgentFinish, error,
+) {
+	if contentResp == nil || len(contentResp.Choices) == 0 {
+		return nil, nil, fmt.Errorf("no choices in response")
+	}
+
+	var text strings.Builder
+	var calls []llms.ToolCall
+	for _, choice := range contentResp.Choices {
+		text.WriteString(choice.Content)
+		for _, call := range choice.ToolCalls {
+			if call.FunctionCall != nil {
+				calls = append(calls, call)
+			}
+		}
+	}
+
+	if len(calls) == 0 {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: text.String()},
+			Log:          text.String(),
+		}, nil
+	}
+
+	actions := make([]schema.AgentAction, 0, len(calls))
+	for _, call := range calls {
+		input := call.FunctionCall.Arguments
+		if tool := a.tool(call.FunctionCall.Name); tool != nil {
+			input = tools.ToolInput(tool, input)
+		}
+		actions = append(actions, schema.AgentAction{
+			Tool:      call.FunctionCall.Name,
+			ToolInput: input,
+			ToolID:    call.ID,
+		})
+	}
+	// The actions of a response share their log, which is how the scratchpad
+	// groups them back into the response.
+	log := toolCallingLog(text.String(), actions)
+	for i := range actions {
+		actions[i].Log = log
+	}
+	return actions, nil, nil
+}
+
+func (a *ToolCallingAgent) GetInputKeys() []string {
+	return a.Prompt.GetInputVariables()
+}
+
+func (a *ToolCallingAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+func (a *ToolCallingAgent) GetTools() []tools.Tool {
+	return a.Tools
+}
+
+func createToolCallingPrompt(opts Options) prompts.ChatPromptTemplate {
+	messageFormatters := make([]prompts.MessageFormatter, 0, len(opts.extraMessages)+2)
+	if opts.systemMessage != "" {
+		messageFormatters = append(messageFormatters, prompts.NewSystemMessagePromptTemplate(opts.systemMessage, nil))
+	}
+	messageFormatters = append(messageFormatters, opts.extraMessages...)
+	messageFormatters = append(messageFormatters, prompts.NewHumanMessagePromptTemplate("{{.input}}", []string{"input"}))
+	return prompts.NewChatPromptTemplate(messageFormatters)
+}
+
+// toolCallingLog returns the log of the actions of a response: its text
+// followed by a line for each tool call.
+func toolCallingLog(text string, actions []schema.AgentAction) string {
+	var b strings.Builder
+	if text != "" {
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+	for _, action := range actions {
+		fmt.Fprintf(&b, "Invoking: %s with %s\n", action.Tool, action.ToolInput)
+	}
+	return b.String()
+}
+
+func (a *ToolCallingAgent) constructScratchPad(steps []schema.AgentStep) []llms.MessageContent {
+	messages := make([]llms.MessageContent, 0, 2*len(steps))
+	for i := 0; i < len(steps); {
+		if steps[i].Action.Tool == "" {
+			// Steps without an action hold the errors given by the executor.
+			messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, steps[i].Observation))
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(steps) && steps[j].Action.Tool != "" && steps[j].Action.Log == steps[i].Action.Log {
+			j++
+		}
+		messages = append(messages, a.responseMessages(steps[i:j])...)
+		i = j
+	}
+	return messages
+}
+
+// responseMessages returns the AI message of a response of the model and the
+// tool messages with the observations of its calls. Each tool response holds
+// the ID of the call for providers pairing them by ID, and the name of the
+// tool for providers pairing them by name.
+func (a *ToolCallingAgent) responseMessages(steps []schema.AgentStep) []llms.MessageContent {
+	actions := make([]schema.AgentAction, 0, len(steps))
+	for _, step := range steps {
+		actions = append(actions, step.Action)
+	}
+
+	ai := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+	log := steps[0].Action.Log
+	if suffix := toolCallingLog("", actions); strings.HasSuffix(log, suffix) {
+		if text := strings.TrimSuffix(strings.TrimSuffix(log, suffix), "\n"); text != "" {
+			ai.Parts = append(ai.Parts, llms.TextContent{Text: text})
+		}
+	}
+	for _, action := range actions {
+		ai.Parts = append(ai.Parts, llms.ToolCall{
+			ID:   action.ToolID,
+			Type: "function",
+			FunctionCall: &llms.FunctionCall{
+				Name:      action.Tool,
+				Arguments: a.toolArguments(action),
+			},
+		})
+	}
+
+	messages := []llms.MessageContent{ai}
+	for _, step := range steps {
+		messages = append(messages, llms.MessageContent{
+			Role: llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{
+				ToolCallID: step.Action.ToolID,
+				Name:       step.Action.Tool,
+				Content:    step.Observation,
+			}},
+		})
+	}
+	return messages
+}
+
+// toolArguments returns the arguments of the tool call of an action, which
+// are the input of a SchemaTool and the "__arg1" argument of other tools.
+func (a *ToolCallingAgent) toolArguments(action schema.AgentAction) string {
+	tool := a.tool(action.Tool)
+	if _, ok := tool.(tools.SchemaTool); ok || (tool == nil && json.Valid([]byte(action.ToolInput))) {
+		return action.ToolInput
+	}
+	args, err := json.Marshal(map[string]string{"__arg1": action.ToolInput})
+	if err != nil {
+		return action.ToolInput
+	}
+	return string(args)
+}
+
+func (a *ToolCallingAgent) tool(name string) tools.Tool { //nolint:ireturn
+	for _, tool := range a.Tools {
+		if strings.EqualFold(tool.Name(), name) {
+			return tool
+		}
+	}
+	return nil
+}
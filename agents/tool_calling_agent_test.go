@@ -0,0 +1,107 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// toolCallingModel responds with its responses in order, streaming their
+// text, and records the messages and options of each request.
+type toolCallingModel struct {
+	responses []*llms.ContentResponse
+	messages  [][]llms.MessageContent
+	options   []llms.CallOptions
+}
+
+func (m *toolCallingModel) GenerateContent(_ context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.messages = append(m.messages, messages)
+	m.options = append(m.options, opts)
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	if opts.StreamingFunc != nil {
+		for _, choice := range resp.Choices {
+			if choice.Content == "" {
+				continue
+			}
+			if err := opts.StreamingFunc(context.Background(), []byte(choice.Content)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (m *toolCallingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func toolCallPart(id, name, arguments string) llms.ToolCall {
+	return llms.ToolCall{ID: id, Type: "function", FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments}}
+}
+
+func toolResponseMessage(id, name, content string) llms.MessageContent {
+	return llms.MessageContent{
+		Role:  llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: id, Name: name, Content: content}},
+	}
+}
+
+func TestToolCallingAgent(t *testing.T) {
+	t.Parallel()
+
+	type weatherInput struct {
+		City string `json:"city"`
+	}
+	weather := tools.NewFunc("weather", "Gets the weather.", func(_ context.Context, in weatherInput) (string, error) {
+		return "sunny in " + in.City, nil
+	})
+	model := &toolCallingModel{responses: []*llms.ContentResponse{
+		// Providers may return the text and each tool call as a choice, and
+		// calls without IDs.
+		{Choices: []*llms.ContentChoice{
+			{Content: "Let me check."},
+			{ToolCalls: []llms.ToolCall{toolCallPart("", "weather", `{"city": "Paris"}`)}},
+			{ToolCalls: []llms.ToolCall{toolCallPart("", "calculator", `{"__arg1": "1 + 2"}`)}},
+		}},
+		// Responses with the same text are not merged.
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("id", "weather", `{"city": "Rome"}`)}}}},
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCallPart("", "search", `{}`)}}}},
+		{Choices: []*llms.ContentChoice{{Content: "It is sunny."}}},
+	}}
+	agent := agents.NewToolCallingAgent(model, []tools.Tool{weather, tools.Calculator{}})
+	require.Equal(t, []string{"input"}, agent.GetInputKeys())
+
+	out, err := chains.Run(context.Background(), agents.NewExecutor(agent), "Weather in Paris?")
+	require.NoError(t, err)
+	require.Equal(t, "It is sunny.", out)
+
+	require.Len(t, model.options[0].Tools, 2)
+	require.Equal(t, weather.Schema(), model.options[0].Tools[0].Function.Parameters)
+
+	want := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "You are a helpful AI assistant."),
+		llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Paris?"),
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{
+			llms.TextContent{Text: "Let me check."},
+			toolCallPart("call_0", "weather", `{"city": "Paris"}`),
+			toolCallPart("call_1", "calculator", `{"__arg1":"1 + 2"}`),
+		}},
+		toolResponseMessage("call_0", "weather", "sunny in Paris"),
+		toolResponseMessage("call_1", "calculator", "3"),
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{toolCallPart("id", "weather", `{"city": "Rome"}`)}},
+		toolResponseMessage("id", "weather", "sunny in Rome"),
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{toolCallPart("call_3", "search", `{}`)}},
+		toolResponseMessage("call_3", "search", "search is not a valid tool, try another one"),
+	}
+	require.Equal(t, want, model.messages[3])
+}
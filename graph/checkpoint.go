@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmc/langchaingo/internal/runstore"
+)
+
+// ErrCheckpointNotFound is returned by checkpointers when there is no checkpoint for a run. It is
+// also agents.ErrCheckpointNotFound.
+var ErrCheckpointNotFound = runstore.ErrNotFound
+
+// Checkpoint is a snapshot of a run of a graph, saved after every step.
+type Checkpoint struct {
+	// RunID identifies the run.
+	RunID string `json:"run_id"`
+	// State is the JSON encoding of the state after the step.
+	State json.RawMessage `json:"state"`
+	// Next are the nodes of the next step.
+	Next []string `json:"next"`
+	// Step is the number of steps done.
+	Step int `json:"step"`
+	// Time is the time of the checkpoint.
+	Time time.Time `json:"time"`
+}
+
+// Checkpointer stores the checkpoints of runs of graphs, so that runs can be resumed by another
+// process. Checkpointers must be safe for concurrent use.
+type Checkpointer interface {
+	// Save saves the checkpoint of a run, replacing the previous one.
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+	// Load returns the checkpoint of a run, or ErrCheckpointNotFound.
+	Load(ctx context.Context, runID string) (*Checkpoint, error)
+	// Delete deletes the checkpoint of a run. Deleting a missing checkpoint is not an error.
+	Delete(ctx context.Context, runID string) error
+}
+
+// ContextWithRunID returns a context identifying the run of a graph. Graphs with a Checkpointer
+// save the checkpoints of the run under this ID, and resume the run from its checkpoint when it
+// has one. It is the same ID as with agents.ContextWithRunID.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return runstore.ContextWithRunID(ctx, runID)
+}
+
+// RunIDFromContext returns the ID of the run set with ContextWithRunID.
+func RunIDFromContext(ctx context.Context) string {
+	return runstore.RunIDFromContext(ctx)
+}
+
+// checkpoint returns the checkpoint to resume the run of the context from, if any.
+func (g *Graph[S]) checkpoint(ctx context.Context) (*Checkpoint, error) {
+	runID := RunIDFromContext(ctx)
+	if g.Checkpointer == nil || runID == "" {
+		return nil, nil
+	}
+	checkpoint, err := g.Checkpointer.Load(ctx, runID)
+	if errors.Is(err, ErrCheckpointNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// saveCheckpoint saves the checkpoint of the run of the context.
+func (g *Graph[S]) saveCheckpoint(ctx context.Context, state S, next []string, step int) error {
+	runID := RunIDFromContext(ctx)
+	if g.Checkpointer == nil || runID == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	checkpoint := &Checkpoint{
+		RunID: runID,
+		State: data,
+		Next:  next,
+		Step:  step,
+		Time:  time.Now(),
+	}
+	if err := g.Checkpointer.Save(ctx, checkpoint); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// deleteCheckpoint deletes the checkpoint of the run of the context once it is done.
+func (g *Graph[S]) deleteCheckpoint(ctx context.Context) error {
+	runID := RunIDFromContext(ctx)
+	if g.Checkpointer == nil || runID == "" {
+		return nil
+	}
+	if err := g.Checkpointer.Delete(ctx, runID); err != nil {
+		return fmt.Errorf("delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+func decodeState[S any](data []byte) (S, error) {
+	var state S
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("decode state: %w", err)
+	}
+	return state, nil
+}
+
+// MemoryCheckpointer is a Checkpointer keeping checkpoints in memory.
+type MemoryCheckpointer struct {
+	store *runstore.Memory[Checkpoint]
+}
+
+var _ Checkpointer = (*MemoryCheckpointer)(nil)
+
+// NewMemoryCheckpointer creates a new MemoryCheckpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{store: runstore.NewMemory[Checkpoint]()}
+}
+
+// Save saves the checkpoint of a run.
+func (m *MemoryCheckpointer) Save(_ context.Context, checkpoint *Checkpoint) error {
+	return m.store.Save(checkpoint.RunID, checkpoint)
+}
+
+// Load returns the checkpoint of a run.
+func (m *MemoryCheckpointer) Load(_ context.Context, runID string) (*Checkpoint, error) {
+	return m.store.Load(runID)
+}
+
+// Delete deletes the checkpoint of a run.
+func (m *MemoryCheckpointer) Delete(_ context.Context, runID string) error {
+	m.store.Delete(runID)
+	return nil
+}
@@ -0,0 +1,28 @@
+// Package graph runs workflows defined as graphs of nodes over a typed shared
+// state.
+//
+// A node is a function returning an update of the state, which is combined
+// with the state by the reducer of the graph. Nodes can be Go functions,
+// chains, agents or LLM calls, see ChainNode, AgentNode and LLMNode. Edges
+// connect the nodes statically, or conditionally with a function choosing the
+// next nodes from the state. Graphs can have cycles, such as a draft being
+// reviewed and revised until it is approved:
+//
+//	g := graph.New[Draft]()
+//	g.AddNode("write", write)
+//	g.AddNode("review", review)
+//	g.AddEdge(graph.Start, "write")
+//	g.AddEdge("write", "review")
+//	g.AddConditionalEdges("review", func(_ context.Context, d Draft) ([]string, error) {
+//		if d.Approved {
+//			return []string{graph.End}, nil
+//		}
+//		return []string{"write"}, nil
+//	})
+//	draft, err := g.Invoke(ctx, Draft{Topic: "graphs"})
+//
+// The graph runs in steps. The nodes of a step run concurrently with the state
+// of the previous step, and the next step runs their successors. A node with
+// several successors fans out to them, and a node that is the successor of
+// several nodes of a step fans them in, running once.
+package graph
@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// Start is the virtual node the edges to the first nodes come from.
+	Start = "__start__"
+	// End is the virtual node ending the branch of the graph leading to it.
+	End = "__end__"
+
+	_defaultMaxSteps = 25
+)
+
+var (
+	// ErrInvalidGraph is returned when a graph is not valid, such as when
+	// an edge leads to an unknown node.
+	ErrInvalidGraph = errors.New("invalid graph")
+	// ErrUnknownNode is returned when a conditional edge leads to an
+	// unknown node.
+	ErrUnknownNode = errors.New("unknown node")
+	// ErrMaxSteps is returned when a run does not end within the maximum
+	// number of steps of the graph.
+	ErrMaxSteps = errors.New("graph did not end before max steps")
+	// ErrNoReducer is returned when several nodes run in a step of a graph
+	// without a reducer.
+	ErrNoReducer = errors.New("parallel nodes need a reducer")
+)
+
+// NodeFunc is the function of a node. It returns the update of the state,
+// which is combined with the state by the reducer of the graph. The state must
+// not be changed in place, as it is shared by the nodes of a step.
+type NodeFunc[S any] func(ctx context.Context, state S) (S, error)
+
+// RouteFunc chooses the next nodes from the state, for conditional edges.
+// Returning End or no nodes ends the branch.
+type RouteFunc[S any] func(ctx context.Context, state S) ([]string, error)
+
+// Reducer combines the update returned by a node with the state.
+type Reducer[S any] func(state, update S) S
+
+// Graph is a workflow of nodes connected by edges over a shared state of type
+// S. The zero value is not usable, graphs are created with New.
+type Graph[S any] struct {
+	// Reducer combines the updates of the nodes with the state, in the order
+	// of the nodes in their step. Without a reducer the update of a node is
+	// the new state, and steps cannot have several nodes.
+	Reducer Reducer[S]
+	// MaxSteps is the maximum number of steps of a run.
+	MaxSteps int
+	// Checkpointer saves the state of runs identified with ContextWithRunID
+	// after every step, and resumes them from it. States are encoded to JSON.
+	Checkpointer Checkpointer
+
+	nodes  map[string]NodeFunc[S]
+	edges  map[string][]string
+	routes map[string][]RouteFunc[S]
+	errs   []error
+
+	// emit receives the events of runs streamed with Stream.
+	emit func(event Event[S])
+}
+
+// New creates a new empty graph.
+func New[S any]() *Graph[S] {
+	return &Graph[S]{
+		MaxSteps: _defaultMaxSteps,
+		nodes:    make(map[string]NodeFunc[S]),
+		edges:    make(map[string][]string),
+		routes:   make(map[string][]RouteFunc[S]),
+	}
+}
+
+// AddNode adds a node to the graph. Errors, such as a duplicate name, are
+// returned by Validate and the runs of the graph.
+func (g *Graph[S]) AddNode(name string, fn NodeFunc[S]) {
+	switch {
+	case name == "" || name == Start || name == End:
+		g.errs = append(g.errs, fmt.Errorf("%w: invalid node name %q", ErrInvalidGraph, name))
+	case g.nodes[name] != nil:
+		g.errs = append(g.errs, fmt.Errorf("%w: duplicate node %s", ErrInvalidGraph, name))
+	case fn == nil:
+		g.errs = append(g.errs, fmt.Errorf("%w: node %s has no function", ErrInvalidGraph, name))
+	default:
+		g.nodes[name] = fn
+	}
+}
+
+// AddEdge adds an edge between two nodes, which can be Start and End.
+func (g *Graph[S]) AddEdge(from, to string) {
+	g.edges[from] = append(g.edges[from], to)
+}
+
+// AddConditionalEdges adds edges from a node, which can be Start, to the
+// nodes chosen by route after the node runs.
+func (g *Graph[S]) AddConditionalEdges(from string, route RouteFunc[S]) {
+	g.routes[from] = append(g.routes[from], route)
+}
+
+// Validate returns an error wrapping ErrInvalidGraph if the graph is not
+// valid.
+func (g *Graph[S]) Validate() error {
+	errs := slices.Clone(g.errs)
+	if len(g.edges[Start]) == 0 && len(g.routes[Start]) == 0 {
+		errs = append(errs, fmt.Errorf("%w: no edge from %s", ErrInvalidGraph, Start))
+	}
+	for from, tos := range g.edges {
+		if from == End || (from != Start && g.nodes[from] == nil) {
+			errs = append(errs, fmt.Errorf("%w: edge from unknown node %s", ErrInvalidGraph, from))
+		}
+		for _, to := range tos {
+			if to == Start || (to != End && g.nodes[to] == nil) {
+				errs = append(errs, fmt.Errorf("%w: edge from %s to unknown node %s", ErrInvalidGraph, from, to))
+			}
+		}
+	}
+	for from := range g.routes {
+		if from == End || (from != Start && g.nodes[from] == nil) {
+			errs = append(errs, fmt.Errorf("%w: conditional edges from unknown node %s", ErrInvalidGraph, from))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Invoke runs the graph from the given state and returns the final state.
+// When the graph has a Checkpointer and the context a run ID with a
+// checkpoint, the run is resumed from the checkpoint rather than the given
+// state.
+func (g *Graph[S]) Invoke(ctx context.Context, state S) (S, error) {
+	if err := g.Validate(); err != nil {
+		return state, err
+	}
+
+	checkpoint, err := g.checkpoint(ctx)
+	if err != nil {
+		return state, err
+	}
+	if checkpoint != nil {
+		if state, err = decodeState[S](checkpoint.State); err != nil {
+			return state, err
+		}
+		return g.run(ctx, state, checkpoint.Next, checkpoint.Step)
+	}
+
+	next, err := g.successors(ctx, state, []string{Start})
+	if err != nil {
+		return state, err
+	}
+	return g.run(ctx, state, next, 0)
+}
+
+// run runs the steps of the graph from the given one, starting with the next
+// nodes.
+func (g *Graph[S]) run(ctx context.Context, state S, next []string, step int) (S, error) {
+	for ; len(next) > 0; step++ {
+		if err := ctx.Err(); err != nil {
+			return state, err
+		}
+		if step >= g.MaxSteps {
+			return state, fmt.Errorf("%w: %d", ErrMaxSteps, g.MaxSteps)
+		}
+		if len(next) > 1 && g.Reducer == nil {
+			return state, fmt.Errorf("%w: %v", ErrNoReducer, next)
+		}
+
+		updates, err := g.runStep(ctx, state, next, step)
+		if err != nil {
+			return state, err
+		}
+		for _, update := range updates {
+			state = g.reduce(state, update)
+		}
+
+		next, err = g.successors(ctx, state, next)
+		if err != nil {
+			return state, err
+		}
+		if err := g.saveCheckpoint(ctx, state, next, step+1); err != nil {
+			return state, err
+		}
+	}
+
+	g.event(Event[S]{Type: EventEnd, Step: step, State: state})
+	return state, g.deleteCheckpoint(ctx)
+}
+
+// runStep runs the nodes of a step concurrently and returns their updates in
+// order. The first error of a node cancels the others.
+func (g *Graph[S]) runStep(ctx context.Context, state S, nodes []string, step int) ([]S, error) {
+	updates := make([]S, len(nodes))
+	if len(nodes) == 1 {
+		update, err := g.runNode(ctx, state, nodes[0], step)
+		updates[0] = update
+		return updates, err
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, node := range nodes {
+		eg.Go(func() error {
+			update, err := g.runNode(ctx, state, node, step)
+			updates[i] = update
+			return err
+		})
+	}
+	return updates, eg.Wait()
+}
+
+func (g *Graph[S]) runNode(ctx context.Context, state S, node string, step int) (S, error) {
+	g.event(Event[S]{Type: EventNodeStart, Step: step, Node: node})
+	update, err := g.nodes[node](ctx, state)
+	if err != nil {
+		return update, fmt.Errorf("node %s: %w", node, err)
+	}
+	g.event(Event[S]{Type: EventNodeEnd, Step: step, Node: node, Update: update})
+	return update, nil
+}
+
+func (g *Graph[S]) reduce(state, update S) S {
+	if g.Reducer == nil {
+		return update
+	}
+	return g.Reducer(state, update)
+}
+
+// successors returns the nodes following the given ones, in order and
+// without duplicates or End.
+func (g *Graph[S]) successors(ctx context.Context, state S, nodes []string) ([]string, error) {
+	var next []string
+	add := func(to string) {
+		if to != End && !slices.Contains(next, to) {
+			next = append(next, to)
+		}
+	}
+
+	for _, node := range nodes {
+		for _, to := range g.edges[node] {
+			add(to)
+		}
+		for _, route := range g.routes[node] {
+			tos, err := route(ctx, state)
+			if err != nil {
+				return nil, fmt.Errorf("route from %s: %w", node, err)
+			}
+			for _, to := range tos {
+				if to != End && g.nodes[to] == nil {
+					return nil, fmt.Errorf("%w: route from %s to %s", ErrUnknownNode, node, to)
+				}
+				add(to)
+			}
+		}
+	}
+	return next, nil
+}
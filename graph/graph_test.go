@@ -0,0 +1,240 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/graph"
+	"github.com/tmc/langchaingo/llms"
+)
+
+type draft struct {
+	Text     string   `json:"text"`
+	Reviews  []string `json:"reviews"`
+	Approved bool     `json:"approved"`
+}
+
+// reviewGraph writes a draft and revises it until the review approves it,
+// at the given revision.
+func reviewGraph(approveAt int) *graph.Graph[draft] {
+	g := graph.New[draft]()
+	g.AddNode("write", func(_ context.Context, d draft) (draft, error) {
+		d.Text = fmt.Sprintf("draft %d", len(d.Reviews)+1)
+		return d, nil
+	})
+	g.AddNode("review", func(_ context.Context, d draft) (draft, error) {
+		d.Reviews = append(d.Reviews[:len(d.Reviews):len(d.Reviews)], "review of "+d.Text)
+		d.Approved = len(d.Reviews) == approveAt
+		return d, nil
+	})
+	g.AddEdge(graph.Start, "write")
+	g.AddEdge("write", "review")
+	g.AddConditionalEdges("review", func(_ context.Context, d draft) ([]string, error) {
+		if d.Approved {
+			return []string{graph.End}, nil
+		}
+		return []string{"write"}, nil
+	})
+	return g
+}
+
+func TestGraphCycle(t *testing.T) {
+	t.Parallel()
+
+	d, err := reviewGraph(3).Invoke(context.Background(), draft{})
+	require.NoError(t, err)
+	require.True(t, d.Approved)
+	require.Equal(t, "draft 3", d.Text)
+	require.Equal(t, []string{"review of draft 1", "review of draft 2", "review of draft 3"}, d.Reviews)
+
+	g := reviewGraph(100)
+	g.MaxSteps = 5
+	d, err = g.Invoke(context.Background(), draft{})
+	require.ErrorIs(t, err, graph.ErrMaxSteps)
+	require.Len(t, d.Reviews, 2)
+}
+
+func TestGraphFanOut(t *testing.T) {
+	t.Parallel()
+
+	type state struct {
+		Log []string
+	}
+	node := func(name string) graph.NodeFunc[state] {
+		return func(_ context.Context, s state) (state, error) {
+			return state{Log: []string{fmt.Sprintf("%s after %d", name, len(s.Log))}}, nil
+		}
+	}
+
+	g := graph.New[state]()
+	for _, name := range []string{"split", "a", "b", "c", "join"} {
+		g.AddNode(name, node(name))
+	}
+	g.AddEdge(graph.Start, "split")
+	g.AddEdge("split", "a")
+	g.AddEdge("split", "b")
+	g.AddConditionalEdges("split", func(context.Context, state) ([]string, error) {
+		return []string{"c", "a"}, nil
+	})
+	g.AddEdge("a", "join")
+	g.AddEdge("b", "join")
+	g.AddEdge("c", "join")
+	g.AddEdge("join", graph.End)
+
+	_, err := g.Invoke(context.Background(), state{})
+	require.ErrorIs(t, err, graph.ErrNoReducer)
+
+	g.Reducer = func(s, update state) state {
+		return state{Log: append(s.Log[:len(s.Log):len(s.Log)], update.Log...)}
+	}
+	s, err := g.Invoke(context.Background(), state{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"split after 0", "a after 1", "b after 1", "c after 1", "join after 4"}, s.Log)
+}
+
+func TestGraphStream(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+	for event := range reviewGraph(2).Stream(context.Background(), draft{}) {
+		line := fmt.Sprintf("%d %s %s", event.Step, event.Type, event.Node)
+		switch event.Type {
+		case graph.EventNodeEnd:
+			line += " " + event.Update.Text
+		case graph.EventEnd:
+			line += strings.Join(event.State.Reviews, ", ")
+		}
+		events = append(events, line)
+	}
+	require.Equal(t, []string{
+		"0 node_start write",
+		"0 node_end write draft 1",
+		"1 node_start review",
+		"1 node_end review draft 1",
+		"2 node_start write",
+		"2 node_end write draft 2",
+		"3 node_start review",
+		"3 node_end review draft 2",
+		"4 end review of draft 1, review of draft 2",
+	}, events)
+
+	// Stopping the iteration stops the run.
+	var n int
+	for range reviewGraph(100).Stream(context.Background(), draft{}) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	require.Equal(t, 3, n)
+}
+
+func TestGraphCheckpoint(t *testing.T) {
+	t.Parallel()
+	ctx := graph.ContextWithRunID(context.Background(), "run")
+	checkpointer := graph.NewMemoryCheckpointer()
+
+	// The review fails the second time.
+	reviews := 0
+	g := graph.New[draft]()
+	g.Checkpointer = checkpointer
+	g.AddNode("write", func(_ context.Context, d draft) (draft, error) {
+		d.Text = fmt.Sprintf("draft %d", len(d.Reviews)+1)
+		return d, nil
+	})
+	g.AddNode("review", func(_ context.Context, d draft) (draft, error) {
+		reviews++
+		if reviews == 2 {
+			return d, errors.New("reviewer unavailable")
+		}
+		d.Reviews = append(d.Reviews[:len(d.Reviews):len(d.Reviews)], "review of "+d.Text)
+		d.Approved = len(d.Reviews) == 2
+		return d, nil
+	})
+	g.AddEdge(graph.Start, "write")
+	g.AddEdge("write", "review")
+	g.AddConditionalEdges("review", func(_ context.Context, d draft) ([]string, error) {
+		if d.Approved {
+			return nil, nil
+		}
+		return []string{"write"}, nil
+	})
+
+	_, err := g.Invoke(ctx, draft{})
+	require.ErrorContains(t, err, "node review: reviewer unavailable")
+	checkpoint, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, 3, checkpoint.Step)
+	require.Equal(t, []string{"review"}, checkpoint.Next)
+
+	// The run resumes with the failed review.
+	d, err := g.Invoke(ctx, draft{Text: "ignored"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"review of draft 1", "review of draft 2"}, d.Reviews)
+	_, err = checkpointer.Load(ctx, "run")
+	require.ErrorIs(t, err, graph.ErrCheckpointNotFound)
+
+	// Agent executors in the nodes of the graph share the run ID and errors.
+	require.Equal(t, "run", agents.RunIDFromContext(ctx))
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
+
+func TestGraphValidate(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New[int]()
+	require.ErrorIs(t, g.Validate(), graph.ErrInvalidGraph)
+
+	inc := func(_ context.Context, n int) (int, error) { return n + 1, nil }
+	g.AddNode("inc", inc)
+	g.AddNode("inc", inc)
+	g.AddEdge(graph.Start, "inc")
+	g.AddEdge("inc", "missing")
+	err := g.Validate()
+	require.ErrorIs(t, err, graph.ErrInvalidGraph)
+	require.ErrorContains(t, err, "duplicate node inc")
+	require.ErrorContains(t, err, "edge from inc to unknown node missing")
+
+	g = graph.New[int]()
+	g.AddNode("inc", inc)
+	g.AddEdge(graph.Start, "inc")
+	g.AddConditionalEdges("inc", func(context.Context, int) ([]string, error) { return []string{"missing"}, nil })
+	_, err = g.Invoke(context.Background(), 0)
+	require.ErrorIs(t, err, graph.ErrUnknownNode)
+}
+
+// echoModel responds with the text of the last message.
+type echoModel struct{}
+
+func (echoModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	text, _ := messages[len(messages)-1].Parts[0].(llms.TextContent)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: strings.ToUpper(text.Text)}}}, nil
+}
+
+func (m echoModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestLLMNode(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New[draft]()
+	g.AddNode("shout", graph.LLMNode(echoModel{},
+		func(d draft) []llms.MessageContent {
+			return []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, d.Text)}
+		},
+		func(d draft, text string) draft {
+			d.Text = text
+			return d
+		}))
+	g.AddEdge(graph.Start, "shout")
+
+	d, err := g.Invoke(context.Background(), draft{Text: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", d.Text)
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ChainNode returns a node calling a chain with the inputs made from the
+// state, whose update is made from the outputs of the chain.
+func ChainNode[S any](
+	chain chains.Chain,
+	inputs func(state S) map[string]any,
+	update func(state S, outputs map[string]any) (S, error),
+	options ...chains.ChainCallOption,
+) NodeFunc[S] {
+	return func(ctx context.Context, state S) (S, error) {
+		outputs, err := chains.Call(ctx, chain, inputs(state), options...)
+		if err != nil {
+			return state, err
+		}
+		return update(state, outputs)
+	}
+}
+
+// AgentNode returns a node running an agent executor with the input made from
+// the state, whose update is made from the output of the agent.
+func AgentNode[S any](
+	executor *agents.Executor,
+	input func(state S) string,
+	update func(state S, output string) S,
+	options ...chains.ChainCallOption,
+) NodeFunc[S] {
+	return func(ctx context.Context, state S) (S, error) {
+		output, err := chains.Run(ctx, executor, input(state), options...)
+		if err != nil {
+			return state, err
+		}
+		return update(state, output), nil
+	}
+}
+
+// LLMNode returns a node generating content with a model from the messages
+// made from the state, whose update is made from the text of the response.
+func LLMNode[S any](
+	llm llms.Model,
+	messages func(state S) []llms.MessageContent,
+	update func(state S, text string) S,
+	options ...llms.CallOption,
+) NodeFunc[S] {
+	return func(ctx context.Context, state S) (S, error) {
+		resp, err := llm.GenerateContent(ctx, messages(state), options...)
+		if err != nil {
+			return state, err
+		}
+		if len(resp.Choices) == 0 {
+			return state, errors.New("no choices in response")
+		}
+		return update(state, resp.Choices[0].Content), nil
+	}
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"context"
+	"iter"
+
+	"github.com/tmc/langchaingo/internal/runstore"
+)
+
+// EventType is the type of an event of a streamed run.
+type EventType string
+
+const (
+	// EventNodeStart is sent when a node starts.
+	EventNodeStart EventType = "node_start"
+	// EventNodeEnd is sent with the update returned by a node.
+	EventNodeEnd EventType = "node_end"
+	// EventEnd is the last event of a run ending, with the final state.
+	EventEnd EventType = "end"
+	// EventError is the last event of a run failing.
+	EventError EventType = "error"
+)
+
+// Event is an event of a run streamed with Graph.Stream.
+type Event[S any] struct {
+	Type EventType
+	// Step is the step of the run.
+	Step int
+	// Node is the node, for EventNodeStart and EventNodeEnd.
+	Node string
+	// Update is the update returned by the node, for EventNodeEnd.
+	Update S
+	// State is the final state, for EventEnd.
+	State S
+	// Err is the error of the run, for EventError.
+	Err error
+}
+
+// Stream runs the graph as Invoke does, and returns an iterator over the
+// events of the run as they happen. The last event is an EventEnd or an
+// EventError. Stopping the iteration cancels the run.
+func (g *Graph[S]) Stream(ctx context.Context, state S) iter.Seq[Event[S]] {
+	return runstore.Stream(ctx, func(ctx context.Context, emit func(Event[S])) {
+		graph := *g
+		graph.emit = emit
+		if _, err := graph.Invoke(ctx, state); err != nil {
+			emit(Event[S]{Type: EventError, Err: err})
+		}
+	})
+}
+
+// event sends an event of the run to Stream.
+func (g *Graph[S]) event(event Event[S]) {
+	if g.emit != nil {
+		g.emit(event)
+	}
+}
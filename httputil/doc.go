@@ -45,6 +45,23 @@
 //	    },
 //	}
 //
+// # Retries
+//
+// RetryTransport retries requests that fail with a network error or a
+// retryable status code, using jittered exponential backoff and honoring
+// Retry-After and rate limit reset headers:
+//
+//	client := &http.Client{
+//	    Transport: &httputil.RetryTransport{
+//	        Transport:  httputil.DefaultTransport,
+//	        MaxRetries: 5,
+//	    },
+//	}
+//
+// API clients report unsuccessful responses as a ResponseError, which keeps
+// the status code and headers for callers that retry at a higher level, such
+// as the llms/retry package.
+//
 // # Integration with httprr
 //
 // The transports in this package are designed to work with the httprr
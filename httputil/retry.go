@@ -0,0 +1,273 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRetries is the number of retries used by [RetryTransport] when
+// MaxRetries is zero.
+const DefaultMaxRetries = 3
+
+// ResponseError is an error for an unsuccessful HTTP response. API clients
+// return it so callers can inspect the status code and headers of the
+// response, for example to honor a Retry-After header.
+type ResponseError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header holds the response headers.
+	Header http.Header
+	// Err describes the failure, usually including the API's error message.
+	Err error
+}
+
+// NewResponseError returns a ResponseError for resp that wraps err.
+func NewResponseError(resp *http.Response, err error) *ResponseError {
+	return &ResponseError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Err:        err,
+	}
+}
+
+// Error implements the error interface.
+func (e *ResponseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter returns how long the server asked the client to wait before
+// retrying, or zero if the response has no such header.
+func (e *ResponseError) RetryAfter() time.Duration {
+	d, _ := RetryAfter(e.Header)
+	return d
+}
+
+// RetryAfter returns how long the response headers ask the client to wait
+// before sending another request. It understands the standard Retry-After
+// header in both its seconds and HTTP-date forms, retry-after-ms, OpenAI's
+// x-ratelimit-reset-requests and x-ratelimit-reset-tokens durations and
+// Anthropic's anthropic-ratelimit-*-reset timestamps. Retry-After and
+// retry-after-ms take precedence, as they are meant for the failed request,
+// while the provider reset headers describe the rate limit windows and are
+// only used without them. When several headers of a kind are present the
+// longest wait is returned.
+func RetryAfter(header http.Header) (time.Duration, bool) {
+	return retryAfter(header, time.Now())
+}
+
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	var wait time.Duration
+	var found bool
+	use := func(d time.Duration) {
+		if d < 0 {
+			d = 0
+		}
+		if !found || d > wait {
+			wait = d
+		}
+		found = true
+	}
+
+	if v := header.Get("Retry-After-Ms"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil {
+			use(time.Duration(ms * float64(time.Millisecond)))
+		}
+	}
+	if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			use(time.Duration(secs * float64(time.Second)))
+		} else if t, err := http.ParseTime(v); err == nil {
+			use(t.Sub(now))
+		}
+	}
+	if found {
+		return wait, true
+	}
+	for _, key := range []string{"X-Ratelimit-Reset-Requests", "X-Ratelimit-Reset-Tokens"} {
+		if v := header.Get(key); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				use(d)
+			}
+		}
+	}
+	for key, values := range header {
+		lower := strings.ToLower(key)
+		if !strings.HasPrefix(lower, "anthropic-ratelimit-") || !strings.HasSuffix(lower, "-reset") || len(values) == 0 {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, values[0]); err == nil {
+			use(t.Sub(now))
+		}
+	}
+	return wait, found
+}
+
+// Backoff computes jittered exponential delays between retries.
+// The zero value uses the defaults documented on each field.
+type Backoff struct {
+	// Initial is the delay before the first retry. Defaults to 500ms.
+	Initial time.Duration
+	// Max is the longest delay between retries. Defaults to 30s.
+	Max time.Duration
+	// Multiplier is the factor the delay grows by after each retry.
+	// Defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction of each delay, between 0 and 1, that is
+	// randomized to spread out retries from concurrent clients. Defaults
+	// to 0.2. Use a negative value to disable jitter.
+	Jitter float64
+}
+
+// Delay returns the delay before the given retry, counting from zero.
+func (b Backoff) Delay(retry int) time.Duration {
+	initial, maxDelay, multiplier, jitter := b.Initial, b.MaxDelay(), b.Multiplier, b.Jitter
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	if jitter == 0 {
+		jitter = 0.2
+	}
+
+	d := float64(initial)
+	for i := 0; i < retry && d < float64(maxDelay); i++ {
+		d *= multiplier
+	}
+	d = min(d, float64(maxDelay))
+	if jitter > 0 {
+		jitter = min(jitter, 1)
+		d -= d * jitter * rand.Float64() //nolint:gosec
+	}
+	return time.Duration(d)
+}
+
+// MaxDelay returns the longest delay between retries.
+func (b Backoff) MaxDelay() time.Duration {
+	if b.Max <= 0 {
+		return 30 * time.Second
+	}
+	return b.Max
+}
+
+// Wait returns the delay before the given retry, counting from zero, for a
+// server that asked to wait retryAfter. The longer of the two delays is used.
+// It returns false if the server asked for a delay longer than MaxDelay, in
+// which case the caller should give up.
+func (b Backoff) Wait(retry int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > b.MaxDelay() {
+		return 0, false
+	}
+	return max(b.Delay(retry), retryAfter), true
+}
+
+// Sleep waits for d or until ctx is done, returning the context's error in
+// the latter case.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RetryTransport is an [http.RoundTripper] that retries requests failing with
+// a network error or a retryable status code (408, 429, 500, 502, 503 and
+// 504), waiting with jittered exponential backoff and honoring the headers
+// understood by [RetryAfter].
+//
+// Retries only happen before a response is returned to the caller, so
+// streamed response bodies are never retried once the caller reads them.
+// Requests with a body are only retried if the request has GetBody set, as
+// requests built by [http.NewRequest] do.
+type RetryTransport struct {
+	// Transport is the underlying [http.RoundTripper] to use.
+	// If nil, [http.DefaultTransport] is used.
+	Transport http.RoundTripper
+	// MaxRetries is the maximum number of retries. Defaults to
+	// DefaultMaxRetries; use a negative value to disable retries.
+	MaxRetries int
+	// Backoff computes the delay between retries.
+	Backoff Backoff
+}
+
+// RoundTrip implements the [http.RoundTripper] interface.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	maxRetries := t.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	ctx := req.Context()
+	for retry := 0; ; retry++ {
+		attempt := req
+		if retry > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = req.Clone(ctx)
+			attempt.Body = body
+		}
+
+		resp, err := transport.RoundTrip(attempt)
+		if retry >= maxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		var retryAfter time.Duration
+		switch {
+		case err != nil:
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+		case isRetryableStatus(resp.StatusCode):
+			retryAfter, _ = RetryAfter(resp.Header)
+		default:
+			return resp, nil
+		}
+
+		wait, ok := t.Backoff.Wait(retry, retryAfter)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package httputil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		found  bool
+	}{
+		{
+			name:   "no headers",
+			header: http.Header{},
+		},
+		{
+			name:   "retry-after seconds",
+			header: http.Header{"Retry-After": {"7"}},
+			want:   7 * time.Second,
+			found:  true,
+		},
+		{
+			name:   "retry-after http date",
+			header: http.Header{"Retry-After": {now.Add(30 * time.Second).Format(http.TimeFormat)}},
+			want:   30 * time.Second,
+			found:  true,
+		},
+		{
+			name:   "retry-after-ms",
+			header: http.Header{"Retry-After-Ms": {"250"}},
+			want:   250 * time.Millisecond,
+			found:  true,
+		},
+		{
+			name: "openai reset headers use the longest wait",
+			header: http.Header{
+				"X-Ratelimit-Reset-Requests": {"1s"},
+				"X-Ratelimit-Reset-Tokens":   {"6m0s"},
+			},
+			want:  6 * time.Minute,
+			found: true,
+		},
+		{
+			name: "retry-after takes precedence over reset headers",
+			header: http.Header{
+				"Retry-After":              {"1"},
+				"X-Ratelimit-Reset-Tokens": {"1m"},
+			},
+			want:  time.Second,
+			found: true,
+		},
+		{
+			name: "anthropic reset timestamp",
+			header: http.Header{
+				"Anthropic-Ratelimit-Tokens-Reset": {now.Add(12 * time.Second).Format(time.RFC3339)},
+			},
+			want:  12 * time.Second,
+			found: true,
+		},
+		{
+			name:   "past date",
+			header: http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}},
+			want:   0,
+			found:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, found := retryAfter(tt.header, now)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Jitter: 0.5}
+	for retry, base := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		base *= time.Millisecond
+		for range 20 {
+			d := b.Delay(retry)
+			assert.LessOrEqual(t, d, base)
+			assert.GreaterOrEqual(t, d, base/2)
+		}
+	}
+
+	noJitter := Backoff{Initial: 100 * time.Millisecond, Jitter: -1}
+	assert.Equal(t, 400*time.Millisecond, noJitter.Delay(2))
+
+	_, ok := b.Wait(0, 2*time.Second)
+	assert.False(t, ok, "waits longer than Max should give up")
+	wait, ok := b.Wait(0, 500*time.Millisecond)
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{
+		Backoff: Backoff{Initial: time.Millisecond, Jitter: -1},
+	}}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		maxRetries int
+		wantCalls  int32
+	}{
+		{name: "non-retryable status", status: http.StatusBadRequest, wantCalls: 1},
+		{name: "retry-after too long", status: http.StatusTooManyRequests, retryAfter: "3600", wantCalls: 1},
+		{name: "max retries", status: http.StatusBadGateway, maxRetries: 2, wantCalls: 3},
+		{name: "retries disabled", status: http.StatusBadGateway, maxRetries: -1, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{
+				MaxRetries: tt.maxRetries,
+				Backoff:    Backoff{Initial: time.Millisecond},
+			}}
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"2"}},
+	}
+	err := NewResponseError(resp, io.ErrUnexpectedEOF)
+	assert.Equal(t, io.ErrUnexpectedEOF.Error(), err.Error())
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 2*time.Second, err.RetryAfter())
+}
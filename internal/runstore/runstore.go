@@ -0,0 +1,183 @@
+// Package runstore holds what the checkpointing and streaming of agent
+// executors and graphs share: the ID of runs in contexts, the stores of their
+// checkpoints and the iterators over the events of their runs.
+package runstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound is returned by stores when there is no checkpoint for a run.
+var ErrNotFound = errors.New("checkpoint not found")
+
+type runIDKey struct{}
+
+// ContextWithRunID returns a context identifying a run.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the ID of the run set with ContextWithRunID.
+func RunIDFromContext(ctx context.Context) string {
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
+
+// Memory keeps checkpoints of type C in memory. It is safe for concurrent
+// use.
+type Memory[C any] struct {
+	mu          sync.Mutex
+	checkpoints map[string][]byte
+}
+
+// NewMemory returns an empty Memory.
+func NewMemory[C any]() *Memory[C] {
+	return &Memory[C]{checkpoints: make(map[string][]byte)}
+}
+
+// Save saves the checkpoint of a run, replacing the previous one.
+func (m *Memory[C]) Save(runID string, checkpoint *C) error {
+	// Checkpoints are kept encoded so that they are not changed by the run.
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints[runID] = data
+	return nil
+}
+
+// Load returns the checkpoint of a run, or ErrNotFound.
+func (m *Memory[C]) Load(runID string) (*C, error) {
+	m.mu.Lock()
+	data, ok := m.checkpoints[runID]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return decode[C](data)
+}
+
+// Delete deletes the checkpoint of a run.
+func (m *Memory[C]) Delete(runID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.checkpoints, runID)
+}
+
+// File keeps checkpoints of type C as JSON files in a directory.
+type File[C any] struct {
+	dir string
+}
+
+// NewFile returns a File keeping checkpoints in dir, which is created if
+// needed.
+func NewFile[C any](dir string) (File[C], error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return File[C]{}, err
+	}
+	return File[C]{dir: dir}, nil
+}
+
+// Save saves the checkpoint of a run. The file is replaced atomically, so that
+// a crash does not leave a partial checkpoint.
+func (f File[C]) Save(runID string, checkpoint *C) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(runID))
+}
+
+// Load returns the checkpoint of a run, or ErrNotFound.
+func (f File[C]) Load(runID string) (*C, error) {
+	data, err := os.ReadFile(f.path(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decode[C](data)
+}
+
+// Delete deletes the checkpoint of a run. Deleting a missing checkpoint is not
+// an error.
+func (f File[C]) Delete(runID string) error {
+	err := os.Remove(f.path(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f File[C]) path(runID string) string {
+	return filepath.Join(f.dir, url.PathEscape(runID)+".json")
+}
+
+func decode[C any](data []byte) (*C, error) {
+	var checkpoint C
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Stream calls run in a goroutine and returns an iterator over the events it
+// emits, as they happen. Stopping the iteration cancels the context of run
+// and waits for it to return. Until then, all events are yielded, including
+// those emitted after ctx is canceled.
+func Stream[E any](ctx context.Context, run func(ctx context.Context, emit func(E))) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// The run sends its events from its goroutines, and the events are
+		// yielded from this one. They are dropped once the iteration stops.
+		events := make(chan E)
+		stopped := make(chan struct{})
+		emit := func(event E) {
+			select {
+			case events <- event:
+			case <-stopped:
+			}
+		}
+
+		go func() {
+			defer close(events)
+			run(ctx, emit)
+		}()
+
+		for event := range events {
+			if !yield(event) {
+				close(stopped)
+				cancel()
+				for range events {
+					// Wait for the canceled run to stop.
+				}
+				return
+			}
+		}
+	}
+}
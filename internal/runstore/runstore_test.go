@@ -0,0 +1,87 @@
+package runstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type checkpoint struct {
+	Steps []string `json:"steps"`
+}
+
+func TestStores(t *testing.T) {
+	t.Parallel()
+
+	file, err := NewFile[checkpoint](t.TempDir())
+	require.NoError(t, err)
+	memory := NewMemory[checkpoint]()
+
+	for name, store := range map[string]interface {
+		Save(runID string, c *checkpoint) error
+		Load(runID string) (*checkpoint, error)
+	}{"memory": memory, "file": file} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := store.Load("run/1")
+			require.ErrorIs(t, err, ErrNotFound)
+
+			// Checkpoints are not changed by their runs once saved.
+			c := &checkpoint{Steps: []string{"a"}}
+			require.NoError(t, store.Save("run/1", c))
+			c.Steps[0] = "b"
+			got, err := store.Load("run/1")
+			require.NoError(t, err)
+			require.Equal(t, []string{"a"}, got.Steps)
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	canceled := make(chan struct{})
+	events := Stream(context.Background(), func(ctx context.Context, emit func(int)) {
+		for i := 0; ctx.Err() == nil; i++ {
+			emit(i)
+		}
+		close(canceled)
+	})
+
+	var got []int
+	for event := range events {
+		got = append(got, event)
+		if len(got) == 3 {
+			break
+		}
+	}
+	require.Equal(t, []int{0, 1, 2}, got)
+	// Stopping the iteration cancels the run and waits for it.
+	select {
+	case <-canceled:
+	default:
+		t.Fatal("the run was not stopped")
+	}
+}
+
+func TestStreamCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := Stream(ctx, func(ctx context.Context, emit func(string)) {
+		emit("start")
+		<-ctx.Done()
+		emit(ctx.Err().Error())
+	})
+
+	var got []string
+	for event := range events {
+		got = append(got, event)
+		cancel()
+	}
+	// The events emitted after the context is canceled are not dropped.
+	require.Equal(t, []string{"start", context.Canceled.Error()}, got)
+}
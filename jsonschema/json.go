@@ -1,10 +1,15 @@
-// Package jsonschema provides very simple functionality for representing a JSON schema as a
-// (nested) struct. This struct can be used with the chat completion "function call" feature.
-// For more complicated schemas, it is recommended to use a dedicated JSON schema library
-// and/or pass in the schema in []byte format.
+// Package jsonschema provides functionality for representing a JSON schema as a
+// (nested) struct. This struct can be used with the chat completion "function call" feature
+// and for structured outputs.
+//
+// Schemas can be written by hand, or derived from Go types with For and Reflect.
+// Values are checked against a schema with Validate.
 package jsonschema
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type DataType string
 
@@ -34,16 +39,106 @@ type Definition struct {
 	Required []string `json:"required,omitempty"`
 	// Items specifies which data type an array contains, if the schema type is Array.
 	Items *Definition `json:"items,omitempty"`
+
+	// Nullable allows the value to be null as well as of the schema type. It is encoded
+	// as a type of [Type, "null"].
+	Nullable bool `json:"-"`
+	// Format is the format of a string, such as "date-time", "email" or "uri".
+	Format string `json:"format,omitempty"`
+	// Pattern is a regular expression strings must match.
+	Pattern string `json:"pattern,omitempty"`
+	// MinLength and MaxLength limit the length of strings, in characters.
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+	// Minimum and Maximum limit numbers, inclusively.
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+	// ExclusiveMinimum and ExclusiveMaximum limit numbers, exclusively.
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	// MinItems and MaxItems limit the length of arrays.
+	MinItems *int `json:"minItems,omitempty"`
+	MaxItems *int `json:"maxItems,omitempty"`
+	// AdditionalProperties describes the properties of an object not listed in Properties.
+	// It is false to disallow them, or a *Definition they must match.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+	// OneOf requires the value to match exactly one of the schemas.
+	OneOf []Definition `json:"oneOf,omitempty"`
+	// AnyOf requires the value to match at least one of the schemas.
+	AnyOf []Definition `json:"anyOf,omitempty"`
+	// Ref is a reference to another schema, either "#" for the root schema or
+	// "#/$defs/<name>" for a schema in the Defs of the root schema.
+	Ref string `json:"$ref,omitempty"`
+	// Defs holds the schemas referenced by name, in the root schema.
+	Defs map[string]Definition `json:"$defs,omitempty"`
 }
 
 func (d Definition) MarshalJSON() ([]byte, error) {
-	if d.Properties == nil {
-		d.Properties = make(map[string]Definition)
+	// References and combinations of schemas don't describe properties themselves.
+	var properties any
+	if d.Properties != nil {
+		properties = d.Properties
+	} else if d.Ref == "" && len(d.OneOf) == 0 && len(d.AnyOf) == 0 {
+		properties = make(map[string]Definition)
+	}
+	var typ any
+	if d.Type != "" {
+		typ = d.Type
+		if d.Nullable {
+			typ = []DataType{d.Type, Null}
+		}
 	}
 	type Alias Definition
 	return json.Marshal(struct {
+		Type       any `json:"type,omitempty"`
+		Properties any `json:"properties,omitempty"`
 		Alias
 	}{
-		Alias: (Alias)(d),
+		Type:       typ,
+		Properties: properties,
+		Alias:      (Alias)(d),
 	})
 }
+
+// UnmarshalJSON decodes a schema, accepting a type of [type, "null"] for
+// nullable values and a boolean or a schema for additional properties.
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	type Alias Definition
+	var raw struct {
+		Type                 json.RawMessage `json:"type"`
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+		Alias
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = Definition(raw.Alias)
+	if len(raw.Type) > 0 {
+		var types []DataType
+		if err := json.Unmarshal(raw.Type, &d.Type); err != nil {
+			if err := json.Unmarshal(raw.Type, &types); err != nil {
+				return fmt.Errorf("decode type: %w", err)
+			}
+		}
+		for _, t := range types {
+			if t == Null {
+				d.Nullable = true
+			} else if d.Type == "" {
+				d.Type = t
+			}
+		}
+	}
+	if len(raw.AdditionalProperties) > 0 {
+		var allowed bool
+		if err := json.Unmarshal(raw.AdditionalProperties, &allowed); err == nil {
+			d.AdditionalProperties = allowed
+			return nil
+		}
+		additional := &Definition{}
+		if err := json.Unmarshal(raw.AdditionalProperties, additional); err != nil {
+			return fmt.Errorf("decode additionalProperties: %w", err)
+		}
+		d.AdditionalProperties = additional
+	}
+	return nil
+}
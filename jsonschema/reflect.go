@@ -0,0 +1,366 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schemer is implemented by types that describe their own schema, such as
+// types that marshal to JSON in a custom way or that accept one of several
+// shapes with OneOf or AnyOf.
+type Schemer interface {
+	JSONSchema() *Definition
+}
+
+// For returns the schema of the values of type T. See Reflect.
+func For[T any]() *Definition {
+	return reflectType(reflect.TypeFor[T]())
+}
+
+// Reflect returns the schema of the values of the type of v.
+//
+// Structs are objects whose properties are their exported fields, named and
+// flattened like encoding/json does. Other properties are not allowed. Fields
+// are required unless their json tag has the omitempty or omitzero option.
+// Pointers are nullable, maps with string keys are objects whose additional
+// properties have the schema of the map values, and time.Time is a string
+// with the date-time format. Types implementing Schemer describe their own
+// schema.
+//
+// Fields are described further with a jsonschema tag, holding a
+// comma-separated list of keywords, such as:
+//
+//	Unit string `json:"unit" jsonschema:"description=The temperature unit,enum=celsius,enum=fahrenheit"`
+//
+// The keywords are description, enum (repeated for each value), format,
+// pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength,
+// maxLength, minItems, maxItems, required, optional and nullable, as well as
+// oneof_type and anyof_type with a semicolon-separated list of types. Commas
+// in values are escaped with a backslash. A describe tag, as used by
+// outputparser.Defined, also sets the description.
+//
+// Recursive struct types are described once in the $defs of the schema and
+// referenced with $ref. Reflect panics if a tag has an invalid number.
+func Reflect(v any) *Definition {
+	return reflectType(reflect.TypeOf(v))
+}
+
+func reflectType(t reflect.Type) *Definition {
+	if t == nil {
+		return &Definition{}
+	}
+	// The first pass finds the recursive types, the second describes them
+	// in $defs.
+	r := &reflector{root: derefType(t), collecting: true, recursive: map[reflect.Type]bool{}, stack: map[reflect.Type]bool{}}
+	r.reflect(t)
+	r.collecting = false
+	r.defs = map[string]Definition{}
+	r.names = map[reflect.Type]string{}
+	def := r.reflect(t)
+	if len(r.defs) > 0 {
+		def.Defs = r.defs
+	}
+	return &def
+}
+
+// nolint:gochecknoglobals
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	schemerType       = reflect.TypeFor[Schemer]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+type reflector struct {
+	root       reflect.Type
+	collecting bool
+	recursive  map[reflect.Type]bool
+	stack      map[reflect.Type]bool
+	defs       map[string]Definition
+	names      map[reflect.Type]string
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (r *reflector) reflect(t reflect.Type) Definition {
+	if t.Kind() == reflect.Pointer {
+		return nullable(r.reflect(derefType(t)))
+	}
+	if t.Implements(schemerType) || reflect.PointerTo(t).Implements(schemerType) {
+		if s, ok := reflect.New(t).Interface().(Schemer); ok {
+			if def := s.JSONSchema(); def != nil {
+				return *def
+			}
+		}
+	}
+	switch {
+	case t == timeType:
+		return Definition{Type: String, Format: "date-time"}
+	case t == rawMessageType:
+		return Definition{}
+	case !t.Implements(jsonMarshalerType) && t.Implements(textMarshalerType):
+		return Definition{Type: String}
+	}
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return Definition{Type: Boolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Definition{Type: Integer}
+	case reflect.Float32, reflect.Float64:
+		return Definition{Type: Number}
+	case reflect.String:
+		return Definition{Type: String}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes []byte as a base64 string.
+			return Definition{Type: String}
+		}
+		items := r.reflect(t.Elem())
+		return Definition{Type: Array, Items: &items}
+	case reflect.Array:
+		items := r.reflect(t.Elem())
+		return Definition{Type: Array, Items: &items, MinItems: ptr(t.Len()), MaxItems: ptr(t.Len())}
+	case reflect.Map:
+		values := r.reflect(t.Elem())
+		return Definition{Type: Object, AdditionalProperties: &values}
+	case reflect.Struct:
+		return r.reflectStruct(t)
+	default:
+		return Definition{}
+	}
+}
+
+func (r *reflector) reflectStruct(t reflect.Type) Definition {
+	if r.collecting {
+		if r.stack[t] {
+			r.recursive[t] = true
+			return Definition{}
+		}
+		r.stack[t] = true
+		defer delete(r.stack, t)
+		return r.structDefinition(t)
+	}
+	if !r.recursive[t] {
+		return r.structDefinition(t)
+	}
+	if t == r.root {
+		if r.stack[t] {
+			return Definition{Ref: "#"}
+		}
+		r.stack[t] = true
+		defer delete(r.stack, t)
+		return r.structDefinition(t)
+	}
+	name, ok := r.names[t]
+	if !ok {
+		name = r.defName(t)
+		r.names[t] = name
+		r.defs[name] = Definition{} // reserves the name
+		r.defs[name] = r.structDefinition(t)
+	}
+	return Definition{Ref: "#/$defs/" + name}
+}
+
+// defName returns a unique name for t in $defs.
+func (r *reflector) defName(t reflect.Type) string {
+	base := t.Name()
+	if i := strings.Index(base, "["); i >= 0 {
+		base = base[:i]
+	}
+	if base == "" {
+		base = "def"
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := r.defs[name]; !taken {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+func (r *reflector) structDefinition(t reflect.Type) Definition {
+	def := Definition{
+		Type:                 Object,
+		Properties:           map[string]Definition{},
+		AdditionalProperties: false,
+	}
+	r.addFields(t, &def)
+	return def
+}
+
+// addFields adds the exported fields of t to def, flattening embedded
+// structs like encoding/json.
+func (r *reflector) addFields(t reflect.Type, def *Definition) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			if ft := derefType(field.Type); ft.Kind() == reflect.Struct {
+				r.addFields(ft, def)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := r.reflect(field.Type)
+		if hasOption(opts, "string") {
+			prop = Definition{Type: String, Nullable: prop.Nullable}
+		}
+		required := !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero")
+		if describe := field.Tag.Get("describe"); describe != "" {
+			prop.Description = describe
+		}
+		required = applyTag(&prop, field.Tag.Get("jsonschema"), required)
+		def.Properties[name] = prop
+		if required {
+			def.Required = append(def.Required, name)
+		}
+	}
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTag applies the keywords of a jsonschema tag to def, and returns
+// whether the field is required.
+func applyTag(def *Definition, tag string, required bool) bool { //nolint:cyclop,funlen
+	for _, keyword := range splitTag(tag) {
+		key, value, _ := strings.Cut(keyword, "=")
+		switch key {
+		case "description":
+			def.Description = value
+		case "enum":
+			def.Enum = append(def.Enum, value)
+		case "format":
+			def.Format = value
+		case "pattern":
+			def.Pattern = value
+		case "minimum":
+			def.Minimum = parseFloat(value)
+		case "maximum":
+			def.Maximum = parseFloat(value)
+		case "exclusiveMinimum":
+			def.ExclusiveMinimum = parseFloat(value)
+		case "exclusiveMaximum":
+			def.ExclusiveMaximum = parseFloat(value)
+		case "minLength":
+			def.MinLength = parseInt(value)
+		case "maxLength":
+			def.MaxLength = parseInt(value)
+		case "minItems":
+			def.MinItems = parseInt(value)
+		case "maxItems":
+			def.MaxItems = parseInt(value)
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case "nullable":
+			*def = nullable(*def)
+		case "oneof_type":
+			def.OneOf = typeDefinitions(value)
+			def.Type = ""
+		case "anyof_type":
+			def.AnyOf = typeDefinitions(value)
+			def.Type = ""
+		}
+	}
+	return required
+}
+
+// splitTag splits a jsonschema tag at the commas not escaped with a
+// backslash.
+func splitTag(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	var (
+		keywords []string
+		b        strings.Builder
+	)
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			b.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			keywords = append(keywords, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(tag[i])
+		}
+	}
+	return append(keywords, b.String())
+}
+
+func typeDefinitions(types string) []Definition {
+	var defs []Definition
+	for _, t := range strings.Split(types, ";") {
+		defs = append(defs, Definition{Type: DataType(t)})
+	}
+	return defs
+}
+
+// nullable returns def allowing null values.
+func nullable(def Definition) Definition {
+	switch {
+	case def.Type != "":
+		def.Nullable = true
+		return def
+	case def.Ref != "":
+		return Definition{AnyOf: []Definition{def, {Type: Null}}}
+	default:
+		// Schemas without a type already allow null.
+		return def
+	}
+}
+
+func parseFloat(s string) *float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic(fmt.Sprintf("jsonschema: invalid number %q in tag", s))
+	}
+	return &f
+}
+
+func parseInt(s string) *int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		panic(fmt.Sprintf("jsonschema: invalid integer %q in tag", s))
+	}
+	return &n
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,142 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+type weatherQuery struct {
+	Location string    `json:"location" jsonschema:"description=The city\\, and the country"`
+	Unit     string    `json:"unit,omitempty" jsonschema:"enum=celsius,enum=fahrenheit"`
+	Days     int       `json:"days" jsonschema:"minimum=1,maximum=7"`
+	Since    time.Time `json:"since"`
+	Contact  *string   `json:"contact" jsonschema:"format=email"`
+	Tags     []string  `json:"tags,omitempty" jsonschema:"maxItems=3"`
+	Extra    any       `json:"extra" jsonschema:"oneof_type=string;integer"`
+	internal string
+}
+
+type treeNode struct {
+	Value    string      `json:"value" describe:"the node value"`
+	Children []*treeNode `json:"children"`
+}
+
+type listItem struct {
+	Name string    `json:"name"`
+	Next *listItem `json:"next"`
+}
+
+type list struct {
+	Head *listItem `json:"head"`
+}
+
+func TestFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		def  *jsonschema.Definition
+		want string
+	}{
+		{
+			name: "struct",
+			def:  jsonschema.For[weatherQuery](),
+			want: `{
+				"type": "object",
+				"properties": {
+					"location": {"type": "string", "description": "The city, and the country", "properties": {}},
+					"unit": {"type": "string", "enum": ["celsius", "fahrenheit"], "properties": {}},
+					"days": {"type": "integer", "minimum": 1, "maximum": 7, "properties": {}},
+					"since": {"type": "string", "format": "date-time", "properties": {}},
+					"contact": {"type": ["string", "null"], "format": "email", "properties": {}},
+					"tags": {"type": "array", "items": {"type": "string", "properties": {}}, "maxItems": 3, "properties": {}},
+					"extra": {"oneOf": [{"type": "string", "properties": {}}, {"type": "integer", "properties": {}}]}
+				},
+				"required": ["location", "days", "since", "contact", "extra"],
+				"additionalProperties": false
+			}`,
+		},
+		{
+			name: "recursive root",
+			def:  jsonschema.For[treeNode](),
+			want: `{
+				"type": "object",
+				"properties": {
+					"value": {"type": "string", "description": "the node value", "properties": {}},
+					"children": {"type": "array", "items": {"anyOf": [{"$ref": "#"}, {"type": "null", "properties": {}}]}, "properties": {}}
+				},
+				"required": ["value", "children"],
+				"additionalProperties": false
+			}`,
+		},
+		{
+			name: "recursive field",
+			def:  jsonschema.For[list](),
+			want: `{
+				"type": "object",
+				"properties": {
+					"head": {"anyOf": [{"$ref": "#/$defs/listItem"}, {"type": "null", "properties": {}}]}
+				},
+				"required": ["head"],
+				"additionalProperties": false,
+				"$defs": {
+					"listItem": {
+						"type": "object",
+						"properties": {
+							"name": {"type": "string", "properties": {}},
+							"next": {"anyOf": [{"$ref": "#/$defs/listItem"}, {"type": "null", "properties": {}}]}
+						},
+						"required": ["name", "next"],
+						"additionalProperties": false
+					}
+				}
+			}`,
+		},
+		{
+			name: "map",
+			def:  jsonschema.Reflect(map[string]int{}),
+			want: `{"type": "object", "additionalProperties": {"type": "integer", "properties": {}}, "properties": {}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var want map[string]any
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("Failed to Unmarshal JSON: error = %v", err)
+			}
+			if got := structToMap(t, tt.def); !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("schema mismatch:\ngot:  %s\nwant: %s", gotJSON, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefinition_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	want := jsonschema.For[weatherQuery]()
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Failed to Marshal JSON: error = %v", err)
+	}
+	var got jsonschema.Definition
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to Unmarshal JSON: error = %v", err)
+	}
+	if !got.Properties["contact"].Nullable {
+		t.Error("contact is not nullable")
+	}
+	if got.AdditionalProperties != false {
+		t.Errorf("additionalProperties = %v, want false", got.AdditionalProperties)
+	}
+	if !reflect.DeepEqual(structToMap(t, &got), structToMap(t, want)) {
+		t.Error("the decoded schema does not encode like the original")
+	}
+}
@@ -0,0 +1,332 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError describes a value that does not match its schema.
+type ValidationError struct {
+	// Path is the location of the value in the data, such as "items[2].name".
+	// It is empty for the data itself.
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks data against schema. Data is either a JSON document, as a
+// []byte or json.RawMessage, or a value that is encoded to JSON first.
+//
+// The returned error joins a *ValidationError for each mismatch. Enums only
+// hold strings, so other values are compared with their string form. Unknown
+// formats are not checked.
+func Validate(schema *Definition, data any) error {
+	var raw []byte
+	switch data := data.(type) {
+	case []byte:
+		raw = data
+	case json.RawMessage:
+		raw = data
+	default:
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return fmt.Errorf("encode data: %w", err)
+		}
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("decode data: %w", err)
+	}
+	v := &validator{root: schema}
+	v.validate(schema, value, "")
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	root  *Definition
+	errs  []error
+	depth int
+}
+
+// _maxRefDepth limits the references followed, for schemas referencing
+// themselves without consuming any data.
+const _maxRefDepth = 100
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value matches def, without recording errors.
+func (v *validator) matches(def *Definition, value any, path string) bool {
+	sub := &validator{root: v.root, depth: v.depth}
+	sub.validate(def, value, path)
+	return len(sub.errs) == 0
+}
+
+func (v *validator) validate(def *Definition, value any, path string) { //nolint:cyclop
+	if def.Ref != "" {
+		v.validateRef(def.Ref, value, path)
+	}
+	if len(def.AnyOf) > 0 {
+		v.validateAnyOf(def.AnyOf, value, path)
+	}
+	if len(def.OneOf) > 0 {
+		n := 0
+		for i := range def.OneOf {
+			if v.matches(&def.OneOf[i], value, path) {
+				n++
+			}
+		}
+		if n != 1 {
+			v.fail(path, "must match exactly one of the allowed schemas, matches %d", n)
+		}
+	}
+	if value == nil && (def.Nullable || def.Type == "" || def.Type == Null) {
+		return
+	}
+	if def.Type != "" && !hasType(value, def.Type) {
+		v.fail(path, "must be %s, got %s", typeName(def.Type, def.Nullable), valueType(value))
+		return
+	}
+	if len(def.Enum) > 0 && !slices.Contains(def.Enum, fmt.Sprint(value)) {
+		v.fail(path, "must be one of %s", strings.Join(def.Enum, ", "))
+	}
+	switch value := value.(type) {
+	case string:
+		v.validateString(def, value, path)
+	case float64:
+		v.validateNumber(def, value, path)
+	case []any:
+		v.validateArray(def, value, path)
+	case map[string]any:
+		v.validateObject(def, value, path)
+	}
+}
+
+func (v *validator) validateAnyOf(defs []Definition, value any, path string) {
+	if slices.ContainsFunc(defs, func(d Definition) bool { return v.matches(&d, value, path) }) {
+		return
+	}
+	// For a schema or null, as used for nullable references, the errors of
+	// the schema are more helpful than a mismatch.
+	if value != nil && len(defs) == 2 && (defs[0].Type == Null || defs[1].Type == Null) {
+		other := &defs[0]
+		if other.Type == Null {
+			other = &defs[1]
+		}
+		v.validate(other, value, path)
+		return
+	}
+	v.fail(path, "does not match any of the allowed schemas")
+}
+
+func (v *validator) validateRef(ref string, value any, path string) {
+	target := v.root
+	if ref != "#" {
+		name, ok := strings.CutPrefix(ref, "#/$defs/")
+		def, found := v.root.Defs[name]
+		if !ok || !found {
+			v.fail(path, "unresolved reference %q", ref)
+			return
+		}
+		target = &def
+	}
+	if v.depth >= _maxRefDepth {
+		v.fail(path, "too many nested references")
+		return
+	}
+	v.depth++
+	v.validate(target, value, path)
+	v.depth--
+}
+
+func (v *validator) validateString(def *Definition, s string, path string) {
+	n := utf8.RuneCountInString(s)
+	if def.MinLength != nil && n < *def.MinLength {
+		v.fail(path, "must be at least %d characters long", *def.MinLength)
+	}
+	if def.MaxLength != nil && n > *def.MaxLength {
+		v.fail(path, "must be at most %d characters long", *def.MaxLength)
+	}
+	if def.Pattern != "" {
+		re, err := regexp.Compile(def.Pattern)
+		if err != nil {
+			v.fail(path, "invalid pattern %q in schema: %v", def.Pattern, err)
+		} else if !re.MatchString(s) {
+			v.fail(path, "must match pattern %q", def.Pattern)
+		}
+	}
+	if def.Format != "" && !hasFormat(s, def.Format) {
+		v.fail(path, "must be a valid %s", def.Format)
+	}
+}
+
+func (v *validator) validateNumber(def *Definition, f float64, path string) {
+	if def.Minimum != nil && f < *def.Minimum {
+		v.fail(path, "must be at least %v", *def.Minimum)
+	}
+	if def.Maximum != nil && f > *def.Maximum {
+		v.fail(path, "must be at most %v", *def.Maximum)
+	}
+	if def.ExclusiveMinimum != nil && f <= *def.ExclusiveMinimum {
+		v.fail(path, "must be greater than %v", *def.ExclusiveMinimum)
+	}
+	if def.ExclusiveMaximum != nil && f >= *def.ExclusiveMaximum {
+		v.fail(path, "must be less than %v", *def.ExclusiveMaximum)
+	}
+}
+
+func (v *validator) validateArray(def *Definition, items []any, path string) {
+	if def.MinItems != nil && len(items) < *def.MinItems {
+		v.fail(path, "must have at least %d items", *def.MinItems)
+	}
+	if def.MaxItems != nil && len(items) > *def.MaxItems {
+		v.fail(path, "must have at most %d items", *def.MaxItems)
+	}
+	if def.Items != nil {
+		for i, item := range items {
+			v.validate(def.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *validator) validateObject(def *Definition, obj map[string]any, path string) {
+	for _, name := range def.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(path, "missing required property %q", name)
+		}
+	}
+	// Properties are checked in a stable order, for stable errors.
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		propPath := name
+		if path != "" {
+			propPath = path + "." + name
+		}
+		if prop, ok := def.Properties[name]; ok {
+			v.validate(&prop, obj[name], propPath)
+			continue
+		}
+		switch additional := def.AdditionalProperties.(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unexpected property %q", name)
+			}
+		case *Definition:
+			v.validate(additional, obj[name], propPath)
+		case Definition:
+			v.validate(&additional, obj[name], propPath)
+		}
+	}
+}
+
+func hasType(value any, t DataType) bool {
+	switch t {
+	case Object:
+		_, ok := value.(map[string]any)
+		return ok
+	case Array:
+		_, ok := value.([]any)
+		return ok
+	case String:
+		_, ok := value.(string)
+		return ok
+	case Number:
+		_, ok := value.(float64)
+		return ok
+	case Integer:
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case Boolean:
+		_, ok := value.(bool)
+		return ok
+	case Null:
+		return value == nil
+	default:
+		return true
+	}
+}
+
+func typeName(t DataType, nullable bool) string {
+	name := "an " + string(t)
+	if t != Object && t != Integer && t != Array {
+		name = "a " + string(t)
+	}
+	if nullable {
+		name += " or null"
+	}
+	return name
+}
+
+func valueType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// nolint:gochecknoglobals
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func hasFormat(s, format string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", s)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	case "ipv6":
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	default:
+		return true
+	}
+}
@@ -0,0 +1,105 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		def     *jsonschema.Definition
+		data    string
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			def:  jsonschema.For[weatherQuery](),
+			data: `{"location": "Paris, France", "unit": "celsius", "days": 3, "since": "2025-01-02T15:04:05Z",
+				"contact": null, "extra": 2}`,
+		},
+		{
+			name: "invalid",
+			def:  jsonschema.For[weatherQuery](),
+			data: `{"location": 1, "unit": "kelvin", "days": 9, "since": "yesterday", "contact": "nobody",
+				"tags": ["a", "b", "c", "d"], "extra": 1.5, "other": true}`,
+			wantErr: []string{
+				"contact: must be a valid email",
+				"days: must be at most 7",
+				"extra: must match exactly one of the allowed schemas, matches 0",
+				"location: must be a string, got number",
+				`unexpected property "other"`,
+				"since: must be a valid date-time",
+				"tags: must have at most 3 items",
+				"unit: must be one of celsius, fahrenheit",
+			},
+		},
+		{
+			name:    "missing required",
+			def:     jsonschema.For[weatherQuery](),
+			data:    `{"location": "Paris", "since": "2025-01-02T15:04:05Z", "contact": null, "extra": "x"}`,
+			wantErr: []string{`missing required property "days"`},
+		},
+		{
+			name: "recursive",
+			def:  jsonschema.For[list](),
+			data: `{"head": {"name": "a", "next": {"name": "b", "next": null}}}`,
+		},
+		{
+			name:    "recursive invalid",
+			def:     jsonschema.For[treeNode](),
+			data:    `{"value": "a", "children": [{"value": "b", "children": [{"value": 3, "children": []}]}]}`,
+			wantErr: []string{"children[0].children[0].value: must be a string, got number"},
+		},
+		{
+			name: "pattern and length",
+			def: &jsonschema.Definition{
+				Type:      jsonschema.String,
+				Pattern:   "^[a-z]+$",
+				MinLength: new(int),
+			},
+			data:    `"ABC"`,
+			wantErr: []string{`must match pattern "^[a-z]+$"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := jsonschema.Validate(tt.def, []byte(tt.data))
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate() error = nil, want an error")
+			}
+			var verr *jsonschema.ValidationError
+			if !errors.As(err, &verr) {
+				t.Errorf("Validate() error is not a *ValidationError: %v", err)
+			}
+			if got := strings.Split(err.Error(), "\n"); strings.Join(got, "\n") != strings.Join(tt.wantErr, "\n") {
+				t.Errorf("Validate() errors:\n%s\nwant:\n%s", err, strings.Join(tt.wantErr, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	t.Parallel()
+
+	def := jsonschema.For[listItem]()
+	if err := jsonschema.Validate(def, listItem{Name: "a", Next: &listItem{Name: "b"}}); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := jsonschema.Validate(def, map[string]any{"name": "a"}); err == nil {
+		t.Error("Validate() error = nil, want a missing property error")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"os"
 	"strings"
 
@@ -39,6 +40,7 @@ type LLM struct {
 var (
 	_ llms.Model          = (*LLM)(nil)
 	_ llms.ReasoningModel = (*LLM)(nil)
+	_ llms.StreamingModel = (*LLM)(nil)
 )
 
 // New returns a new Anthropic LLM.
@@ -97,6 +99,11 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	return generateMessagesContent(ctx, o, messages, opts)
 }
 
+// StreamContent implements the StreamingModel interface.
+func (o *LLM) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return llms.StreamEvents(ctx, o, messages, options...)
+}
+
 func generateCompletionsContent(ctx context.Context, o *LLM, messages []llms.MessageContent, opts *llms.CallOptions) (*llms.ContentResponse, error) {
 	if len(messages) == 0 || len(messages[0].Parts) == 0 {
 		return nil, ErrEmptyResponse
@@ -116,7 +123,7 @@ func generateCompletionsContent(ctx context.Context, o *LLM, messages []llms.Mes
 		StopWords:     opts.StopWords,
 		Temperature:   opts.Temperature,
 		TopP:          opts.TopP,
-		StreamingFunc: opts.StreamingFunc,
+		StreamingFunc: opts.TextStreamHandler(),
 	})
 	if err != nil {
 		if o.CallbacksHandler != nil {
@@ -131,10 +138,33 @@ func generateCompletionsContent(ctx context.Context, o *LLM, messages []llms.Mes
 				Content: result.Text,
 			},
 		},
+		Model: result.Model,
 	}
 	return resp, nil
 }
 
+// convertToolChoice converts the tool choice of the options, "none", "auto",
+// "required" or a llms.ToolChoice naming a function, to an anthropic tool
+// choice.
+func convertToolChoice(choice any) *anthropicclient.ToolChoice {
+	switch c := choice.(type) {
+	case string:
+		switch c {
+		case "none", "auto", "any":
+			return &anthropicclient.ToolChoice{Type: c}
+		case "required":
+			return &anthropicclient.ToolChoice{Type: "any"}
+		}
+	case llms.ToolChoice:
+		return convertToolChoice(&c)
+	case *llms.ToolChoice:
+		if c != nil && c.Function != nil {
+			return &anthropicclient.ToolChoice{Type: "tool", Name: c.Function.Name}
+		}
+	}
+	return nil
+}
+
 func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.MessageContent, opts *llms.CallOptions) (*llms.ContentResponse, error) {
 	chatMessages, systemPrompt, err := processMessages(messages)
 	if err != nil {
@@ -145,19 +175,40 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 
 	betaHeaders, thinking := extractThinkingOptions(o, opts)
 
+	// Structured output is requested with a tool whose input schema is the
+	// response schema. The model can't be forced to call a tool while
+	// thinking, so then it is only offered.
+	toolChoice := convertToolChoice(opts.ToolChoice)
+	responseTool := ""
+	if opts.ResponseSchema != nil {
+		responseTool = opts.ResponseSchema.Name
+		if responseTool == "" {
+			responseTool = "response"
+		}
+		tools = append(tools, anthropicclient.Tool{
+			Name:        responseTool,
+			Description: opts.ResponseSchema.Description,
+			InputSchema: opts.ResponseSchema.Schema,
+		})
+		toolChoice = &anthropicclient.ToolChoice{Type: "tool", Name: responseTool}
+		if thinking != nil {
+			toolChoice = &anthropicclient.ToolChoice{Type: "auto"}
+		}
+	}
+
 	result, err := o.client.CreateMessage(ctx, &anthropicclient.MessageRequest{
-		Model:                  opts.Model,
-		Messages:               chatMessages,
-		System:                 systemPrompt,
-		MaxTokens:              opts.MaxTokens,
-		StopWords:              opts.StopWords,
-		Temperature:            opts.Temperature,
-		TopP:                   opts.TopP,
-		Tools:                  tools,
-		Thinking:               thinking,
-		BetaHeaders:            betaHeaders,
-		StreamingFunc:          opts.StreamingFunc,
-		StreamingReasoningFunc: opts.StreamingReasoningFunc,
+		Model:              opts.Model,
+		Messages:           chatMessages,
+		System:             systemPrompt,
+		MaxTokens:          opts.MaxTokens,
+		StopWords:          opts.StopWords,
+		Temperature:        opts.Temperature,
+		TopP:               opts.TopP,
+		Tools:              tools,
+		ToolChoice:         toolChoice,
+		Thinking:           thinking,
+		BetaHeaders:        betaHeaders,
+		StreamingEventFunc: opts.StreamEventHandler(),
 	})
 	if err != nil {
 		if o.CallbacksHandler != nil {
@@ -165,7 +216,24 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 		}
 		return nil, fmt.Errorf("anthropic: failed to create message: %w", err)
 	}
-	return processAnthropicResponse(result)
+	resp, err := processAnthropicResponse(result)
+	if err != nil || responseTool == "" {
+		return resp, err
+	}
+	return structuredResponse(resp, responseTool), nil
+}
+
+// structuredResponse returns resp with the call of the structured output tool
+// replaced by its arguments as the content.
+func structuredResponse(resp *llms.ContentResponse, tool string) *llms.ContentResponse {
+	for _, choice := range resp.Choices {
+		if len(choice.ToolCalls) == 1 && choice.ToolCalls[0].FunctionCall != nil &&
+			choice.ToolCalls[0].FunctionCall.Name == tool {
+			choice.Content = choice.ToolCalls[0].FunctionCall.Arguments
+			choice.ToolCalls = nil
+		}
+	}
+	return resp
 }
 
 // processAnthropicResponse converts Anthropic API response to standard ContentResponse
@@ -183,8 +251,10 @@ func processAnthropicResponse(result *anthropicclient.MessageResponsePayload) (*
 				thinkingContent, outputContent := extractThinkingFromText(textContent.Text)
 
 				choices[i] = &llms.ContentChoice{
-					Content:    textContent.Text,
-					StopReason: result.StopReason,
+					Content:      textContent.Text,
+					StopReason:   result.StopReason,
+					FinishReason: llms.NormalizeStopReason(result.StopReason),
+					Usage:        result.TokenUsage(),
 					GenerationInfo: map[string]any{
 						"InputTokens":              result.Usage.InputTokens,
 						"OutputTokens":             result.Usage.OutputTokens,
@@ -214,7 +284,9 @@ func processAnthropicResponse(result *anthropicclient.MessageResponsePayload) (*
 							},
 						},
 					},
-					StopReason: result.StopReason,
+					StopReason:   result.StopReason,
+					FinishReason: llms.NormalizeStopReason(result.StopReason),
+					Usage:        result.TokenUsage(),
 					GenerationInfo: map[string]any{
 						"InputTokens":              result.Usage.InputTokens,
 						"OutputTokens":             result.Usage.OutputTokens,
@@ -228,8 +300,10 @@ func processAnthropicResponse(result *anthropicclient.MessageResponsePayload) (*
 		case "thinking":
 			if thinkingContent, ok := content.(*anthropicclient.ThinkingContent); ok {
 				choices[i] = &llms.ContentChoice{
-					Content:    "", // Thinking content is not included in output
-					StopReason: result.StopReason,
+					Content:      "", // Thinking content is not included in output
+					StopReason:   result.StopReason,
+					FinishReason: llms.NormalizeStopReason(result.StopReason),
+					Usage:        result.TokenUsage(),
 					GenerationInfo: map[string]any{
 						"ThinkingContent":          thinkingContent.Thinking,
 						"ThinkingSignature":        thinkingContent.Signature,
@@ -249,6 +323,7 @@ func processAnthropicResponse(result *anthropicclient.MessageResponsePayload) (*
 
 	return &llms.ContentResponse{
 		Choices: choices,
+		Model:   result.Model,
 	}, nil
 }
 
@@ -384,34 +459,41 @@ func handleHumanMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, e
 }
 
 func handleAIMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, error) {
-	if toolCall, ok := msg.Parts[0].(llms.ToolCall); ok {
-		var inputStruct map[string]interface{}
-		err := json.Unmarshal([]byte(toolCall.FunctionCall.Arguments), &inputStruct)
-		if err != nil {
-			return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: failed to unmarshal tool call arguments: %w", err)
-		}
-		toolUse := anthropicclient.ToolUseContent{
-			Type:  "tool_use",
-			ID:    toolCall.ID,
-			Name:  toolCall.FunctionCall.Name,
-			Input: inputStruct,
+	content := make([]anthropicclient.Content, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch part := part.(type) {
+		case llms.ToolCall:
+			var inputStruct map[string]interface{}
+			err := json.Unmarshal([]byte(part.FunctionCall.Arguments), &inputStruct)
+			if err != nil {
+				return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: failed to unmarshal tool call arguments: %w", err)
+			}
+			content = append(content, anthropicclient.ToolUseContent{
+				Type:  "tool_use",
+				ID:    part.ID,
+				Name:  part.FunctionCall.Name,
+				Input: inputStruct,
+			})
+		case llms.TextContent:
+			// Empty text blocks are rejected next to tool calls.
+			if part.Text == "" && len(msg.Parts) > 1 {
+				continue
+			}
+			content = append(content, &anthropicclient.TextContent{
+				Type: "text",
+				Text: part.Text,
+			})
+		default:
+			return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for AI message", ErrInvalidContentType)
 		}
-
-		return anthropicclient.ChatMessage{
-			Role:    RoleAssistant,
-			Content: []anthropicclient.Content{toolUse},
-		}, nil
 	}
-	if textContent, ok := msg.Parts[0].(llms.TextContent); ok {
-		return anthropicclient.ChatMessage{
-			Role: RoleAssistant,
-			Content: []anthropicclient.Content{&anthropicclient.TextContent{
-				Type: "text",
-				Text: textContent.Text,
-			}},
-		}, nil
+	if len(content) == 0 {
+		return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for AI message", ErrInvalidContentType)
 	}
-	return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for AI message", ErrInvalidContentType)
+	return anthropicclient.ChatMessage{
+		Role:    RoleAssistant,
+		Content: content,
+	}, nil
 }
 
 type ToolResult struct {
@@ -421,19 +503,25 @@ type ToolResult struct {
 }
 
 func handleToolMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, error) {
-	if toolCallResponse, ok := msg.Parts[0].(llms.ToolCallResponse); ok {
-		toolContent := anthropicclient.ToolResultContent{
+	content := make([]anthropicclient.Content, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		toolCallResponse, ok := part.(llms.ToolCallResponse)
+		if !ok {
+			return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for tool message", ErrInvalidContentType)
+		}
+		content = append(content, anthropicclient.ToolResultContent{
 			Type:      "tool_result",
 			ToolUseID: toolCallResponse.ToolCallID,
 			Content:   toolCallResponse.Content,
-		}
-
-		return anthropicclient.ChatMessage{
-			Role:    RoleUser,
-			Content: []anthropicclient.Content{toolContent},
-		}, nil
+		})
 	}
-	return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for tool message", ErrInvalidContentType)
+	if len(content) == 0 {
+		return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for tool message", ErrInvalidContentType)
+	}
+	return anthropicclient.ChatMessage{
+		Role:    RoleUser,
+		Content: content,
+	}, nil
 }
 
 // SupportsReasoning implements the ReasoningModel interface.
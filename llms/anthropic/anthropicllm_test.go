@@ -2,9 +2,11 @@ package anthropic
 
 import (
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/anthropic/internal/anthropicclient"
 )
 
 func TestNew(t *testing.T) {
@@ -143,6 +145,29 @@ func TestProcessMessages(t *testing.T) {
 	}
 }
 
+func TestHandleAIMessageToolCalls(t *testing.T) {
+	msg := llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			llms.TextContent{Text: "Let me check."},
+			llms.ToolCall{ID: "1", FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+			llms.ToolCall{ID: "2", FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+		},
+	}
+
+	result, err := handleAIMessage(msg)
+	if err != nil {
+		t.Fatalf("handleAIMessage() error = %v", err)
+	}
+	content, ok := result.Content.([]anthropicclient.Content)
+	if !ok || len(content) != 3 {
+		t.Fatalf("handleAIMessage() content = %#v, want 3 blocks", result.Content)
+	}
+	if toolUse, ok := content[2].(anthropicclient.ToolUseContent); !ok || toolUse.ID != "2" {
+		t.Errorf("handleAIMessage() last block = %#v, want the second tool call", content[2])
+	}
+}
+
 func TestToolsToTools(t *testing.T) {
 	tools := []llms.Tool{
 		{
@@ -177,6 +202,42 @@ func TestToolsToTools(t *testing.T) {
 	}
 }
 
+func TestStructuredResponse(t *testing.T) {
+	resp := &llms.ContentResponse{Choices: []*llms.ContentChoice{
+		{ToolCalls: []llms.ToolCall{{ID: "1", FunctionCall: &llms.FunctionCall{Name: "get_weather", Arguments: `{}`}}}},
+		{ToolCalls: []llms.ToolCall{{ID: "2", FunctionCall: &llms.FunctionCall{Name: "person", Arguments: `{"name":"Ada"}`}}}},
+	}}
+
+	result := structuredResponse(resp, "person")
+
+	if len(result.Choices[0].ToolCalls) != 1 {
+		t.Errorf("structuredResponse() removed the call of another tool")
+	}
+	if got := result.Choices[1].Content; got != `{"name":"Ada"}` {
+		t.Errorf("structuredResponse() content = %q, want the tool arguments", got)
+	}
+	if len(result.Choices[1].ToolCalls) != 0 {
+		t.Errorf("structuredResponse() kept the structured output tool call")
+	}
+}
+
+func TestConvertToolChoice(t *testing.T) {
+	tests := []struct {
+		choice any
+		want   *anthropicclient.ToolChoice
+	}{
+		{nil, nil},
+		{"none", &anthropicclient.ToolChoice{Type: "none"}},
+		{"required", &anthropicclient.ToolChoice{Type: "any"}},
+		{llms.ToolChoice{Type: "function", Function: &llms.FunctionReference{Name: "weather"}}, &anthropicclient.ToolChoice{Type: "tool", Name: "weather"}},
+	}
+	for _, tt := range tests {
+		if got := convertToolChoice(tt.choice); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("convertToolChoice(%v) = %+v, want %+v", tt.choice, got, tt.want)
+		}
+	}
+}
+
 func TestOptions(t *testing.T) {
 	t.Run("WithModel", func(t *testing.T) {
 		opts := &options{}
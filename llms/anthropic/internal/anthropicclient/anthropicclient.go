@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -103,7 +104,8 @@ type CompletionRequest struct {
 
 // Completion is a completion.
 type Completion struct {
-	Text string `json:"text"`
+	Text  string `json:"text"`
+	Model string `json:"model"`
 }
 
 // CreateCompletion creates a completion.
@@ -122,7 +124,8 @@ func (c *Client) CreateCompletion(ctx context.Context, r *CompletionRequest) (*C
 		return nil, err
 	}
 	return &Completion{
-		Text: resp.Completion,
+		Text:  resp.Completion,
+		Model: resp.Model,
 	}, nil
 }
 
@@ -134,6 +137,7 @@ type MessageRequest struct {
 	MaxTokens   int           `json:"max_tokens,omitempty"`
 	TopP        float64       `json:"top_p,omitempty"`
 	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 	StopWords   []string      `json:"stop_sequences,omitempty"`
 	Stream      bool          `json:"stream,omitempty"`
 
@@ -141,26 +145,27 @@ type MessageRequest struct {
 	Thinking *ThinkingConfig `json:"thinking,omitempty"`
 
 	// BetaHeaders are additional beta feature headers to include
-	BetaHeaders            []string                                                      `json:"-"`
-	StreamingFunc          func(ctx context.Context, chunk []byte) error                `json:"-"`
-	StreamingReasoningFunc func(ctx context.Context, reasoningChunk, chunk []byte) error `json:"-"`
+	BetaHeaders []string `json:"-"`
+	// StreamingEventFunc is a function to be called for each typed event of a
+	// streaming response. The response is streamed when it is set.
+	StreamingEventFunc func(ctx context.Context, event llms.StreamEvent) error `json:"-"`
 }
 
 // CreateMessage creates message for the messages api.
 func (c *Client) CreateMessage(ctx context.Context, r *MessageRequest) (*MessageResponsePayload, error) {
 	resp, err := c.createMessage(ctx, &messagePayload{
-		Model:                  r.Model,
-		Messages:               r.Messages,
-		System:                 r.System,
-		Temperature:            r.Temperature,
-		MaxTokens:              r.MaxTokens,
-		StopWords:              r.StopWords,
-		TopP:                   r.TopP,
-		Tools:                  r.Tools,
-		Stream:                 r.Stream,
-		Thinking:               r.Thinking,
-		StreamingFunc:          r.StreamingFunc,
-		StreamingReasoningFunc: r.StreamingReasoningFunc,
+		Model:              r.Model,
+		Messages:           r.Messages,
+		System:             r.System,
+		Temperature:        r.Temperature,
+		MaxTokens:          r.MaxTokens,
+		StopWords:          r.StopWords,
+		TopP:               r.TopP,
+		Tools:              r.Tools,
+		ToolChoice:         r.ToolChoice,
+		Stream:             r.Stream,
+		Thinking:           r.Thinking,
+		StreamingEventFunc: r.StreamingEventFunc,
 	}, r.BetaHeaders)
 	if err != nil {
 		return nil, err
@@ -223,7 +228,7 @@ func (c *Client) decodeError(resp *http.Response) error {
 
 	var errResp errorMessage
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-		return errors.New(msg)
+		return httputil.NewResponseError(resp, errors.New(msg))
 	}
-	return fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+	return httputil.NewResponseError(resp, fmt.Errorf("%s: %s", msg, errResp.Error.Message))
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/internal/httprr"
+	"github.com/tmc/langchaingo/llms"
 )
 
 func TestClient_CreateCompletion(t *testing.T) {
@@ -97,8 +98,10 @@ func TestClient_CreateMessageStream(t *testing.T) {
 		},
 		MaxTokens: 100,
 		Stream:    true,
-		StreamingFunc: func(ctx context.Context, chunk []byte) error {
-			chunks = append(chunks, string(chunk))
+		StreamingEventFunc: func(ctx context.Context, event llms.StreamEvent) error {
+			if event.Type == llms.StreamEventTextDelta {
+				chunks = append(chunks, event.Delta)
+			}
 			return nil
 		},
 	}
@@ -11,6 +11,8 @@ import (
 	"log"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 var (
@@ -42,13 +44,24 @@ type messagePayload struct {
 	Stream      bool          `json:"stream,omitempty"`
 	Temperature float64       `json:"temperature"`
 	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 	TopP        float64       `json:"top_p,omitempty"`
 
 	// Extended thinking parameters (Claude 3.7+)
 	Thinking *ThinkingConfig `json:"thinking,omitempty"`
 
-	StreamingFunc          func(ctx context.Context, chunk []byte) error                      `json:"-"`
-	StreamingReasoningFunc func(ctx context.Context, reasoningChunk, chunk []byte) error `json:"-"`
+	StreamingEventFunc func(ctx context.Context, event llms.StreamEvent) error `json:"-"`
+}
+
+// emit reports a typed stream event if an event function is set.
+func (p *messagePayload) emit(ctx context.Context, event llms.StreamEvent) error {
+	if p.StreamingEventFunc == nil {
+		return nil
+	}
+	if err := p.StreamingEventFunc(ctx, event); err != nil {
+		return fmt.Errorf("streaming event func returned an error: %w", err)
+	}
+	return nil
 }
 
 // ThinkingConfig represents the thinking configuration for Claude 3.7+
@@ -64,6 +77,13 @@ type Tool struct {
 	InputSchema any    `json:"input_schema,omitempty"`
 }
 
+// ToolChoice controls how the model uses tools: "auto", "any", "none", or
+// "tool" to call the tool with Name.
+type ToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
 // CacheControl represents Anthropic's prompt caching configuration.
 type CacheControl struct {
 	Type string `json:"type"`
@@ -152,6 +172,20 @@ type MessageResponsePayload struct {
 	} `json:"usage"`
 }
 
+// TokenUsage returns the usage of the response in the provider-independent
+// form. Anthropic reports cached prompt tokens separately from input tokens,
+// so they are added to InputTokens.
+func (m *MessageResponsePayload) TokenUsage() *llms.Usage {
+	input := m.Usage.InputTokens + m.Usage.CacheCreationInputTokens + m.Usage.CacheReadInputTokens
+	return &llms.Usage{
+		InputTokens:      input,
+		OutputTokens:     m.Usage.OutputTokens,
+		TotalTokens:      input + m.Usage.OutputTokens,
+		CacheReadTokens:  m.Usage.CacheReadInputTokens,
+		CacheWriteTokens: m.Usage.CacheCreationInputTokens,
+	}
+}
+
 func (m *MessageResponsePayload) UnmarshalJSON(data []byte) error {
 	type Alias MessageResponsePayload
 	aux := &struct {
@@ -220,7 +254,7 @@ func (c *Client) setMessageDefaults(payload *messagePayload) {
 	default:
 		payload.Model = defaultModel
 	}
-	if payload.StreamingFunc != nil || payload.StreamingReasoningFunc != nil {
+	if payload.StreamingEventFunc != nil {
 		payload.Stream = true
 	}
 }
@@ -243,7 +277,7 @@ func (c *Client) createMessage(ctx context.Context, payload *messagePayload, bet
 		return nil, c.decodeError(resp)
 	}
 
-	if payload.StreamingFunc != nil {
+	if payload.Stream {
 		return parseStreamingMessageResponse(ctx, resp, payload)
 	}
 
@@ -314,13 +348,13 @@ func processStreamEvent(ctx context.Context, event map[string]interface{}, paylo
 	case "message_start":
 		return handleMessageStartEvent(event, response)
 	case "content_block_start":
-		return handleContentBlockStartEvent(event, response)
+		return handleContentBlockStartEvent(ctx, event, response, payload)
 	case "content_block_delta":
 		return handleContentBlockDeltaEvent(ctx, event, response, payload)
 	case "content_block_stop":
-		return handleContentBlockStop(event, response)
+		return handleContentBlockStop(ctx, event, response, payload)
 	case "message_delta":
-		return handleMessageDeltaEvent(event, response)
+		return handleMessageDeltaEvent(ctx, event, response, payload)
 	case "message_stop":
 		eventChan <- MessageEvent{Response: &response, Err: nil}
 	case "ping":
@@ -366,7 +400,7 @@ func handleMessageStartEvent(event map[string]interface{}, response MessageRespo
 	return response, nil
 }
 
-func handleContentBlockStartEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+func handleContentBlockStartEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
 	indexValue, ok := event["index"].(float64)
 	if !ok {
 		return response, ErrInvalidIndexField
@@ -397,12 +431,24 @@ func handleContentBlockStartEvent(event map[string]interface{}, response Message
 				input = make(map[string]interface{})
 			}
 
-			response.Content = append(response.Content, &ToolUseContent{
+			toolUse := &ToolUseContent{
 				Type:  eventType,
 				ID:    getString(contentBlock, "id"),
 				Name:  getString(contentBlock, "name"),
 				Input: input,
+			}
+			response.Content = append(response.Content, toolUse)
+			err := payload.emit(ctx, llms.StreamEvent{
+				Type:  llms.StreamEventToolCallStart,
+				Index: toolUseIndex(response, index),
+				ToolCall: &llms.ToolCall{
+					ID:           toolUse.ID,
+					FunctionCall: &llms.FunctionCall{Name: toolUse.Name},
+				},
 			})
+			if err != nil {
+				return response, err
+			}
 		case "thinking":
 			response.Content = append(response.Content, &ThinkingContent{
 				Type: eventType,
@@ -439,7 +485,7 @@ func handleContentBlockDeltaEvent(ctx context.Context, event map[string]interfac
 	case "text_delta":
 		return handleTextDelta(ctx, delta, response, payload, index)
 	case "input_json_delta":
-		return handleJSONDelta(delta, response, index)
+		return handleJSONDelta(ctx, delta, response, payload, index)
 	case "thinking_delta":
 		return handleThinkingDelta(ctx, delta, response, payload, index)
 	}
@@ -459,19 +505,11 @@ func handleTextDelta(ctx context.Context, delta map[string]interface{}, response
 	}
 	textContent.Text += text
 
-	// Streaming functions only work with text deltas.
-	if payload.StreamingFunc != nil {
-		err := payload.StreamingFunc(ctx, []byte(text))
-		if err != nil {
-			return response, fmt.Errorf("streaming func returned an error: %w", err)
-		}
-	}
-
-	return response, nil
+	return response, payload.emit(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: text})
 }
 
 // handleJSONDelta processes JSON delta events for content blocks.
-func handleJSONDelta(delta map[string]interface{}, response MessageResponsePayload, index int) (MessageResponsePayload, error) {
+func handleJSONDelta(ctx context.Context, delta map[string]interface{}, response MessageResponsePayload, payload *messagePayload, index int) (MessageResponsePayload, error) {
 	partialJSON, ok := delta["partial_json"].(string)
 	if !ok {
 		return response, ErrInvalidDeltaPartialJSONField
@@ -481,8 +519,15 @@ func handleJSONDelta(delta map[string]interface{}, response MessageResponsePaylo
 		return response, ErrFailedCastToToolUseContent
 	}
 	toolUseContent.inputData += partialJSON
+	if partialJSON == "" {
+		return response, nil
+	}
 
-	return response, nil
+	return response, payload.emit(ctx, llms.StreamEvent{
+		Type:  llms.StreamEventToolCallDelta,
+		Index: toolUseIndex(response, index),
+		Delta: partialJSON,
+	})
 }
 
 // handleThinkingDelta processes thinking delta events for content blocks.
@@ -497,20 +542,10 @@ func handleThinkingDelta(ctx context.Context, delta map[string]interface{}, resp
 	}
 	thinkingContent.Thinking += thinking
 
-	// Call StreamingReasoningFunc if provided (similar to OpenAI pattern)
-	if payload.StreamingReasoningFunc != nil {
-		reasoningChunk := []byte(thinking)
-		// For thinking deltas, the content chunk is empty since this is pure reasoning
-		err := payload.StreamingReasoningFunc(ctx, reasoningChunk, []byte{})
-		if err != nil {
-			return response, fmt.Errorf("streaming reasoning func returned an error: %w", err)
-		}
-	}
-
-	return response, nil
+	return response, payload.emit(ctx, llms.StreamEvent{Type: llms.StreamEventReasoningDelta, Delta: thinking})
 }
 
-func handleContentBlockStop(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+func handleContentBlockStop(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
 	indexValue, ok := event["index"].(float64)
 	if !ok {
 		return response, ErrInvalidIndexField
@@ -529,12 +564,39 @@ func handleContentBlockStop(event map[string]interface{}, response MessageRespon
 			}
 			toolUseContent.Input = input
 		}
+		arguments := toolUseContent.inputData
+		if arguments == "" {
+			arguments = "{}"
+		}
+		return response, payload.emit(ctx, llms.StreamEvent{
+			Type:  llms.StreamEventToolCallEnd,
+			Index: toolUseIndex(response, index),
+			ToolCall: &llms.ToolCall{
+				ID: toolUseContent.ID,
+				FunctionCall: &llms.FunctionCall{
+					Name:      toolUseContent.Name,
+					Arguments: arguments,
+				},
+			},
+		})
 	}
 
 	return response, nil
 }
 
-func handleMessageDeltaEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+// toolUseIndex returns the position of the tool use block at content index
+// among the tool use blocks of the response.
+func toolUseIndex(response MessageResponsePayload, index int) int {
+	n := 0
+	for _, c := range response.Content[:index] {
+		if _, ok := c.(*ToolUseContent); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func handleMessageDeltaEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
 	delta, ok := event["delta"].(map[string]interface{})
 	if !ok {
 		return response, ErrInvalidDeltaField
@@ -560,6 +622,14 @@ func handleMessageDeltaEvent(event map[string]interface{}, response MessageRespo
 	if cacheReadTokens, err := getFloat64(usage, "cache_read_input_tokens"); err == nil {
 		response.Usage.CacheReadInputTokens = int(cacheReadTokens)
 	}
+
+	err := payload.emit(ctx, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: response.TokenUsage()})
+	if err != nil {
+		return response, err
+	}
+	if response.StopReason != "" {
+		return response, payload.emit(ctx, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: response.StopReason})
+	}
 	return response, nil
 }
 
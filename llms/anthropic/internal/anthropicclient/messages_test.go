@@ -4,9 +4,11 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 )
 
 func Test_parseStreamingMessageResponse_withEmptyInput(t *testing.T) {
@@ -69,6 +71,55 @@ func Test_parseStreamingMessageResponse_withInputJSONDeltas(t *testing.T) {
 	}, secondContent.Input, "Tool use input should match expected value")
 }
 
+func Test_parseStreamingMessageResponse_streamingEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	response := createSSEResponse(SSEDataWithInputJSONDeltas)
+	defer response.Body.Close()
+
+	var events []llms.StreamEvent
+	payload := &messagePayload{
+		StreamingEventFunc: func(_ context.Context, event llms.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	_, err := parseStreamingMessageResponse(ctx, response, payload)
+	require.NoError(t, err)
+
+	var text, args string
+	var types []llms.StreamEventType
+	for _, event := range events {
+		switch event.Type {
+		case llms.StreamEventTextDelta:
+			text += event.Delta
+			continue
+		case llms.StreamEventToolCallDelta:
+			require.Equal(t, 0, event.Index)
+			args += event.Delta
+			continue
+		}
+		types = append(types, event.Type)
+	}
+	require.Equal(t, "I can help you get the current time. Let me check that for you.", text)
+	require.JSONEq(t, `{"format": "2006-01-02 15:04:05"}`, args)
+	require.Equal(t, []llms.StreamEventType{
+		llms.StreamEventToolCallStart,
+		llms.StreamEventToolCallEnd,
+		llms.StreamEventUsage,
+		llms.StreamEventStop,
+	}, types)
+
+	start := events[slices.IndexFunc(events, func(e llms.StreamEvent) bool { return e.Type == llms.StreamEventToolCallStart })]
+	require.Equal(t, "toolu_01HSrVQU8QDxAsVwuAdbja45", start.ToolCall.ID)
+	require.Equal(t, "get_current_time", start.ToolCall.FunctionCall.Name)
+
+	usage := events[len(events)-2].Usage
+	require.Equal(t, &llms.Usage{InputTokens: 463, OutputTokens: 83, TotalTokens: 546}, usage)
+	require.Equal(t, "tool_use", events[len(events)-1].StopReason)
+}
+
 // createAnthropicSSEResponse creates an HTTP response containing a simulated
 // Anthropic API server-sent events (SSE) stream.
 func createSSEResponse(data string) *http.Response {
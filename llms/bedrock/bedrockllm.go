@@ -3,6 +3,7 @@ package bedrock
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
@@ -65,6 +66,11 @@ func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
 }
 
+// StreamContent implements llms.StreamingModel.
+func (l *LLM) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return llms.StreamEvents(ctx, l, messages, options...)
+}
+
 // GenerateContent implements llms.Model.
 func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
 	if l.CallbacksHandler != nil {
@@ -90,6 +96,8 @@ func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		}
 		return nil, err
 	}
+	// Bedrock responses do not report the model, which is the requested one.
+	res.Model = opts.Model
 
 	if l.CallbacksHandler != nil {
 		l.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, res)
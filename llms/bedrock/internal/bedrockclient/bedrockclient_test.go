@@ -672,10 +672,7 @@ func TestAnthropicResponseParsing(t *testing.T) {
 		},
 		StopReason:   AnthropicCompletionReasonEndTurn,
 		StopSequence: "",
-		Usage: struct {
-			InputTokens  int `json:"input_tokens"`
-			OutputTokens int `json:"output_tokens"`
-		}{
+		Usage: anthropicUsage{
 			InputTokens:  10,
 			OutputTokens: 15,
 		},
@@ -698,6 +695,21 @@ func TestAnthropicResponseParsing(t *testing.T) {
 	require.Equal(t, 15, parsed.Usage.OutputTokens)
 }
 
+func TestAnthropicTokenUsage(t *testing.T) {
+	var output anthropicTextGenerationOutput
+	err := json.Unmarshal([]byte(`{"usage":{"input_tokens":10,"output_tokens":15,`+
+		`"cache_creation_input_tokens":100,"cache_read_input_tokens":1000}}`), &output)
+	require.NoError(t, err)
+
+	require.Equal(t, &llms.Usage{
+		InputTokens:      1110,
+		OutputTokens:     15,
+		TotalTokens:      1125,
+		CacheReadTokens:  1000,
+		CacheWriteTokens: 100,
+	}, output.Usage.tokenUsage(output.Usage.OutputTokens))
+}
+
 // Edge case tests
 func TestEmptyResponses(t *testing.T) {
 	t.Run("Amazon empty results", func(t *testing.T) {
@@ -738,26 +750,20 @@ func TestAnthropicStreamingResponseChunk(t *testing.T) {
 			chunk: streamingCompletionResponseChunk{
 				Type: "message_start",
 				Message: struct {
-					ID           string `json:"id"`
-					Type         string `json:"type"`
-					Role         string `json:"role"`
-					Content      []any  `json:"content"`
-					Model        string `json:"model"`
-					StopReason   any    `json:"stop_reason"`
-					StopSequence any    `json:"stop_sequence"`
-					Usage        struct {
-						InputTokens  int `json:"input_tokens"`
-						OutputTokens int `json:"output_tokens"`
-					} `json:"usage"`
+					ID           string         `json:"id"`
+					Type         string         `json:"type"`
+					Role         string         `json:"role"`
+					Content      []any          `json:"content"`
+					Model        string         `json:"model"`
+					StopReason   any            `json:"stop_reason"`
+					StopSequence any            `json:"stop_sequence"`
+					Usage        anthropicUsage `json:"usage"`
 				}{
 					ID:    "msg-123",
 					Type:  "message",
 					Role:  "assistant",
 					Model: "claude-3",
-					Usage: struct {
-						InputTokens  int `json:"input_tokens"`
-						OutputTokens int `json:"output_tokens"`
-					}{
+					Usage: anthropicUsage{
 						InputTokens: 25,
 					},
 				},
@@ -771,6 +777,7 @@ func TestAnthropicStreamingResponseChunk(t *testing.T) {
 				Delta: struct {
 					Type         string `json:"type"`
 					Text         string `json:"text"`
+					PartialJSON  string `json:"partial_json"`
 					StopReason   string `json:"stop_reason"`
 					StopSequence any    `json:"stop_sequence"`
 				}{
@@ -786,6 +793,7 @@ func TestAnthropicStreamingResponseChunk(t *testing.T) {
 				Delta: struct {
 					Type         string `json:"type"`
 					Text         string `json:"text"`
+					PartialJSON  string `json:"partial_json"`
 					StopReason   string `json:"stop_reason"`
 					StopSequence any    `json:"stop_sequence"`
 				}{
@@ -121,8 +121,10 @@ func createAi21Completion(ctx context.Context, client *bedrockruntime.Client, mo
 	choices := make([]*llms.ContentChoice, len(output.Completions))
 	for i, completion := range output.Completions {
 		choices[i] = &llms.ContentChoice{
-			Content:    completion.Data.Text,
-			StopReason: completion.FinishReason.Reason,
+			Content:      completion.Data.Text,
+			StopReason:   completion.FinishReason.Reason,
+			FinishReason: llms.NormalizeStopReason(completion.FinishReason.Reason),
+			Usage:        llms.NewUsage(len(output.Prompt.Tokens), len(completion.Data.Tokens)),
 			GenerationInfo: map[string]any{
 				"id":            output.ID,
 				"input_tokens":  len(output.Prompt.Tokens),
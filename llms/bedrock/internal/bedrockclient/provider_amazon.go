@@ -104,8 +104,10 @@ func createAmazonCompletion(ctx context.Context,
 
 	for i, result := range output.Results {
 		contentChoices[i] = &llms.ContentChoice{
-			Content:    result.OutputText,
-			StopReason: result.CompletionReason,
+			Content:      result.OutputText,
+			StopReason:   result.CompletionReason,
+			FinishReason: llms.NormalizeStopReason(result.CompletionReason),
+			Usage:        llms.NewUsage(output.InputTextTokenCount, result.TokenCount),
 			GenerationInfo: map[string]any{
 				"input_tokens":  output.InputTextTokenCount,
 				"output_tokens": result.TokenCount,
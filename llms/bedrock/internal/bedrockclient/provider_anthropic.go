@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
@@ -98,11 +99,29 @@ type anthropicTextGenerationOutput struct {
 	// One of: ["end_turn", "max_tokens", "stop_sequence", "tool_use"]
 	StopReason string `json:"stop_reason"`
 	// Which custom stop sequence was matched, if any.
-	StopSequence string `json:"stop_sequence"`
-	Usage        struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
-	} `json:"usage"`
+	StopSequence string         `json:"stop_sequence"`
+	Usage        anthropicUsage `json:"usage"`
+}
+
+// anthropicUsage represents the token usage of an Anthropic response.
+type anthropicUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// tokenUsage returns the usage with the given number of output tokens. As
+// with the Anthropic API, the cached tokens are counted as input tokens.
+func (u anthropicUsage) tokenUsage(outputTokens int) *llms.Usage {
+	input := u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+	return &llms.Usage{
+		InputTokens:      input,
+		OutputTokens:     outputTokens,
+		TotalTokens:      input + outputTokens,
+		CacheReadTokens:  u.CacheReadInputTokens,
+		CacheWriteTokens: u.CacheCreationInputTokens,
+	}
 }
 
 // anthropicContentBlock represents a content block in Anthropic response
@@ -187,7 +206,7 @@ func createAnthropicCompletion(ctx context.Context,
 		return nil, err
 	}
 
-	if options.StreamingFunc != nil {
+	if options.IsStreaming() {
 		modelInput := &bedrockruntime.InvokeModelWithResponseStreamInput{
 			ModelId:     aws.String(modelID),
 			Accept:      aws.String("*/*"),
@@ -222,7 +241,9 @@ func createAnthropicCompletion(ctx context.Context,
 
 	// Process content blocks and build a single ContentChoice
 	choice := &llms.ContentChoice{
-		StopReason: output.StopReason,
+		StopReason:   output.StopReason,
+		FinishReason: llms.NormalizeStopReason(output.StopReason),
+		Usage:        output.Usage.tokenUsage(output.Usage.OutputTokens),
 		GenerationInfo: map[string]interface{}{
 			"input_tokens":  output.Usage.InputTokens,
 			"output_tokens": output.Usage.OutputTokens,
@@ -269,9 +290,15 @@ type streamingCompletionResponseChunk struct {
 	Delta struct {
 		Type         string `json:"type"`
 		Text         string `json:"text"`
+		PartialJSON  string `json:"partial_json"`
 		StopReason   string `json:"stop_reason"`
 		StopSequence any    `json:"stop_sequence"`
 	} `json:"delta"`
+	ContentBlock struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"content_block"`
 	AmazonBedrockInvocationMetrics struct {
 		InputTokenCount   int `json:"inputTokenCount"`
 		OutputTokenCount  int `json:"outputTokenCount"`
@@ -282,17 +309,14 @@ type streamingCompletionResponseChunk struct {
 		OutputTokens int `json:"output_tokens"`
 	} `json:"usage"`
 	Message struct {
-		ID           string `json:"id"`
-		Type         string `json:"type"`
-		Role         string `json:"role"`
-		Content      []any  `json:"content"`
-		Model        string `json:"model"`
-		StopReason   any    `json:"stop_reason"`
-		StopSequence any    `json:"stop_sequence"`
-		Usage        struct {
-			InputTokens  int `json:"input_tokens"`
-			OutputTokens int `json:"output_tokens"`
-		} `json:"usage"`
+		ID           string         `json:"id"`
+		Type         string         `json:"type"`
+		Role         string         `json:"role"`
+		Content      []any          `json:"content"`
+		Model        string         `json:"model"`
+		StopReason   any            `json:"stop_reason"`
+		StopSequence any            `json:"stop_sequence"`
+		Usage        anthropicUsage `json:"usage"`
 	} `json:"message"`
 }
 
@@ -307,7 +331,15 @@ func parseStreamingCompletionResponse(ctx context.Context, client *bedrockruntim
 	}
 	defer stream.Close()
 
+	emit := func(event llms.StreamEvent) error {
+		return options.EmitStreamEvent(ctx, event)
+	}
+
 	contentchoices := []*llms.ContentChoice{{GenerationInfo: map[string]interface{}{}}}
+	// toolCalls maps content block indexes to the tool calls they carry.
+	toolCalls := map[int]*llms.ToolCall{}
+	var toolCallOrder []int
+	var startUsage anthropicUsage
 	for e := range stream.Events() {
 		if err = stream.Err(); err != nil {
 			return nil, err
@@ -322,15 +354,76 @@ func parseStreamingCompletionResponse(ctx context.Context, client *bedrockruntim
 
 			switch resp.Type {
 			case "message_start":
+				startUsage = resp.Message.Usage
 				contentchoices[0].GenerationInfo["input_tokens"] = resp.Message.Usage.InputTokens
+			case "content_block_start":
+				if resp.ContentBlock.Type != "tool_use" {
+					continue
+				}
+				toolCalls[resp.Index] = &llms.ToolCall{
+					ID:           resp.ContentBlock.ID,
+					Type:         "function",
+					FunctionCall: &llms.FunctionCall{Name: resp.ContentBlock.Name},
+				}
+				toolCallOrder = append(toolCallOrder, resp.Index)
+				err = emit(llms.StreamEvent{
+					Type:  llms.StreamEventToolCallStart,
+					Index: len(toolCallOrder) - 1,
+					ToolCall: &llms.ToolCall{
+						ID:           resp.ContentBlock.ID,
+						Type:         "function",
+						FunctionCall: &llms.FunctionCall{Name: resp.ContentBlock.Name},
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
 			case "content_block_delta":
-				if err = options.StreamingFunc(ctx, []byte(resp.Delta.Text)); err != nil {
+				if tc, ok := toolCalls[resp.Index]; ok {
+					tc.FunctionCall.Arguments += resp.Delta.PartialJSON
+					err = emit(llms.StreamEvent{
+						Type:  llms.StreamEventToolCallDelta,
+						Index: slices.Index(toolCallOrder, resp.Index),
+						Delta: resp.Delta.PartialJSON,
+					})
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+				if err = emit(llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: resp.Delta.Text}); err != nil {
 					return nil, err
 				}
 				contentchoices[0].Content += resp.Delta.Text
+			case "content_block_stop":
+				tc, ok := toolCalls[resp.Index]
+				if !ok {
+					continue
+				}
+				if tc.FunctionCall.Arguments == "" {
+					tc.FunctionCall.Arguments = "{}"
+				}
+				end := *tc
+				err = emit(llms.StreamEvent{
+					Type:     llms.StreamEventToolCallEnd,
+					Index:    slices.Index(toolCallOrder, resp.Index),
+					ToolCall: &end,
+				})
+				if err != nil {
+					return nil, err
+				}
 			case "message_delta":
 				contentchoices[0].StopReason = resp.Delta.StopReason
+				contentchoices[0].FinishReason = llms.NormalizeStopReason(resp.Delta.StopReason)
 				contentchoices[0].GenerationInfo["output_tokens"] = resp.Usage.OutputTokens
+				contentchoices[0].Usage = startUsage.tokenUsage(resp.Usage.OutputTokens)
+				usage := *contentchoices[0].Usage
+				if err = emit(llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &usage}); err != nil {
+					return nil, err
+				}
+				if err = emit(llms.StreamEvent{Type: llms.StreamEventStop, StopReason: resp.Delta.StopReason}); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -338,6 +431,13 @@ func parseStreamingCompletionResponse(ctx context.Context, client *bedrockruntim
 		return nil, err
 	}
 
+	for _, index := range toolCallOrder {
+		contentchoices[0].ToolCalls = append(contentchoices[0].ToolCalls, *toolCalls[index])
+	}
+	if len(contentchoices[0].ToolCalls) > 0 {
+		contentchoices[0].FuncCall = contentchoices[0].ToolCalls[0].FunctionCall
+	}
+
 	return &llms.ContentResponse{
 		Choices: contentchoices,
 	}, nil
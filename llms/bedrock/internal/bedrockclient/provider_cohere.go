@@ -106,8 +106,9 @@ func createCohereCompletion(ctx context.Context,
 
 	for i, gen := range output.Generations {
 		choices[i] = &llms.ContentChoice{
-			Content:    gen.Text,
-			StopReason: gen.FinishReason,
+			Content:      gen.Text,
+			StopReason:   gen.FinishReason,
+			FinishReason: llms.NormalizeStopReason(gen.FinishReason),
 			GenerationInfo: map[string]interface{}{
 				"generation_id": gen.ID,
 				"index":         i,
@@ -86,8 +86,10 @@ func createMetaCompletion(ctx context.Context,
 	return &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{
 			{
-				Content:    output.Generation,
-				StopReason: output.StopReason,
+				Content:      output.Generation,
+				StopReason:   output.StopReason,
+				FinishReason: llms.NormalizeStopReason(output.StopReason),
+				Usage:        llms.NewUsage(output.PromptTokenCount, output.GenerationTokenCount),
 				GenerationInfo: map[string]interface{}{
 					"input_tokens":  output.PromptTokenCount,
 					"output_tokens": output.GenerationTokenCount,
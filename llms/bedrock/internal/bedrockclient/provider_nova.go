@@ -157,7 +157,7 @@ func createNovaCompletion(ctx context.Context,
 		return nil, err
 	}
 
-	if options.StreamingFunc != nil {
+	if options.IsStreaming() {
 		return nil, errors.New("streaming not implemented for nova")
 	}
 
@@ -189,8 +189,10 @@ func createNovaCompletion(ctx context.Context,
 	Contentchoices := make([]*llms.ContentChoice, len(content))
 	for i, c := range content {
 		Contentchoices[i] = &llms.ContentChoice{
-			Content:    c.Text,
-			StopReason: output.StopReason,
+			Content:      c.Text,
+			StopReason:   output.StopReason,
+			FinishReason: llms.NormalizeStopReason(output.StopReason),
+			Usage:        llms.NewUsage(output.Usage.InputTokens, output.Usage.OutputTokens),
 			GenerationInfo: map[string]interface{}{
 				"input_tokens":  output.Usage.InputTokens,
 				"output_tokens": output.Usage.OutputTokens,
@@ -55,10 +55,7 @@ func TestAnthropicToolCallSupport(t *testing.T) {
 				},
 			},
 			StopReason: AnthropicCompletionReasonEndTurn,
-			Usage: struct {
-				InputTokens  int `json:"input_tokens"`
-				OutputTokens int `json:"output_tokens"`
-			}{
+			Usage: anthropicUsage{
 				InputTokens:  100,
 				OutputTokens: 50,
 			},
@@ -5,6 +5,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"iter"
 
 	"github.com/tmc/langchaingo/llms"
 )
@@ -17,21 +19,42 @@ type Backend interface {
 	Put(ctx context.Context, key string, response *llms.ContentResponse)
 }
 
-// Cacher is an LLM wrapper that caches the responses from the LLM.
+// Middleware returns an llms.Middleware that caches the responses of the
+// model it wraps in backend. Use it with llms.Wrap to combine caching with
+// other middleware.
+func Middleware(backend Backend) llms.Middleware {
+	return func(next llms.Model) llms.Model {
+		return llms.GenerateContentFunc(func(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+			return generateContent(ctx, next, backend, messages, options)
+		})
+	}
+}
+
+// ErrNoEmbeddings is returned by the embedding methods of a Cacher whose
+// wrapped model does not create embeddings.
+var ErrNoEmbeddings = errors.New("cache: the wrapped model does not create embeddings")
+
+// Cacher is an LLM wrapper that caches the responses from the LLM. It keeps
+// the reasoning and streaming capabilities of the wrapped model, and forwards
+// embedding requests to it, without caching them.
 type Cacher struct {
 	llm   llms.Model
-	cache Backend
+	model llms.Model
 }
 
 // assert that `Cacher` implements the `llms.Model` interface.
-var _ llms.Model = (*Cacher)(nil)
+var (
+	_ llms.Model          = (*Cacher)(nil)
+	_ llms.StreamingModel = (*Cacher)(nil)
+	_ llms.ReasoningModel = (*Cacher)(nil)
+)
 
 // New wraps a Model and adds caching capabilities using the provided
 // cache backend.
 func New(llm llms.Model, backend Backend) *Cacher {
 	return &Cacher{
 		llm:   llm,
-		cache: backend,
+		model: llms.Wrap(llm, Middleware(backend)),
 	}
 }
 
@@ -50,6 +73,61 @@ func (c *Cacher) Call(ctx context.Context, prompt string, options ...llms.CallOp
 // messages. It's the most general interface for multi-modal LLMs that support
 // chat-like interactions.
 func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	return c.model.GenerateContent(ctx, messages, options...)
+}
+
+// StreamContent streams the response of the model as typed events. Cached
+// responses are streamed at once.
+func (c *Cacher) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return llms.StreamContent(ctx, c.model, messages, options...)
+}
+
+// SupportsReasoning reports whether the wrapped model supports reasoning.
+func (c *Cacher) SupportsReasoning() bool {
+	return llms.SupportsReasoningModel(c.model)
+}
+
+// CreateEmbedding creates embeddings with the wrapped model, if it creates
+// embeddings.
+func (c *Cacher) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	e, ok := c.llm.(interface {
+		CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+	})
+	if !ok {
+		return nil, ErrNoEmbeddings
+	}
+	return e.CreateEmbedding(ctx, texts)
+}
+
+// EmbedDocuments embeds documents with the wrapped model, if it embeds
+// documents.
+func (c *Cacher) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	e, ok := c.llm.(interface {
+		EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error)
+	})
+	if !ok {
+		return nil, ErrNoEmbeddings
+	}
+	return e.EmbedDocuments(ctx, texts)
+}
+
+// EmbedQuery embeds a query with the wrapped model, if it embeds queries.
+func (c *Cacher) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	e, ok := c.llm.(interface {
+		EmbedQuery(ctx context.Context, text string) ([]float32, error)
+	})
+	if !ok {
+		return nil, ErrNoEmbeddings
+	}
+	return e.EmbedQuery(ctx, text)
+}
+
+// Unwrap returns the wrapped model.
+func (c *Cacher) Unwrap() llms.Model {
+	return c.llm
+}
+
+func generateContent(ctx context.Context, llm llms.Model, backend Backend, messages []llms.MessageContent, options []llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
 	var opts llms.CallOptions
 	for _, opt := range options {
 		opt(&opts)
@@ -60,10 +138,11 @@ func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageCon
 		return nil, err
 	}
 
-	if response := c.cache.Get(ctx, key); response != nil {
-		if opts.StreamingFunc != nil && len(response.Choices) > 0 {
+	if response := backend.Get(ctx, key); response != nil {
+		if len(response.Choices) > 0 {
 			// only stream the first choice.
-			if err := opts.StreamingFunc(ctx, []byte(response.Choices[0].Content)); err != nil {
+			event := llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: response.Choices[0].Content}
+			if err := opts.EmitStreamEvent(ctx, event); err != nil {
 				return nil, err
 			}
 		}
@@ -71,12 +150,12 @@ func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageCon
 		return response, nil
 	}
 
-	response, err := c.llm.GenerateContent(ctx, messages, options...)
+	response, err := llm.GenerateContent(ctx, messages, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	c.cache.Put(ctx, key, response)
+	backend.Put(ctx, key, response)
 
 	return response, nil
 }
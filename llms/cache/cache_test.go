@@ -170,3 +170,65 @@ func TestCache_Call_Streaming(t *testing.T) {
 	rq.True(mockCache.hit)
 	rq.True(stream)
 }
+
+type reasoningLLM struct {
+	*mockLLM
+}
+
+func (m *reasoningLLM) SupportsReasoning() bool {
+	return true
+}
+
+func (m *reasoningLLM) CreateEmbedding(_ context.Context, texts []string) ([][]float32, error) {
+	return make([][]float32, len(texts)), nil
+}
+
+func TestCache_Capabilities(t *testing.T) {
+	t.Parallel()
+	rq := require.New(t)
+
+	inner := &reasoningLLM{newMockLLM(&llms.ContentResponse{}, nil)}
+	llm := New(inner, newMockCache())
+	rq.True(llms.SupportsReasoningModel(llm))
+	rq.Same(inner, llm.Unwrap())
+	embeddings, err := llm.CreateEmbedding(context.Background(), []string{"a", "b"})
+	rq.NoError(err)
+	rq.Len(embeddings, 2)
+	_, err = llm.EmbedQuery(context.Background(), "a")
+	rq.ErrorIs(err, ErrNoEmbeddings)
+	_, err = New(newMockLLM(&llms.ContentResponse{}, nil), newMockCache()).CreateEmbedding(context.Background(), []string{"a"})
+	rq.ErrorIs(err, ErrNoEmbeddings)
+
+	wrapped := llms.Wrap(inner, Middleware(newMockCache()))
+	rq.True(llms.SupportsReasoningModel(wrapped))
+	rq.Implements((*interface {
+		CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+	})(nil), wrapped)
+}
+
+func TestCache_StreamContent(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+	rq := require.New(t)
+
+	exp := &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{
+			Content: "world",
+		}},
+	}
+	mockLLM := newMockLLM(exp, nil)
+	llm := New(mockLLM, newMockCache())
+	msgs := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hello")}
+
+	for range 2 {
+		var text string
+		for event, err := range llm.StreamContent(ctx, msgs) {
+			rq.NoError(err)
+			if event.Type == llms.StreamEventTextDelta {
+				text += event.Delta
+			}
+		}
+		rq.Equal("world", text)
+	}
+	rq.Equal(1, mockLLM.called)
+}
@@ -1,4 +1,8 @@
 // Package cache provides a generic wrapper that adds caching to a `llms.Model`. Responses are
 // cached under a key calculated based on the provided messages and options. Different cache
 // backends can be used when creating the wrapper.
+//
+// Caching is also available as an llms.Middleware, to combine it with other middleware:
+//
+//	llm = llms.Wrap(llm, logging, cache.Middleware(backend))
 package cache
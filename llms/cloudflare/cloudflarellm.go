@@ -113,12 +113,12 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		chatMsgs = append(chatMsgs, msg)
 	}
 
-	stream := func(b bool) *bool { return &b }(opts.StreamingFunc != nil)
+	stream := func(b bool) *bool { return &b }(opts.IsStreaming())
 
 	res, err := o.client.GenerateContent(ctx, &cloudflareclient.GenerateContentRequest{
 		Messages:      chatMsgs,
 		Stream:        *stream,
-		StreamingFunc: opts.StreamingFunc,
+		StreamingFunc: opts.TextStreamHandler(),
 	})
 	if err != nil {
 		return nil, err
@@ -134,7 +134,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		},
 	}
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Model: o.options.model}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
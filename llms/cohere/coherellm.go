@@ -54,6 +54,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	}
 
 	resp := &llms.ContentResponse{
+		Model: o.client.Model(),
 		Choices: []*llms.ContentChoice{
 			{
 				Content: result.Text,
@@ -65,6 +65,11 @@ func New(token string, baseURL string, model string, opts ...Option) (*Client, e
 	return c, nil
 }
 
+// Model returns the model generating the completions.
+func (c *Client) Model() string {
+	return c.model
+}
+
 type GenerationRequest struct {
 	Prompt string `json:"prompt"`
 }
@@ -0,0 +1,76 @@
+package cost
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Totals is the aggregated token usage and cost of a set of requests.
+type Totals struct {
+	// Requests is the number of requests.
+	Requests int `json:"requests"`
+	// Unpriced is the number of requests whose cost is unknown, because the
+	// model has no price or the provider reported no usage. Their tokens are
+	// included in Usage but not in Cost.
+	Unpriced int `json:"unpriced,omitempty"`
+	// Usage is the sum of the token usage of the requests.
+	Usage llms.Usage `json:"usage"`
+	// Cost is the cost of the requests in US dollars.
+	Cost float64 `json:"cost"`
+}
+
+// Add adds the requests of other to t.
+func (t *Totals) Add(other Totals) {
+	t.Requests += other.Requests
+	t.Unpriced += other.Unpriced
+	t.Usage.InputTokens += other.Usage.InputTokens
+	t.Usage.OutputTokens += other.Usage.OutputTokens
+	t.Usage.TotalTokens += other.Usage.TotalTokens
+	t.Usage.CacheReadTokens += other.Usage.CacheReadTokens
+	t.Usage.CacheWriteTokens += other.Usage.CacheWriteTokens
+	t.Usage.ReasoningTokens += other.Usage.ReasoningTokens
+	t.Cost += other.Cost
+}
+
+// Accumulator collects the totals of the requests made with a context. It is
+// safe for concurrent use.
+type Accumulator struct {
+	mu     sync.Mutex
+	totals Totals
+}
+
+// Add adds t to the accumulated totals.
+func (a *Accumulator) Add(t Totals) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.totals.Add(t)
+}
+
+// Totals returns the accumulated totals.
+func (a *Accumulator) Totals() Totals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.totals
+}
+
+type accumulatorKey struct{}
+
+// WithAccumulator returns a context carrying a new Accumulator. Handlers add
+// the cost of every request made with the context, or a context derived from
+// it, to the accumulator:
+//
+//	ctx, acc := cost.WithAccumulator(r.Context())
+//	answer, err := chains.Run(ctx, chain, question)
+//	slog.InfoContext(ctx, "answered", "cost", acc.Totals().Cost)
+func WithAccumulator(ctx context.Context) (context.Context, *Accumulator) {
+	acc := &Accumulator{}
+	return context.WithValue(ctx, accumulatorKey{}, acc), acc
+}
+
+// FromContext returns the Accumulator of ctx, or nil if it has none.
+func FromContext(ctx context.Context) *Accumulator {
+	acc, _ := ctx.Value(accumulatorKey{}).(*Accumulator)
+	return acc
+}
@@ -0,0 +1,21 @@
+// Package cost prices model responses from their token usage.
+//
+// A Table holds per-model prices for input, output, cached and reasoning
+// tokens. DefaultTable returns the list prices of common models, versioned by
+// date; set your own rates with Table.Set or build a table with NewTable.
+//
+// A Handler is a callbacks.Handler that prices every response a model
+// reports, aggregating the cost of the whole run, of each chain and of each
+// agent execution. Responses are priced as the model they report in
+// llms.ContentResponse.Model:
+//
+//	handler := cost.NewHandler()
+//	llm, err := openai.New(openai.WithModel("gpt-4o"), openai.WithCallback(handler))
+//
+// To attribute cost to a request, give its context an Accumulator. The
+// handler adds the cost of every response generated with that context:
+//
+//	ctx, acc := cost.WithAccumulator(r.Context())
+//	answer, err := chains.Run(ctx, chain, question)
+//	logger.InfoContext(ctx, "answered", "cost_usd", acc.Totals().Cost)
+package cost
@@ -0,0 +1,149 @@
+package cost
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Handler is a callbacks.Handler that prices every response reported to
+// HandleLLMGenerateContentEnd. It aggregates the cost of all responses it
+// sees, of each chain and of each agent execution, and adds it to the
+// Accumulator of the request context, if any.
+//
+// Set the same Handler on the models and on the chains or agents using them.
+// Chain and agent totals assume chains run one at a time on the Handler;
+// use a Handler per concurrent run, or the context Accumulator, otherwise.
+type Handler struct {
+	callbacks.SimpleHandler
+
+	opts options
+
+	mu     sync.Mutex
+	total  Totals
+	chains []Totals
+}
+
+var _ callbacks.Handler = (*Handler)(nil)
+
+// NewHandler returns a Handler pricing responses with the options.
+func NewHandler(opts ...Option) *Handler {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Handler{opts: o}
+}
+
+// Total returns the totals of all responses the handler has seen.
+func (h *Handler) Total() Totals {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.total
+}
+
+// HandleLLMGenerateContentEnd prices res and adds its cost to the totals.
+// Nil responses are ignored.
+func (h *Handler) HandleLLMGenerateContentEnd(ctx context.Context, res *llms.ContentResponse) {
+	if res == nil {
+		return
+	}
+	t := h.price(res)
+
+	h.mu.Lock()
+	h.total.Add(t)
+	for i := range h.chains {
+		h.chains[i].Add(t)
+	}
+	h.mu.Unlock()
+
+	if acc := FromContext(ctx); acc != nil {
+		acc.Add(t)
+	}
+}
+
+// HandleChainStart starts the totals of a chain.
+func (h *Handler) HandleChainStart(_ context.Context, _ map[string]any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.chains = append(h.chains, Totals{})
+}
+
+// HandleChainEnd reports the totals of the chain that ended.
+func (h *Handler) HandleChainEnd(ctx context.Context, _ map[string]any) {
+	h.endChain(ctx)
+}
+
+// HandleChainError reports the totals of the chain that failed.
+func (h *Handler) HandleChainError(ctx context.Context, _ error) {
+	h.endChain(ctx)
+}
+
+// HandleAgentFinish reports the totals of the agent execution, which is the
+// chain running the agent.
+func (h *Handler) HandleAgentFinish(ctx context.Context, _ schema.AgentFinish) {
+	h.mu.Lock()
+	t := h.total
+	if n := len(h.chains); n > 0 {
+		t = h.chains[n-1]
+	}
+	h.mu.Unlock()
+
+	if h.opts.onAgentFinish != nil {
+		h.opts.onAgentFinish(ctx, t)
+	}
+}
+
+func (h *Handler) endChain(ctx context.Context) {
+	h.mu.Lock()
+	n := len(h.chains)
+	if n == 0 {
+		h.mu.Unlock()
+		return
+	}
+	t := h.chains[n-1]
+	h.chains = h.chains[:n-1]
+	h.mu.Unlock()
+
+	if h.opts.onChainEnd != nil {
+		h.opts.onChainEnd(ctx, t)
+	}
+}
+
+// price returns the totals of a single response.
+func (h *Handler) price(res *llms.ContentResponse) Totals {
+	return h.opts.table.price(h.model(res), res)
+}
+
+// model returns the model that generated res, if the provider reported it,
+// or the configured model.
+func (h *Handler) model(res *llms.ContentResponse) string {
+	if res.Model != "" {
+		return res.Model
+	}
+	for _, c := range res.Choices {
+		for _, key := range []string{"model", "Model"} {
+			if model, ok := c.GenerationInfo[key].(string); ok && model != "" {
+				return model
+			}
+		}
+	}
+	return h.opts.model
+}
+
+// responseUsage returns the usage reported in res, if any. Providers report
+// the usage of the whole request on every choice.
+func responseUsage(res *llms.ContentResponse) *llms.Usage {
+	if res == nil {
+		return nil
+	}
+	for _, c := range res.Choices {
+		if c.Usage != nil {
+			return c.Usage
+		}
+	}
+	return nil
+}
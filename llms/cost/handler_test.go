@@ -0,0 +1,127 @@
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/openai"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func response(model string, input, output int) *llms.ContentResponse {
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{Usage: llms.NewUsage(input, output)}},
+		Model:   model,
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable("test", map[string]Price{
+		"small": {Input: 1, Output: 2},
+		"large": {Input: 10, Output: 20},
+	})
+	var chains, agents []Totals
+	h := NewHandler(
+		WithTable(table),
+		WithModel("small"),
+		WithOnChainEnd(func(_ context.Context, t Totals) { chains = append(chains, t) }),
+		WithOnAgentFinish(func(_ context.Context, t Totals) { agents = append(agents, t) }),
+	)
+
+	ctx, acc := WithAccumulator(context.Background())
+	h.HandleChainStart(ctx, nil)
+	h.HandleLLMGenerateContentEnd(ctx, response("", 1_000_000, 0))
+	h.HandleChainStart(ctx, nil)
+	h.HandleLLMGenerateContentEnd(ctx, response("large", 0, 1_000_000))
+	h.HandleAgentFinish(ctx, schema.AgentFinish{})
+	h.HandleChainEnd(ctx, nil)
+	h.HandleChainEnd(ctx, nil)
+	h.HandleLLMGenerateContentEnd(context.Background(), response("unknown", 10, 10))
+
+	require.Len(t, chains, 2)
+	require.InDelta(t, 20, chains[0].Cost, 1e-9, "inner chain")
+	require.InDelta(t, 21, chains[1].Cost, 1e-9, "outer chain includes the inner chain")
+	require.Equal(t, 2, chains[1].Requests)
+	require.Len(t, agents, 1)
+	require.InDelta(t, 20, agents[0].Cost, 1e-9)
+
+	total := h.Total()
+	require.Equal(t, 3, total.Requests)
+	require.Equal(t, 1, total.Unpriced)
+	require.Equal(t, 2_000_020, total.Usage.TotalTokens)
+	require.InDelta(t, 21, total.Cost, 1e-9)
+
+	// The response generated without the accumulator's context is not counted.
+	require.Equal(t, 2, acc.Totals().Requests)
+	require.InDelta(t, 21, acc.Totals().Cost, 1e-9)
+}
+
+func TestHandlerNoUsage(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(WithModel("gpt-4o"))
+	h.HandleLLMGenerateContentEnd(context.Background(), &llms.ContentResponse{Choices: []*llms.ContentChoice{{}}})
+	// Nil responses are not requests.
+	h.HandleLLMGenerateContentEnd(context.Background(), nil)
+	require.Equal(t, Totals{Requests: 1, Unpriced: 1}, h.Total())
+	require.Nil(t, FromContext(context.Background()))
+}
+
+func TestHandlerProviderModel(t *testing.T) {
+	t.Parallel()
+
+	// The server answers with a dated snapshot of a model other than the
+	// requested one, which must be the model priced.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usage := `"usage":{"prompt_tokens":1000000,"completion_tokens":1000000,"total_tokens":2000000}`
+		var req struct {
+			Stream bool `json:"stream"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, `data: {"id":"1","model":"gpt-4o-mini-2024-07-18","choices":[{"index":0,"delta":{"content":"Hi"},"finish_reason":"stop"}]}`+"\n\n")
+			fmt.Fprint(w, `data: {"id":"1","model":"gpt-4o-mini-2024-07-18","choices":[],`+usage+`}`+"\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","model":"gpt-4o-mini-2024-07-18","choices":[{"index":0,`+
+			`"message":{"role":"assistant","content":"Hi"},"finish_reason":"stop"}],`+usage+`}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	for name, opts := range map[string][]llms.CallOption{
+		"non-streaming": nil,
+		"streaming": {llms.WithStreamingFunc(func(context.Context, []byte) error {
+			return nil
+		})},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			h := NewHandler(WithModel("gpt-4o"))
+			llm, err := openai.New(openai.WithToken("test"), openai.WithBaseURL(srv.URL),
+				openai.WithModel("gpt-4o"), openai.WithCallback(h))
+			require.NoError(t, err)
+
+			_, err = llm.GenerateContent(context.Background(),
+				[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hello")}, opts...)
+			require.NoError(t, err)
+			total := h.Total()
+			require.Equal(t, 1, total.Requests)
+			require.Zero(t, total.Unpriced)
+			require.InDelta(t, 0.15+0.60, total.Cost, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package cost
+
+import "context"
+
+type options struct {
+	table         *Table
+	model         string
+	onChainEnd    func(ctx context.Context, totals Totals)
+	onAgentFinish func(ctx context.Context, totals Totals)
+}
+
+func defaultOptions() options {
+	return options{
+		table: DefaultTable(),
+	}
+}
+
+// Option is a function that configures a Handler.
+type Option func(*options)
+
+// WithTable sets the pricing table. The default is DefaultTable.
+func WithTable(table *Table) Option {
+	return func(o *options) {
+		o.table = table
+	}
+}
+
+// WithModel sets the model name used to price responses that do not report
+// the model that generated them.
+func WithModel(model string) Option {
+	return func(o *options) {
+		o.model = model
+	}
+}
+
+// WithOnChainEnd sets a function called with the totals of each chain when it
+// ends, including the chains it called.
+func WithOnChainEnd(fn func(ctx context.Context, totals Totals)) Option {
+	return func(o *options) {
+		o.onChainEnd = fn
+	}
+}
+
+// WithOnAgentFinish sets a function called with the totals of each agent
+// execution when the agent finishes.
+func WithOnAgentFinish(fn func(ctx context.Context, totals Totals)) Option {
+	return func(o *options) {
+		o.onAgentFinish = fn
+	}
+}
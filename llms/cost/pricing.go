@@ -0,0 +1,206 @@
+package cost
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// DefaultVersion is the version of the prices in DefaultTable.
+const DefaultVersion = "2025-08-01"
+
+// Price is the price of a model in US dollars per million tokens. Rates that
+// are zero fall back to the Input rate for cached tokens and to the Output
+// rate for reasoning tokens.
+type Price struct {
+	// Input is the rate for prompt tokens.
+	Input float64 `json:"input"`
+	// Output is the rate for generated tokens.
+	Output float64 `json:"output"`
+	// CacheRead is the rate for prompt tokens read from the prompt cache.
+	CacheRead float64 `json:"cache_read,omitempty"`
+	// CacheWrite is the rate for prompt tokens written to the prompt cache.
+	CacheWrite float64 `json:"cache_write,omitempty"`
+	// Reasoning is the rate for reasoning tokens.
+	Reasoning float64 `json:"reasoning,omitempty"`
+}
+
+// Cost returns the cost in US dollars of the tokens in usage.
+func (p Price) Cost(usage llms.Usage) float64 {
+	cacheRead := orDefault(p.CacheRead, p.Input)
+	cacheWrite := orDefault(p.CacheWrite, p.Input)
+	reasoning := orDefault(p.Reasoning, p.Output)
+
+	input := usage.InputTokens - usage.CacheReadTokens - usage.CacheWriteTokens
+	output := usage.OutputTokens - usage.ReasoningTokens
+	total := float64(max(input, 0))*p.Input +
+		float64(usage.CacheReadTokens)*cacheRead +
+		float64(usage.CacheWriteTokens)*cacheWrite +
+		float64(max(output, 0))*p.Output +
+		float64(usage.ReasoningTokens)*reasoning
+	return total / 1e6
+}
+
+func orDefault(rate, fallback float64) float64 {
+	if rate == 0 {
+		return fallback
+	}
+	return rate
+}
+
+// Table is a versioned set of model prices. It is safe for concurrent use.
+type Table struct {
+	version string
+
+	mu     sync.RWMutex
+	prices map[string]Price
+}
+
+// NewTable returns a table with the given version and prices. Prices are
+// keyed by model name or model name prefix.
+func NewTable(version string, prices map[string]Price) *Table {
+	return &Table{
+		version: version,
+		prices:  maps.Clone(prices),
+	}
+}
+
+// DefaultTable returns a new table with the public list prices of common
+// models as of DefaultVersion. Prices change; check them against your
+// provider's pricing page, or set your negotiated rates with Set.
+func DefaultTable() *Table {
+	return NewTable(DefaultVersion, defaultPrices)
+}
+
+// Version returns the version of the table.
+func (t *Table) Version() string {
+	return t.version
+}
+
+// Set sets the price of a model, or of all models starting with name.
+func (t *Table) Set(name string, price Price) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.prices == nil {
+		t.prices = make(map[string]Price)
+	}
+	t.prices[name] = price
+}
+
+// Price returns the price of model. Models without an exact entry use the
+// entry with the longest matching prefix, so "gpt-4o-2024-08-06" is priced as
+// "gpt-4o". Provider prefixes such as "models/" or "openai/" are ignored.
+func (t *Table) Price(model string) (Price, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	if price, ok := t.prices[model]; ok {
+		return price, true
+	}
+	var (
+		best  string
+		price Price
+		found bool
+	)
+	for name, p := range t.prices {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best, price, found = name, p, true
+		}
+	}
+	return price, found
+}
+
+// Cost returns the cost in US dollars of the tokens in usage for model, and
+// false if the table has no price for model.
+func (t *Table) Cost(model string, usage llms.Usage) (float64, bool) {
+	price, ok := t.Price(model)
+	if !ok {
+		return 0, false
+	}
+	return price.Cost(usage), true
+}
+
+// ResponseTotals returns the totals of a single response, priced as the model
+// it reports.
+func (t *Table) ResponseTotals(res *llms.ContentResponse) Totals {
+	var model string
+	if res != nil {
+		model = res.Model
+	}
+	return t.price(model, res)
+}
+
+// price returns the totals of a single response of model.
+func (t *Table) price(model string, res *llms.ContentResponse) Totals {
+	totals := Totals{Requests: 1}
+	usage := responseUsage(res)
+	if usage == nil {
+		totals.Unpriced = 1
+		return totals
+	}
+	totals.Usage = *usage
+	cost, ok := t.Cost(model, *usage)
+	if !ok {
+		totals.Unpriced = 1
+		return totals
+	}
+	totals.Cost = cost
+	return totals
+}
+
+// nolint:gochecknoglobals
+var defaultPrices = map[string]Price{
+	// OpenAI
+	"gpt-5":         {Input: 1.25, CacheRead: 0.125, Output: 10},
+	"gpt-5-mini":    {Input: 0.25, CacheRead: 0.025, Output: 2},
+	"gpt-5-nano":    {Input: 0.05, CacheRead: 0.005, Output: 0.40},
+	"gpt-4.1":       {Input: 2, CacheRead: 0.50, Output: 8},
+	"gpt-4.1-mini":  {Input: 0.40, CacheRead: 0.10, Output: 1.60},
+	"gpt-4.1-nano":  {Input: 0.10, CacheRead: 0.025, Output: 0.40},
+	"gpt-4o":        {Input: 2.50, CacheRead: 1.25, Output: 10},
+	"gpt-4o-mini":   {Input: 0.15, CacheRead: 0.075, Output: 0.60},
+	"gpt-4-turbo":   {Input: 10, Output: 30},
+	"gpt-4":         {Input: 30, Output: 60},
+	"gpt-3.5-turbo": {Input: 0.50, Output: 1.50},
+	"o1":            {Input: 15, CacheRead: 7.50, Output: 60},
+	"o1-mini":       {Input: 1.10, CacheRead: 0.55, Output: 4.40},
+	"o3":            {Input: 2, CacheRead: 0.50, Output: 8},
+	"o3-mini":       {Input: 1.10, CacheRead: 0.55, Output: 4.40},
+	"o4-mini":       {Input: 1.10, CacheRead: 0.275, Output: 4.40},
+
+	"text-embedding-3-small": {Input: 0.02},
+	"text-embedding-3-large": {Input: 0.13},
+	"text-embedding-ada-002": {Input: 0.10},
+
+	// Anthropic
+	"claude-opus-4":     {Input: 15, CacheRead: 1.50, CacheWrite: 18.75, Output: 75},
+	"claude-sonnet-4":   {Input: 3, CacheRead: 0.30, CacheWrite: 3.75, Output: 15},
+	"claude-3-7-sonnet": {Input: 3, CacheRead: 0.30, CacheWrite: 3.75, Output: 15},
+	"claude-3-5-sonnet": {Input: 3, CacheRead: 0.30, CacheWrite: 3.75, Output: 15},
+	"claude-3-5-haiku":  {Input: 0.80, CacheRead: 0.08, CacheWrite: 1, Output: 4},
+	"claude-3-opus":     {Input: 15, CacheRead: 1.50, CacheWrite: 18.75, Output: 75},
+	"claude-3-haiku":    {Input: 0.25, CacheRead: 0.03, CacheWrite: 0.30, Output: 1.25},
+
+	// Google
+	"gemini-2.5-pro":        {Input: 1.25, CacheRead: 0.31, Output: 10},
+	"gemini-2.5-flash":      {Input: 0.30, CacheRead: 0.075, Output: 2.50},
+	"gemini-2.5-flash-lite": {Input: 0.10, CacheRead: 0.025, Output: 0.40},
+	"gemini-2.0-flash":      {Input: 0.10, CacheRead: 0.025, Output: 0.40},
+	"gemini-1.5-pro":        {Input: 1.25, Output: 5},
+	"gemini-1.5-flash":      {Input: 0.075, Output: 0.30},
+
+	// Mistral
+	"mistral-large":  {Input: 2, Output: 6},
+	"mistral-medium": {Input: 0.40, Output: 2},
+	"mistral-small":  {Input: 0.10, Output: 0.30},
+	"codestral":      {Input: 0.30, Output: 0.90},
+
+	// DeepSeek
+	"deepseek-chat":     {Input: 0.27, CacheRead: 0.07, Output: 1.10},
+	"deepseek-reasoner": {Input: 0.55, CacheRead: 0.14, Output: 2.19},
+}
@@ -0,0 +1,61 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestPriceCost(t *testing.T) {
+	t.Parallel()
+
+	price := Price{Input: 3, CacheRead: 0.30, CacheWrite: 3.75, Output: 15}
+	usage := llms.Usage{
+		InputTokens:      1_000_000,
+		CacheReadTokens:  200_000,
+		CacheWriteTokens: 100_000,
+		OutputTokens:     100_000,
+		ReasoningTokens:  40_000,
+	}
+	// 700k input, 200k cache reads, 100k cache writes and 100k output tokens,
+	// reasoning billed as output.
+	require.InDelta(t, 2.1+0.06+0.375+1.5, price.Cost(usage), 1e-9)
+
+	// Cached tokens fall back to the input rate.
+	require.InDelta(t, 3, Price{Input: 3}.Cost(llms.Usage{InputTokens: 1_000_000, CacheReadTokens: 500_000}), 1e-9)
+}
+
+func TestTablePrice(t *testing.T) {
+	t.Parallel()
+
+	table := DefaultTable()
+	require.Equal(t, DefaultVersion, table.Version())
+
+	cases := []struct {
+		model string
+		want  string
+	}{
+		{"gpt-4o", "gpt-4o"},
+		{"gpt-4o-2024-08-06", "gpt-4o"},
+		{"gpt-4o-mini-2024-07-18", "gpt-4o-mini"},
+		{"claude-sonnet-4-20250514", "claude-sonnet-4"},
+		{"models/gemini-2.5-flash", "gemini-2.5-flash"},
+	}
+	for _, tc := range cases {
+		got, ok := table.Price(tc.model)
+		require.True(t, ok, tc.model)
+		require.Equal(t, defaultPrices[tc.want], got, tc.model)
+	}
+
+	_, ok := table.Price("unknown-model")
+	require.False(t, ok)
+
+	table.Set("unknown-model", Price{Input: 1, Output: 2})
+	cost, ok := table.Cost("unknown-model-v2", llms.Usage{InputTokens: 1_000_000, OutputTokens: 1_000_000})
+	require.True(t, ok)
+	require.InDelta(t, 3, cost, 1e-9)
+
+	_, ok = DefaultTable().Price("unknown-model")
+	require.False(t, ok, "Set does not change the default prices")
+}
@@ -2,6 +2,7 @@ package llms
 
 import (
 	"log"
+	"strings"
 
 	"github.com/pkoukk/tiktoken-go"
 )
@@ -10,69 +11,23 @@ const (
 	_tokenApproximation = 4
 )
 
-const (
-	_gpt35TurboContextSize    = 16385  // gpt-3.5-turbo default context
-	_gpt35Turbo16KContextSize = 16385  // gpt-3.5-turbo-16k
-	_gpt4ContextSize          = 8192   // gpt-4
-	_gpt432KContextSize       = 32768  // gpt-4-32k
-	_gpt4TurboContextSize     = 128000 // gpt-4-turbo models
-	_gpt4oContextSize         = 128000 // gpt-4o models
-	_gpt4oMiniContextSize     = 128000 // gpt-4o-mini
-	_textDavinci3ContextSize  = 4097
-	_textBabbage1ContextSize  = 2048
-	_textAda1ContextSize      = 2048
-	_textCurie1ContextSize    = 2048
-	_codeDavinci2ContextSize  = 8000
-	_codeCushman1ContextSize  = 2048
-	_defaultContextSize       = 2048
-)
-
-// nolint:gochecknoglobals
-var modelToContextSize = map[string]int{
-	// GPT-3.5 models
-	"gpt-3.5-turbo":      _gpt35TurboContextSize,
-	"gpt-3.5-turbo-16k":  _gpt35Turbo16KContextSize,
-	"gpt-3.5-turbo-0125": _gpt35TurboContextSize,
-	"gpt-3.5-turbo-1106": _gpt35TurboContextSize,
-	// GPT-4 models
-	"gpt-4":          _gpt4ContextSize,
-	"gpt-4-32k":      _gpt432KContextSize,
-	"gpt-4-0613":     _gpt4ContextSize,
-	"gpt-4-32k-0613": _gpt432KContextSize,
-	// GPT-4 Turbo models
-	"gpt-4-turbo":            _gpt4TurboContextSize,
-	"gpt-4-turbo-preview":    _gpt4TurboContextSize,
-	"gpt-4-turbo-2024-04-09": _gpt4TurboContextSize,
-	"gpt-4-1106-preview":     _gpt4TurboContextSize,
-	"gpt-4-0125-preview":     _gpt4TurboContextSize,
-	// GPT-4o models
-	"gpt-4o":                 _gpt4oContextSize,
-	"gpt-4o-2024-05-13":      _gpt4oContextSize,
-	"gpt-4o-2024-08-06":      _gpt4oContextSize,
-	"gpt-4o-mini":            _gpt4oMiniContextSize,
-	"gpt-4o-mini-2024-07-18": _gpt4oMiniContextSize,
-	// Legacy models
-	"text-davinci-003": _textDavinci3ContextSize,
-	"text-curie-001":   _textCurie1ContextSize,
-	"text-babbage-001": _textBabbage1ContextSize,
-	"text-ada-001":     _textAda1ContextSize,
-	"code-davinci-002": _codeDavinci2ContextSize,
-	"code-cushman-001": _codeCushman1ContextSize,
-}
+const _defaultContextSize = 2048
 
-// GetModelContextSize gets the max number of tokens for a language model. If the model
-// name isn't recognized the default value 2048 is returned.
+// GetModelContextSize gets the max number of tokens for a language model from
+// the default model registry. If the model isn't registered the default value
+// 2048 is returned.
 func GetModelContextSize(model string) int {
-	contextSize, ok := modelToContextSize[model]
-	if !ok {
+	info, ok := LookupModel(model)
+	if !ok || info.ContextSize == 0 {
 		return _defaultContextSize
 	}
-	return contextSize
+	return info.ContextSize
 }
 
-// CountTokens gets the number of tokens the text contains.
+// CountTokens gets the number of tokens the text contains, using the
+// tokenizer of the model from the default model registry.
 func CountTokens(model, text string) int {
-	e, err := tiktoken.EncodingForModel(model)
+	e, err := encodingForModel(model)
 	if err != nil {
 		e, err = tiktoken.GetEncoding("gpt2")
 		if err != nil {
@@ -83,7 +38,38 @@ func CountTokens(model, text string) int {
 	return len(e.Encode(text, nil, nil))
 }
 
+func encodingForModel(model string) (*tiktoken.Tiktoken, error) {
+	if info, ok := LookupModel(model); ok && info.Tokenizer != "" {
+		return tiktoken.GetEncoding(info.Tokenizer)
+	}
+	return tiktoken.EncodingForModel(model)
+}
+
 // CalculateMaxTokens calculates the max number of tokens that could be added to a text.
 func CalculateMaxTokens(model, text string) int {
 	return GetModelContextSize(model) - CountTokens(model, text)
 }
+
+// MessagesText returns the text of messages, including tool calls and tool
+// responses, one part per line. It is meant for estimating the token count
+// of a request with CountTokens.
+func MessagesText(messages []MessageContent) string {
+	var sb strings.Builder
+	for _, m := range messages {
+		for _, part := range m.Parts {
+			switch p := part.(type) {
+			case TextContent:
+				sb.WriteString(p.Text)
+			case ToolCall:
+				if p.FunctionCall != nil {
+					sb.WriteString(p.FunctionCall.Name)
+					sb.WriteString(p.FunctionCall.Arguments)
+				}
+			case ToolCallResponse:
+				sb.WriteString(p.Content)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
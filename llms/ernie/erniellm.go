@@ -90,8 +90,8 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		Temperature:   opts.Temperature,
 		TopP:          opts.TopP,
 		PenaltyScore:  opts.RepetitionPenalty,
-		StreamingFunc: opts.StreamingFunc,
-		Stream:        opts.StreamingFunc != nil,
+		StreamingFunc: opts.TextStreamHandler(),
+		Stream:        opts.IsStreaming(),
 	})
 	if err != nil {
 		if o.CallbacksHandler != nil {
@@ -109,9 +109,11 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	}
 
 	resp := &llms.ContentResponse{
+		Model: string(o.getModel(*opts)),
 		Choices: []*llms.ContentChoice{
 			{
 				Content: result.Result,
+				Usage:   llms.NewUsage(result.Usage.PromptTokens, result.Usage.CompletionTokens),
 			},
 		},
 	}
@@ -146,13 +148,14 @@ func (o *LLM) CreateEmbedding(ctx context.Context, texts []string) ([][]float32,
 }
 
 func (o *LLM) getModelPath(opts llms.CallOptions) ernieclient.ModelPath {
-	model := o.model
+	return modelToPath(o.getModel(opts))
+}
 
-	if model == "" {
-		model = ModelName(opts.Model)
+func (o *LLM) getModel(opts llms.CallOptions) ModelName {
+	if o.model == "" {
+		return ModelName(opts.Model)
 	}
-
-	return modelToPath(model)
+	return o.model
 }
 
 func modelToPath(model ModelName) ernieclient.ModelPath {
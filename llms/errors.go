@@ -125,6 +125,20 @@ func (e *Error) WithDetail(key string, value interface{}) *Error {
 	return e
 }
 
+// ErrorCodeOf returns the error code of err. Errors that are not an *Error
+// are classified by the patterns of a generic ErrorMapper. It returns the
+// empty string for a nil error.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		_ = errors.As(NewErrorMapper("").WrapError(err), &e)
+	}
+	return e.Code
+}
+
 // IsAuthenticationError returns true if the error is an authentication error.
 func IsAuthenticationError(err error) bool {
 	var e *Error
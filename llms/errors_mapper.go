@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"net"
+	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/httputil"
 )
 
 // ErrorMapper helps map provider-specific errors to standardized errors.
@@ -38,6 +41,9 @@ func defaultMatchers() []ErrorMatcher {
 	// Add context error matchers
 	matchers = append(matchers, contextErrorMatchers()...)
 
+	// Add HTTP status code matchers
+	matchers = append(matchers, statusCodeMatchers()...)
+
 	// Add string pattern matchers
 	matchers = append(matchers, stringPatternMatchers()...)
 
@@ -69,6 +75,35 @@ func contextErrorMatchers() []ErrorMatcher {
 	}
 }
 
+// statusCodeMatchers returns matchers for unsuccessful HTTP responses
+// reported as an *httputil.ResponseError.
+func statusCodeMatchers() []ErrorMatcher {
+	status := func(codes ...int) func(error) bool {
+		return func(err error) bool {
+			var respErr *httputil.ResponseError
+			if !errors.As(err, &respErr) {
+				return false
+			}
+			for _, code := range codes {
+				if respErr.StatusCode == code {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return []ErrorMatcher{
+		{Match: status(http.StatusUnauthorized, http.StatusForbidden), Code: ErrCodeAuthentication},
+		{Match: status(http.StatusTooManyRequests), Code: ErrCodeRateLimit},
+		{Match: status(http.StatusRequestTimeout, http.StatusGatewayTimeout), Code: ErrCodeTimeout},
+		{Match: status(http.StatusNotFound), Code: ErrCodeResourceNotFound},
+		{
+			Match: status(http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, 529),
+			Code:  ErrCodeProviderUnavailable,
+		},
+	}
+}
+
 // stringPatternMatchers returns matchers based on error string patterns.
 func stringPatternMatchers() []ErrorMatcher {
 	// Define pattern groups for easier maintenance
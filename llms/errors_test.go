@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"testing"
 
+	"github.com/tmc/langchaingo/httputil"
 	"github.com/tmc/langchaingo/llms"
 )
 
@@ -191,6 +193,12 @@ func TestErrorMapper(t *testing.T) {
 			err:          errors.New("Content blocked by safety filters"),
 			expectedCode: llms.ErrCodeContentFilter,
 		},
+		{
+			name: "http status",
+			err: httputil.NewResponseError(&http.Response{StatusCode: http.StatusServiceUnavailable},
+				errors.New("API returned unexpected status code: 503")),
+			expectedCode: llms.ErrCodeProviderUnavailable,
+		},
 		{
 			name:         "unknown error",
 			err:          errors.New("Something went wrong"),
@@ -298,3 +306,16 @@ type timeoutError struct{}
 func (e *timeoutError) Error() string   { return "timeout" }
 func (e *timeoutError) Timeout() bool   { return true }
 func (e *timeoutError) Temporary() bool { return true }
+
+func TestErrorCodeOf(t *testing.T) {
+	if got := llms.ErrorCodeOf(nil); got != "" {
+		t.Errorf("ErrorCodeOf(nil) = %v, want empty", got)
+	}
+	wrapped := fmt.Errorf("call failed: %w", llms.NewError(llms.ErrCodeQuotaExceeded, "openai", "quota"))
+	if got := llms.ErrorCodeOf(wrapped); got != llms.ErrCodeQuotaExceeded {
+		t.Errorf("ErrorCodeOf(wrapped) = %v, want %v", got, llms.ErrCodeQuotaExceeded)
+	}
+	if got := llms.ErrorCodeOf(errors.New("Error 429: Too Many Requests")); got != llms.ErrCodeRateLimit {
+		t.Errorf("ErrorCodeOf(plain) = %v, want %v", got, llms.ErrCodeRateLimit)
+	}
+}
@@ -122,6 +122,10 @@ func (ToolCallResponse) isPart() {}
 // It can potentially return multiple content choices.
 type ContentResponse struct {
 	Choices []*ContentChoice
+
+	// Model is the model that generated the response, as reported by the
+	// provider or, for providers that do not report it, as requested.
+	Model string
 }
 
 // ContentChoice is one of the response choices returned by GenerateContent
@@ -130,10 +134,19 @@ type ContentChoice struct {
 	// Content is the textual content of a response
 	Content string
 
-	// StopReason is the reason the model stopped generating output.
+	// StopReason is the provider-specific reason the model stopped generating
+	// output. FinishReason holds the same reason in normalized form.
 	StopReason string
 
+	// FinishReason is the normalized reason the model stopped generating output.
+	FinishReason StopReason
+
+	// Usage is the token usage of the request in a provider-independent form.
+	// It is nil if the provider does not report token usage.
+	Usage *Usage
+
 	// GenerationInfo is arbitrary information the model adds to the response.
+	// Token counts are also available in a normalized form in Usage.
 	GenerationInfo map[string]any
 
 	// FuncCall is non-nil when the model asks to invoke a function/tool.
@@ -2,9 +2,16 @@ package googleai
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/google/generative-ai-go/genai"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/llms"
 )
 
@@ -404,6 +411,31 @@ func TestConvertTools(t *testing.T) { //nolint:funlen // comprehensive test //no
 		assert.Contains(t, funcDecl.Parameters.Required, "location")
 	})
 
+	t.Run("jsonschema parameters", func(t *testing.T) {
+		type weatherArgs struct {
+			Location string  `json:"location" jsonschema:"description=City name"`
+			Unit     *string `json:"unit" jsonschema:"enum=celsius,enum=fahrenheit"`
+		}
+		tools := []llms.Tool{
+			{
+				Type: "function",
+				Function: &llms.FunctionDefinition{
+					Name:        "get_weather",
+					Description: "Get weather information",
+					Parameters:  jsonschema.For[weatherArgs](),
+				},
+			},
+		}
+		result, err := convertTools(tools)
+		assert.NoError(t, err)
+
+		params := result[0].FunctionDeclarations[0].Parameters
+		assert.Equal(t, "City name", params.Properties["location"].Description)
+		assert.True(t, params.Properties["unit"].Nullable)
+		assert.Equal(t, genai.TypeString, params.Properties["unit"].Type)
+		assert.Equal(t, []string{"celsius", "fahrenheit"}, params.Properties["unit"].Enum)
+	})
+
 	t.Run("nested object schema", func(t *testing.T) {
 		tools := []llms.Tool{
 			{
@@ -570,3 +602,51 @@ func TestConvertTools(t *testing.T) { //nolint:funlen // comprehensive test //no
 		assert.Contains(t, customizationsProp.Items.Required, "value")
 	})
 }
+
+func TestConvertToolChoice(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, convertToolChoice(nil))
+	assert.Nil(t, convertToolChoice("unknown"))
+	assert.Equal(t, genai.FunctionCallingNone, convertToolChoice("none").FunctionCallingConfig.Mode)
+	assert.Equal(t, genai.FunctionCallingAny, convertToolChoice("required").FunctionCallingConfig.Mode)
+
+	config := convertToolChoice(llms.ToolChoice{Type: "function", Function: &llms.FunctionReference{Name: "weather"}})
+	assert.Equal(t, genai.FunctionCallingAny, config.FunctionCallingConfig.Mode)
+	assert.Equal(t, []string{"weather"}, config.FunctionCallingConfig.AllowedFunctionNames)
+}
+
+// roundTripFunc is an http.RoundTripper calling a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestGenerateContentStreamingError(t *testing.T) {
+	t.Parallel()
+
+	// The REST API streams a JSON array of responses.
+	chunks := `[{"candidates":[{"content":{"parts":[{"text":"Hello"}],"role":"model"}}]},` +
+		`{"candidates":[{"content":{"parts":[{"text":" world"}],"role":"model"},"finishReason":"STOP"}]}]`
+	client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(chunks)),
+		}, nil
+	})}
+	llm, err := New(context.Background(), WithRest(), WithHTTPClient(client), WithAPIKey("test"))
+	require.NoError(t, err)
+
+	errStop := errors.New("stop streaming")
+	var streamed string
+	_, err = llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hi")},
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			streamed += string(chunk)
+			return errStop
+		}))
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, "Hello", streamed)
+}
@@ -73,6 +73,11 @@ func New(ctx context.Context, projectID, location string, opts ...Option) (*PaLM
 	}, nil
 }
 
+// TextModelName returns the model generating the completions.
+func (c *PaLMClient) TextModelName() string {
+	return c.textModelName
+}
+
 // ErrEmptyResponse is returned when the OpenAI API returns an empty response.
 var ErrEmptyResponse = errors.New("empty response")
 
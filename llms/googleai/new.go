@@ -22,6 +22,7 @@ type GoogleAI struct {
 var (
 	_ llms.Model          = &GoogleAI{}
 	_ llms.ReasoningModel = &GoogleAI{}
+	_ llms.StreamingModel = &GoogleAI{}
 )
 
 // New creates a new GoogleAI client.
@@ -62,6 +62,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	}
 
 	resp := &llms.ContentResponse{
+		Model: o.client.TextModelName(),
 		Choices: []*llms.ContentChoice{
 			{
 				Content: results[0].Text,
@@ -24,7 +24,10 @@ type Vertex struct {
 	palmClient       *palmclient.PaLMClient
 }
 
-var _ llms.Model = &Vertex{}
+var (
+	_ llms.Model          = &Vertex{}
+	_ llms.StreamingModel = &Vertex{}
+)
 
 // New creates a new Vertex client.
 func New(ctx context.Context, opts ...googleai.Option) (*Vertex, error) {
@@ -10,10 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"strings"
 
 	"cloud.google.com/go/vertexai/genai"
 	"github.com/tmc/langchaingo/internal/imageutil"
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/llms"
 	"google.golang.org/api/iterator"
 )
@@ -39,6 +41,15 @@ func (g *Vertex) Call(ctx context.Context, prompt string, options ...llms.CallOp
 	return llms.GenerateFromSinglePrompt(ctx, g, prompt, options...)
 }
 
+// StreamContent implements the [llms.StreamingModel] interface.
+func (v *Vertex) StreamContent(
+	ctx context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) iter.Seq2[llms.StreamEvent, error] {
+	return llms.StreamEvents(ctx, v, messages, options...)
+}
+
 // GenerateContent implements the [llms.Model] interface.
 func (g *Vertex) GenerateContent(
 	ctx context.Context,
@@ -90,6 +101,7 @@ func (g *Vertex) GenerateContent(
 	if model.Tools, err = convertTools(opts.Tools); err != nil {
 		return nil, err
 	}
+	model.ToolConfig = convertToolChoice(opts.ToolChoice)
 
 	// set model.ResponseMIMEType from either opts.JSONMode or opts.ResponseMIMEType
 	switch {
@@ -101,6 +113,13 @@ func (g *Vertex) GenerateContent(
 		model.ResponseMIMEType = ResponseMIMETypeJson
 	}
 
+	if opts.ResponseSchema != nil {
+		if model.ResponseSchema, err = convertResponseSchema(opts.ResponseSchema); err != nil {
+			return nil, err
+		}
+		model.ResponseMIMEType = ResponseMIMETypeJson
+	}
+
 	var response *llms.ContentResponse
 
 	if len(messages) == 1 {
@@ -115,6 +134,8 @@ func (g *Vertex) GenerateContent(
 	if err != nil {
 		return nil, err
 	}
+	// Gemini does not report the model in its responses.
+	response.Model = opts.Model
 
 	if g.CallbacksHandler != nil {
 		g.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -171,6 +192,8 @@ func convertCandidates(candidates []*genai.Candidate, usage *genai.UsageMetadata
 			&llms.ContentChoice{
 				Content:        buf.String(),
 				StopReason:     candidate.FinishReason.String(),
+				FinishReason:   finishReason(candidate),
+				Usage:          tokenUsage(usage),
 				GenerationInfo: metadata,
 				ToolCalls:      toolCalls,
 			})
@@ -219,6 +242,36 @@ func convertParts(parts []llms.ContentPart) ([]genai.Part, error) {
 	return convertedParts, nil
 }
 
+// convertToolChoice converts the tool choice of the options, "none", "auto",
+// "required" or a llms.ToolChoice naming a function, to a genai tool config.
+func convertToolChoice(choice any) *genai.ToolConfig {
+	config := &genai.FunctionCallingConfig{}
+	switch c := choice.(type) {
+	case string:
+		switch c {
+		case "none":
+			config.Mode = genai.FunctionCallingNone
+		case "auto":
+			config.Mode = genai.FunctionCallingAuto
+		case "required", "any":
+			config.Mode = genai.FunctionCallingAny
+		default:
+			return nil
+		}
+	case llms.ToolChoice:
+		return convertToolChoice(&c)
+	case *llms.ToolChoice:
+		if c == nil || c.Function == nil {
+			return nil
+		}
+		config.Mode = genai.FunctionCallingAny
+		config.AllowedFunctionNames = []string{c.Function.Name}
+	default:
+		return nil
+	}
+	return &genai.ToolConfig{FunctionCallingConfig: config}
+}
+
 // convertContent converts between a langchain MessageContent and genai content.
 func convertContent(content llms.MessageContent) (*genai.Content, error) {
 	parts, err := convertParts(content.Parts)
@@ -263,7 +316,7 @@ func generateFromSingleMessage(
 		return nil, err
 	}
 
-	if opts.StreamingFunc == nil {
+	if !opts.IsStreaming() {
 		// When no streaming is requested, just call GenerateContent and return
 		// the complete response with a list of candidates.
 		resp, err := model.GenerateContent(ctx, convertedParts...)
@@ -287,6 +340,7 @@ func generateFromMessages(
 	opts *llms.CallOptions,
 ) (*llms.ContentResponse, error) {
 	history := make([]*genai.Content, 0, len(messages))
+	var prevRole llms.ChatMessageType
 	for _, mc := range messages {
 		content, err := convertContent(mc)
 		if err != nil {
@@ -296,7 +350,15 @@ func generateFromMessages(
 			model.SystemInstruction = content
 			continue
 		}
+		// Tool responses are sent as one message per call, but Gemini
+		// expects the responses to the calls of a turn in one content.
+		if mc.Role == llms.ChatMessageTypeTool && prevRole == llms.ChatMessageTypeTool {
+			last := history[len(history)-1]
+			last.Parts = append(last.Parts, content.Parts...)
+			continue
+		}
 		history = append(history, content)
+		prevRole = mc.Role
 	}
 
 	// Given N total messages, genai's chat expects the first N-1 messages as
@@ -308,7 +370,7 @@ func generateFromMessages(
 	session := model.StartChat()
 	session.History = history
 
-	if opts.StreamingFunc == nil {
+	if !opts.IsStreaming() {
 		resp, err := session.SendMessage(ctx, reqContent.Parts...)
 		if err != nil {
 			return nil, err
@@ -336,6 +398,7 @@ func convertAndStreamFromIterator(
 	candidate := &genai.Candidate{
 		Content: &genai.Content{},
 	}
+	toolCallIndex := 0
 DoStream:
 	for {
 		resp, err := iter.Next()
@@ -361,17 +424,254 @@ DoStream:
 		candidate.CitationMetadata = respCandidate.CitationMetadata
 
 		for _, part := range respCandidate.Content.Parts {
-			if text, ok := part.(genai.Text); ok {
-				if opts.StreamingFunc(ctx, []byte(text)) != nil {
-					break DoStream
-				}
+			if err := streamPart(ctx, part, &toolCallIndex, opts); err != nil {
+				return nil, err
 			}
 		}
 	}
 	mresp := iter.MergedResponse()
+	if err := streamUsageAndStop(ctx, candidate, mresp.UsageMetadata, opts); err != nil {
+		return nil, err
+	}
 	return convertCandidates([]*genai.Candidate{candidate}, mresp.UsageMetadata)
 }
 
+// streamPart reports a single streamed part to the streaming functions in
+// opts.
+func streamPart(ctx context.Context, part genai.Part, toolCallIndex *int, opts *llms.CallOptions) error {
+	switch p := part.(type) {
+	case genai.Text:
+		return opts.EmitStreamEvent(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: string(p)})
+	case genai.FunctionCall:
+		// Function calls arrive in one piece, so they are reported as a
+		// complete start, arguments and end sequence.
+		b, err := json.Marshal(p.Args)
+		if err != nil {
+			return err
+		}
+		events := llms.ToolCallEvents(*toolCallIndex, llms.ToolCall{
+			FunctionCall: &llms.FunctionCall{
+				Name:      p.Name,
+				Arguments: string(b),
+			},
+		})
+		*toolCallIndex++
+		for _, event := range events {
+			if err := opts.EmitStreamEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// streamUsageAndStop reports the usage and finish reason of a streamed
+// response to the streaming functions in opts.
+func streamUsageAndStop(ctx context.Context, candidate *genai.Candidate, usage *genai.UsageMetadata, opts *llms.CallOptions) error {
+	if usage != nil {
+		err := opts.EmitStreamEvent(ctx, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: tokenUsage(usage)})
+		if err != nil {
+			return err
+		}
+	}
+	return opts.EmitStreamEvent(ctx, llms.StreamEvent{
+		Type:         llms.StreamEventStop,
+		StopReason:   candidate.FinishReason.String(),
+		FinishReason: finishReason(candidate),
+	})
+}
+
+// finishReason maps the finish reason of candidate to a llms.StopReason.
+// Gemini reports a normal stop when it calls functions, so candidates with
+// function calls are reported as llms.StopReasonToolUse.
+func finishReason(candidate *genai.Candidate) llms.StopReason {
+	switch candidate.FinishReason {
+	case genai.FinishReasonUnspecified:
+		return ""
+	case genai.FinishReasonStop:
+		if candidate.Content != nil {
+			for _, part := range candidate.Content.Parts {
+				if _, ok := part.(genai.FunctionCall); ok {
+					return llms.StopReasonToolUse
+				}
+			}
+		}
+		return llms.StopReasonEndTurn
+	case genai.FinishReasonMaxTokens:
+		return llms.StopReasonMaxTokens
+	case genai.FinishReasonSafety, genai.FinishReasonRecitation, genai.FinishReasonBlocklist,
+		genai.FinishReasonProhibitedContent, genai.FinishReasonSpii:
+		return llms.StopReasonContentFilter
+	default:
+		return llms.StopReasonOther
+	}
+}
+
+// tokenUsage converts usage to a llms.Usage, or returns nil if usage is nil.
+func tokenUsage(usage *genai.UsageMetadata) *llms.Usage {
+	if usage == nil {
+		return nil
+	}
+	return &llms.Usage{
+		InputTokens:  int(usage.PromptTokenCount),
+		OutputTokens: int(usage.CandidatesTokenCount),
+		TotalTokens:  int(usage.TotalTokenCount),
+	}
+}
+
+// nullableSchemaType returns the type of a nullable schema, whose types are
+// a type and "null".
+func nullableSchemaType(types []any) (string, bool) {
+	if len(types) != 2 {
+		return "", false
+	}
+	for _, t := range types {
+		if t, ok := t.(string); ok && t != "null" {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// convertSchemaRecursive recursively converts a schema map to a genai.Schema
+func convertSchemaRecursive(schemaMap map[string]any, toolIndex int, propertyPath string) (*genai.Schema, error) {
+	schema := &genai.Schema{}
+
+	if ty, ok := schemaMap["type"]; ok {
+		tyString, ok := ty.(string)
+		if types, isList := ty.([]any); isList {
+			// Nullable types are encoded as [type, "null"].
+			tyString, ok = nullableSchemaType(types)
+			schema.Nullable = true
+		}
+		if !ok {
+			return nil, fmt.Errorf("tool [%d], property [%s]: expected string for type", toolIndex, propertyPath)
+		}
+		schema.Type = convertToolSchemaType(tyString)
+	}
+
+	if desc, ok := schemaMap["description"]; ok {
+		descString, ok := desc.(string)
+		if !ok {
+			return nil, fmt.Errorf("tool [%d], property [%s]: expected string for description", toolIndex, propertyPath)
+		}
+		schema.Description = descString
+	}
+
+	if enum, ok := schemaMap["enum"].([]any); ok {
+		for _, e := range enum {
+			eString, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("tool [%d], property [%s]: expected string for enum", toolIndex, propertyPath)
+			}
+			schema.Enum = append(schema.Enum, eString)
+		}
+	} else if enum, ok := schemaMap["enum"].([]string); ok {
+		schema.Enum = enum
+	}
+
+	// Handle object properties recursively
+	if properties, ok := schemaMap["properties"]; ok {
+		propMap, ok := properties.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("tool [%d], property [%s]: expected map for properties", toolIndex, propertyPath)
+		}
+
+		schema.Properties = make(map[string]*genai.Schema)
+		for propName, propValue := range propMap {
+			valueMap, ok := propValue.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("tool [%d], property [%s.%s]: expect to find a value map", toolIndex, propertyPath, propName)
+			}
+
+			nestedPath := propName
+			if propertyPath != "" {
+				nestedPath = propertyPath + "." + propName
+			}
+
+			nestedSchema, err := convertSchemaRecursive(valueMap, toolIndex, nestedPath)
+			if err != nil {
+				return nil, err
+			}
+			schema.Properties[propName] = nestedSchema
+		}
+	} else if schema.Type == genai.TypeObject && propertyPath == "" {
+		// For top-level object schemas without properties, this is an error
+		return nil, fmt.Errorf("tool [%d]: expected to find a map of properties", toolIndex)
+	}
+
+	// Handle array items recursively
+	if items, ok := schemaMap["items"]; ok && schema.Type == genai.TypeArray {
+		itemMap, ok := items.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("tool [%d], property [%s]: expect to find a map for array items", toolIndex, propertyPath)
+		}
+
+		itemsPath := propertyPath + "[]"
+		itemsSchema, err := convertSchemaRecursive(itemMap, toolIndex, itemsPath)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = itemsSchema
+	}
+
+	// Handle required fields
+	if required, ok := schemaMap["required"]; ok {
+		if rs, ok := required.([]string); ok {
+			schema.Required = rs
+		} else if ri, ok := required.([]interface{}); ok {
+			rs := make([]string, 0, len(ri))
+			for _, r := range ri {
+				rString, ok := r.(string)
+				if !ok {
+					return nil, fmt.Errorf("tool [%d], property [%s]: expected string for required", toolIndex, propertyPath)
+				}
+				rs = append(rs, rString)
+			}
+			schema.Required = rs
+		} else {
+			return nil, fmt.Errorf("tool [%d], property [%s]: expected array for required", toolIndex, propertyPath)
+		}
+	}
+
+	return schema, nil
+}
+
+// convertResponseSchema converts the JSON schema of a structured response to
+// a genai.Schema.
+func convertResponseSchema(responseSchema *llms.ResponseSchema) (*genai.Schema, error) {
+	schemaMap, err := responseSchema.SchemaMap()
+	if err != nil {
+		return nil, fmt.Errorf("response schema: %w", err)
+	}
+	schema, err := convertSchemaRecursive(schemaMap, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("response schema: %w", err)
+	}
+	return schema, nil
+}
+
+// toolParameters returns the parameters schema of a tool as a map. The schema
+// is either a map, a jsonschema.Definition or a json.RawMessage.
+func toolParameters(parameters any) (map[string]any, bool) {
+	switch parameters := parameters.(type) {
+	case map[string]any:
+		return parameters, true
+	case jsonschema.Definition, *jsonschema.Definition, json.RawMessage:
+		data, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, false
+		}
+		var params map[string]any
+		if err := json.Unmarshal(data, &params); err != nil {
+			return nil, false
+		}
+		return params, true
+	default:
+		return nil, false
+	}
+}
+
 // convertTools converts from a list of langchaingo tools to a list of genai
 // tools.
 func convertTools(tools []llms.Tool) ([]*genai.Tool, error) {
@@ -388,9 +688,9 @@ func convertTools(tools []llms.Tool) ([]*genai.Tool, error) {
 			Description: tool.Function.Description,
 		}
 
-		// Expect the Parameters field to be a map[string]any, from which we will
-		// extract properties to populate the schema.
-		params, ok := tool.Function.Parameters.(map[string]any)
+		// Expect the Parameters field to be a map[string]any or a jsonschema
+		// definition, from which we will extract properties to populate the schema.
+		params, ok := toolParameters(tool.Function.Parameters)
 		if !ok {
 			return nil, fmt.Errorf("tool [%d]: unsupported type %T of Parameters", i, tool.Function.Parameters)
 		}
@@ -91,21 +91,26 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 	req := &llamafileclient.ChatRequest{
 		Messages: chatMsgs,
-		Stream:   func(b bool) *bool { return &b }(opts.StreamingFunc != nil),
+		Stream:   func(b bool) *bool { return &b }(opts.IsStreaming()),
 	}
 
 	req = makeLlamaOptionsFromOptions(req, opts)
 
 	streamedResponse := ""
+	var final llamafileclient.ChatResponse
 	fn := func(response llamafileclient.ChatResponse) error {
-		if opts.StreamingFunc != nil && response.Content != "" {
-			if err := opts.StreamingFunc(ctx, []byte(response.Content)); err != nil {
+		if response.Content != "" {
+			event := llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: response.Content}
+			if err := opts.EmitStreamEvent(ctx, event); err != nil {
 				return err
 			}
 		}
 		if response.Content != "" {
 			streamedResponse += response.Content
 		}
+		if response.Stop {
+			final = response
+		}
 
 		return nil
 	}
@@ -119,14 +124,31 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	}
 
 	return &llms.ContentResponse{
+		Model: final.Model,
 		Choices: []*llms.ContentChoice{
 			{
-				Content: streamedResponse,
+				Content:      streamedResponse,
+				FinishReason: finishReason(final),
+				Usage:        llms.NewUsage(final.TokensEvaluated, final.TokensPredicted),
 			},
 		},
 	}, nil
 }
 
+// finishReason returns the reason the final response of a chat stopped.
+func finishReason(response llamafileclient.ChatResponse) llms.StopReason {
+	switch {
+	case !response.Stop:
+		return ""
+	case response.StoppedLimit:
+		return llms.StopReasonMaxTokens
+	case response.StoppedWord:
+		return llms.StopReasonStopSequence
+	default:
+		return llms.StopReasonEndTurn
+	}
+}
+
 func (o *LLM) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
 	resp, err := o.client.CreateEmbedding(ctx, texts)
 	if err != nil {
@@ -164,7 +186,7 @@ func typeToRole(typ llms.ChatMessageType) string {
 
 func makeLlamaOptionsFromOptions(input *llamafileclient.ChatRequest, opts llms.CallOptions) *llamafileclient.ChatRequest {
 	// Initialize llamaOptions with values from opts
-	streamValue := opts.StreamingFunc != nil
+	streamValue := opts.IsStreaming()
 
 	input.FrequencyPenalty = opts.FrequencyPenalty // Assuming FrequencyPenalty correlates to FrequencyPenalty; adjust if necessary
 	input.MinP = float64(opts.MinLength)           // Assuming there's a direct correlation; adjust if necessary
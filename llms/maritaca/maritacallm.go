@@ -110,7 +110,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		Format:   format,
 		Messages: chatMsgs,
 		Options:  maritacaOptions,
-		Stream:   func(b bool) *bool { return &b }(opts.StreamingFunc != nil),
+		Stream:   func(b bool) *bool { return &b }(opts.IsStreaming()),
 	}
 
 	var fn maritacaclient.ChatResponseFunc
@@ -118,8 +118,9 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	var resp maritacaclient.ChatResponse
 
 	fn = func(response maritacaclient.ChatResponse) error {
-		if opts.StreamingFunc != nil && response.Text != "" {
-			if err := opts.StreamingFunc(ctx, []byte(response.Text)); err != nil {
+		if response.Text != "" {
+			event := llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: response.Text}
+			if err := opts.EmitStreamEvent(ctx, event); err != nil {
 				return err
 			}
 		}
@@ -145,7 +146,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 	choices := createChoice(resp)
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Model: model}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -179,7 +180,7 @@ func makemaritacaOptionsFromOptions(maritacaOptions maritacaclient.Options, opts
 	maritacaOptions.TopP = opts.TopP
 	maritacaOptions.RepetitionPenalty = opts.RepetitionPenalty
 	maritacaOptions.StoppingTokens = opts.StopWords
-	maritacaOptions.Stream = opts.StreamingFunc != nil
+	maritacaOptions.Stream = opts.IsStreaming()
 
 	return maritacaOptions
 }
@@ -188,6 +189,7 @@ func createChoice(resp maritacaclient.ChatResponse) []*llms.ContentChoice {
 	return []*llms.ContentChoice{
 		{
 			Content: resp.Answer,
+			Usage:   llms.NewUsage(resp.Usage.PromptTokens, resp.Usage.CompletionTokens),
 			GenerationInfo: map[string]any{
 				"CompletionTokens": resp.Usage.CompletionTokens,
 				"PromptTokens":     resp.Usage.PromptTokens,
@@ -0,0 +1,115 @@
+package llms
+
+import (
+	"context"
+	"iter"
+)
+
+// Middleware wraps a Model to add behavior around its calls, such as
+// caching, logging, retries or rewriting requests. Middleware is composed
+// with Wrap.
+type Middleware func(Model) Model
+
+// GenerateContentFunc is an adapter to allow the use of ordinary functions as
+// Models. It is convenient for writing Middleware:
+//
+//	func logging(next llms.Model) llms.Model {
+//		return llms.GenerateContentFunc(func(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+//			log.Printf("generating content for %d messages", len(messages))
+//			return next.GenerateContent(ctx, messages, options...)
+//		})
+//	}
+type GenerateContentFunc func(ctx context.Context, messages []MessageContent, options ...CallOption) (*ContentResponse, error) //nolint:lll
+
+// GenerateContent calls f(ctx, messages, options...).
+func (f GenerateContentFunc) GenerateContent(ctx context.Context, messages []MessageContent, options ...CallOption) (*ContentResponse, error) { //nolint:lll
+	return f(ctx, messages, options...)
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (f GenerateContentFunc) Call(ctx context.Context, prompt string, options ...CallOption) (string, error) {
+	return GenerateFromSinglePrompt(ctx, f, prompt, options...)
+}
+
+// Wrap applies middleware to model. The first middleware is the outermost:
+// it sees requests first and responses last.
+//
+// The returned Model keeps the optional capabilities of model, even when the
+// middleware returns a plain Model: it implements StreamingModel and
+// ReasoningModel, reporting whether model supports reasoning, and it can
+// create embeddings when model can. Unwrap returns the wrapped model.
+func Wrap(model Model, mws ...Middleware) Model {
+	if len(mws) == 0 {
+		return model
+	}
+	outer := model
+	for i := len(mws) - 1; i >= 0; i-- {
+		outer = mws[i](outer)
+	}
+	w := &wrappedModel{outer: outer, inner: model}
+	if e, ok := model.(embedder); ok {
+		return &wrappedEmbedder{wrappedModel: w, embedder: e}
+	}
+	return w
+}
+
+// embedder is implemented by models that can also create embeddings, matching
+// embeddings.EmbedderClient.
+type embedder interface {
+	CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// wrappedModel is a model with middleware applied. Calls go through the
+// middleware in outer; optional capabilities come from inner.
+type wrappedModel struct {
+	outer Model
+	inner Model
+}
+
+var (
+	_ StreamingModel = (*wrappedModel)(nil)
+	_ ReasoningModel = (*wrappedModel)(nil)
+)
+
+func (w *wrappedModel) GenerateContent(ctx context.Context, messages []MessageContent, options ...CallOption) (*ContentResponse, error) { //nolint:lll
+	return w.outer.GenerateContent(ctx, messages, options...)
+}
+
+func (w *wrappedModel) Call(ctx context.Context, prompt string, options ...CallOption) (string, error) {
+	return GenerateFromSinglePrompt(ctx, w, prompt, options...)
+}
+
+func (w *wrappedModel) StreamContent(ctx context.Context, messages []MessageContent, options ...CallOption) iter.Seq2[StreamEvent, error] { //nolint:lll
+	return StreamContent(ctx, w.outer, messages, options...)
+}
+
+func (w *wrappedModel) SupportsReasoning() bool {
+	if _, ok := w.outer.(ReasoningModel); ok {
+		return SupportsReasoningModel(w.outer)
+	}
+	return SupportsReasoningModel(w.inner)
+}
+
+// Unwrap returns the model the middleware was applied to.
+func (w *wrappedModel) Unwrap() Model {
+	return w.inner
+}
+
+// wrappedEmbedder is a wrappedModel whose model can create embeddings.
+type wrappedEmbedder struct {
+	*wrappedModel
+	embedder embedder
+}
+
+func (w *wrappedEmbedder) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	if e, ok := w.outer.(embedder); ok {
+		return e.CreateEmbedding(ctx, texts)
+	}
+	return w.embedder.CreateEmbedding(ctx, texts)
+}
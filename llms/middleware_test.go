@@ -0,0 +1,122 @@
+package llms_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// eventModel streams like the providers do: each chunk is reported as a text
+// delta event with EmitStreamEvent.
+type eventModel struct {
+	chunks    []string
+	reasoning bool
+}
+
+func (m *eventModel) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	for _, chunk := range m.chunks {
+		if err := opts.EmitStreamEvent(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: chunk}); err != nil {
+			return nil, err
+		}
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: strings.Join(m.chunks, "")}}}, nil
+}
+
+func (m *eventModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *eventModel) SupportsReasoning() bool {
+	return m.reasoning
+}
+
+type embeddingModel struct {
+	eventModel
+}
+
+func (m *embeddingModel) CreateEmbedding(_ context.Context, texts []string) ([][]float32, error) {
+	return make([][]float32, len(texts)), nil
+}
+
+// recording returns a middleware that appends name to calls on each request.
+func recording(name string, calls *[]string) llms.Middleware {
+	return func(next llms.Model) llms.Model {
+		return llms.GenerateContentFunc(func(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+			*calls = append(*calls, name)
+			return next.GenerateContent(ctx, messages, options...)
+		})
+	}
+}
+
+func TestWrapOrder(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	model := llms.Wrap(&eventModel{chunks: []string{"Hi"}}, recording("outer", &calls), recording("inner", &calls))
+
+	got, err := llms.GenerateFromSinglePrompt(context.Background(), model, "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Hi" {
+		t.Errorf("got %q, want %q", got, "Hi")
+	}
+	if want := []string{"outer", "inner"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestWrapCapabilities(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	base := &eventModel{reasoning: true}
+	model := llms.Wrap(base, recording("mw", &calls))
+	if !llms.SupportsReasoningModel(model) {
+		t.Error("wrapped reasoning model does not support reasoning")
+	}
+	if _, ok := model.(llms.StreamingModel); !ok {
+		t.Error("wrapped model is not a StreamingModel")
+	}
+	if u, ok := model.(interface{ Unwrap() llms.Model }); !ok || u.Unwrap() != base {
+		t.Error("Unwrap does not return the wrapped model")
+	}
+
+	type embedder interface {
+		CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+	}
+	if _, ok := model.(embedder); ok {
+		t.Error("wrapped model creates embeddings, but the model does not")
+	}
+	if _, ok := llms.Wrap(&embeddingModel{}, recording("mw", &calls)).(embedder); !ok {
+		t.Error("wrapped embedding model does not create embeddings")
+	}
+}
+
+func TestWrapStreamContent(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	model := llms.Wrap(&eventModel{chunks: []string{"Hel", "lo"}}, recording("mw", &calls))
+
+	events, err := collectEvents(llms.StreamContent(context.Background(), model, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The middleware passes both streaming callbacks to the model, which
+	// reports each chunk twice; the text is streamed once.
+	want := []llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Delta: "Hel"},
+		{Type: llms.StreamEventTextDelta, Delta: "lo"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events mismatch:\ngot:  %+v\nwant: %+v", events, want)
+	}
+}
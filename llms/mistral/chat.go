@@ -0,0 +1,175 @@
+package mistral
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sdk "github.com/gage-technologies/mistral-go"
+	"github.com/tmc/langchaingo/httputil"
+)
+
+// chatClient sends chat completion requests to the Mistral API, with the
+// request and response types of the SDK. Unlike the SDK client, it honors
+// contexts and reports unsuccessful responses as *httputil.ResponseError, so
+// that their status code and Retry-After headers are available to callers.
+type chatClient struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+func newChatClient(apiKey, endpoint string, maxRetries int, timeout time.Duration) *chatClient {
+	if endpoint == "" {
+		endpoint = sdk.Endpoint
+	}
+	// As with the SDK, maxRetries counts the first attempt.
+	retries := maxRetries - 1
+	if retries <= 0 {
+		retries = -1
+	}
+	return &chatClient{
+		apiKey:   apiKey,
+		endpoint: endpoint,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &httputil.RetryTransport{MaxRetries: retries},
+		},
+	}
+}
+
+// chat returns the completion of the messages.
+func (c *chatClient) chat(ctx context.Context, model string, messages []sdk.ChatMessage, params *sdk.ChatRequestParams) (*sdk.ChatCompletionResponse, error) {
+	resp, err := c.post(ctx, chatRequest(model, messages, params, false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var res sdk.ChatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &res, nil
+}
+
+// chatStream returns a channel receiving the chunks of the completion of the
+// messages. The channel is closed at the end of the stream; read errors are
+// sent as chunks with an Error.
+func (c *chatClient) chatStream(ctx context.Context, model string, messages []sdk.ChatMessage, params *sdk.ChatRequestParams) (<-chan sdk.ChatCompletionStreamResponse, error) {
+	resp, err := c.post(ctx, chatRequest(model, messages, params, true))
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan sdk.ChatCompletionStreamResponse)
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		send := func(chunk sdk.ChatCompletionStreamResponse) bool {
+			select {
+			case chunks <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			data, ok := bytes.CutPrefix(scanner.Bytes(), []byte("data:"))
+			if !ok {
+				continue
+			}
+			data = bytes.TrimSpace(data)
+			if bytes.Equal(data, []byte("[DONE]")) {
+				return
+			}
+			var chunk sdk.ChatCompletionStreamResponse
+			if err := json.Unmarshal(data, &chunk); err != nil {
+				chunk = sdk.ChatCompletionStreamResponse{Error: fmt.Errorf("error decoding stream response: %w", err)}
+			}
+			if !send(chunk) || chunk.Error != nil {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(sdk.ChatCompletionStreamResponse{Error: fmt.Errorf("error reading stream response: %w", err)})
+		}
+	}()
+	return chunks, nil
+}
+
+// chatRequest returns the body of a chat completion request, with the same
+// fields as the requests of the SDK.
+func chatRequest(model string, messages []sdk.ChatMessage, params *sdk.ChatRequestParams, stream bool) map[string]any {
+	if params == nil {
+		params = &sdk.DefaultChatRequestParams
+	}
+	request := map[string]any{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+		"top_p":       params.TopP,
+		"random_seed": params.RandomSeed,
+		"safe_prompt": params.SafePrompt,
+	}
+	if stream {
+		request["stream"] = true
+	}
+	if params.Tools != nil {
+		request["tools"] = params.Tools
+	}
+	if params.ToolChoice != "" {
+		request["tool_choice"] = params.ToolChoice
+	}
+	if params.ResponseFormat != "" {
+		request["response_format"] = map[string]any{"type": params.ResponseFormat}
+	}
+	return request
+}
+
+func (c *chatClient) post(ctx context.Context, payload map[string]any) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := url.JoinPath(c.endpoint, "v1/chat/completions")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+// decodeError returns the error of an unsuccessful response, with the
+// message of the SDK errors.
+func decodeError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	msg := fmt.Sprintf("(HTTP Error %d) %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	return httputil.NewResponseError(resp, errors.New(msg))
+}
@@ -0,0 +1,108 @@
+package mistral
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/cost"
+)
+
+func TestGenerateContentResponseError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message":"Requests rate limit exceeded"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	model, err := New(WithAPIKey("test"), WithEndpoint(srv.URL), WithMaxRetries(1))
+	require.NoError(t, err)
+	messages := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hello")}
+
+	for name, opts := range map[string][]llms.CallOption{
+		"non-streaming": nil,
+		"streaming": {llms.WithStreamingFunc(func(context.Context, []byte) error {
+			return nil
+		})},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := model.GenerateContent(context.Background(), messages, opts...)
+			var respErr *httputil.ResponseError
+			require.ErrorAs(t, err, &respErr)
+			require.Equal(t, http.StatusTooManyRequests, respErr.StatusCode)
+			require.Equal(t, 7*time.Second, respErr.RetryAfter())
+			require.ErrorContains(t, err, "Requests rate limit exceeded")
+			require.Equal(t, llms.ErrCodeRateLimit, llms.ErrorCodeOf(err))
+		})
+	}
+}
+
+func TestGenerateContentStream(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/chat/completions", r.URL.Path)
+		require.Equal(t, "Bearer test", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"id":"1","model":"mistral-small","choices":[{"index":0,"delta":{"content":"Hello"}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"id":"1","model":"mistral-small","choices":[{"index":0,"delta":{"content":" world"},"finish_reason":"stop"}],`+
+			`"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	model, err := New(WithAPIKey("test"), WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	var streamed string
+	resp, err := model.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hello")},
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			streamed += string(chunk)
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, "Hello world", streamed)
+	require.Equal(t, "Hello world", resp.Choices[0].Content)
+	require.Equal(t, llms.NewUsage(3, 2), resp.Choices[0].Usage)
+
+	errStop := errors.New("stop")
+	_, err = model.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hello")},
+		llms.WithStreamingFunc(func(context.Context, []byte) error {
+			return errStop
+		}))
+	require.ErrorIs(t, err, errStop)
+}
+
+func TestGenerateContentCallbacks(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","model":"mistral-small","choices":[{"index":0,"message":{"role":"assistant","content":"Hello"},"finish_reason":"stop"}],`+
+			`"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`)
+	}))
+	defer srv.Close()
+
+	handler := cost.NewHandler()
+	model, err := New(WithAPIKey("test"), WithEndpoint(srv.URL), WithCallbacksHandler(handler))
+	require.NoError(t, err)
+
+	_, err = model.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hello")})
+	require.NoError(t, err)
+	// The response is reported once.
+	require.Equal(t, 1, handler.Total().Requests)
+}
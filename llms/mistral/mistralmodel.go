@@ -13,6 +13,7 @@ import (
 // Model encapsulates an instantiated Mistral client, the client options used to instantiate the client, and a callback handler provided by Langchain Go.
 type Model struct {
 	client           *sdk.MistralClient
+	chatClient       *chatClient
 	clientOptions    *clientOptions
 	CallbacksHandler callbacks.Handler
 }
@@ -34,10 +35,15 @@ func New(opts ...Option) (*Model, error) {
 		opt(options)
 	}
 
+	var handler callbacks.Handler = callbacks.SimpleHandler{}
+	if options.callbacksHandler != nil {
+		handler = options.callbacksHandler
+	}
 	return &Model{
 		clientOptions:    options,
 		client:           sdk.NewMistralClient(options.apiKey, options.endpoint, options.maxRetries, options.timeout),
-		CallbacksHandler: callbacks.SimpleHandler{},
+		chatClient:       newChatClient(options.apiKey, options.endpoint, options.maxRetries, options.timeout),
+		CallbacksHandler: handler,
 	}, nil
 }
 
@@ -52,7 +58,7 @@ func (m *Model) Call(ctx context.Context, prompt string, options ...llms.CallOpt
 		Role:    "user",
 		Content: prompt,
 	})
-	res, err := m.client.Chat(callOptions.Model, messages, &mistralChatParams)
+	res, err := m.chatClient.chat(ctx, callOptions.Model, messages, &mistralChatParams)
 	if err != nil {
 		m.CallbacksHandler.HandleLLMError(ctx, err)
 		return "", err
@@ -78,7 +84,7 @@ func (m *Model) GenerateContent(ctx context.Context, langchainMessages []llms.Me
 		return nil, err
 	}
 
-	if callOptions.StreamingFunc != nil {
+	if callOptions.IsStreaming() {
 		return generateStreamingContent(ctx, m, callOptions, messages, chatOpts)
 	}
 	return generateNonStreamingContent(ctx, m, callOptions, messages, chatOpts)
@@ -144,8 +150,7 @@ func mistralChatParamsFromCallOptions(callOpts *llms.CallOptions) sdk.ChatReques
 }
 
 func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llms.CallOptions, messages []sdk.ChatMessage, chatOpts sdk.ChatRequestParams) (*llms.ContentResponse, error) {
-	res, err := m.client.Chat(callOptions.Model, messages, &chatOpts)
-	m.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, nil)
+	res, err := m.chatClient.chat(ctx, callOptions.Model, messages, &chatOpts)
 	if err != nil {
 		m.CallbacksHandler.HandleLLMError(ctx, err)
 		return nil, err
@@ -158,11 +163,14 @@ func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llm
 
 	langchainContentResponse := &llms.ContentResponse{
 		Choices: make([]*llms.ContentChoice, 0),
+		Model:   res.Model,
 	}
 	for idx, choice := range res.Choices {
 		langchainContentResponse.Choices = append(langchainContentResponse.Choices, &llms.ContentChoice{
-			Content:    choice.Message.Content,
-			StopReason: string(choice.FinishReason),
+			Content:      choice.Message.Content,
+			StopReason:   string(choice.FinishReason),
+			FinishReason: llms.NormalizeStopReason(string(choice.FinishReason)),
+			Usage:        llms.NewUsage(res.Usage.PromptTokens, res.Usage.CompletionTokens),
 			GenerationInfo: map[string]any{
 				"created": res.Created,
 				"model":   res.Model,
@@ -190,7 +198,7 @@ func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llm
 }
 
 func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.CallOptions, messages []sdk.ChatMessage, chatOpts sdk.ChatRequestParams) (*llms.ContentResponse, error) {
-	chatResChan, err := m.client.ChatStream(callOptions.Model, messages, &chatOpts)
+	chatResChan, err := m.chatClient.chatStream(ctx, callOptions.Model, messages, &chatOpts)
 	if err != nil {
 		m.CallbacksHandler.HandleLLMError(ctx, err)
 		return nil, err
@@ -207,12 +215,17 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 		chunkStr := ""
 		langchainContentResponse.Choices[0].GenerationInfo["created"] = chatResChunk.Created
 		langchainContentResponse.Choices[0].GenerationInfo["model"] = chatResChunk.Model
+		langchainContentResponse.Model = chatResChunk.Model
 		langchainContentResponse.Choices[0].GenerationInfo["usage"] = chatResChunk.Usage
+		if chatResChunk.Usage.TotalTokens > 0 {
+			langchainContentResponse.Choices[0].Usage = llms.NewUsage(chatResChunk.Usage.PromptTokens, chatResChunk.Usage.CompletionTokens)
+		}
 		if chatResChunk.Error == nil {
 			for _, choice := range chatResChunk.Choices {
 				chunkStr += choice.Delta.Content
 				langchainContentResponse.Choices[0].Content += choice.Delta.Content
 				langchainContentResponse.Choices[0].StopReason = string(choice.FinishReason)
+				langchainContentResponse.Choices[0].FinishReason = llms.NormalizeStopReason(string(choice.FinishReason))
 				if len(choice.Delta.ToolCalls) > 0 {
 					langchainContentResponse.Choices[0].FuncCall = (*llms.FunctionCall)(&choice.Delta.ToolCalls[0].Function)
 					for _, tool := range choice.Delta.ToolCalls {
@@ -227,7 +240,7 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 					}
 				}
 			}
-			err := callOptions.StreamingFunc(ctx, []byte(chunkStr))
+			err := callOptions.EmitStreamEvent(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: chunkStr})
 			if err != nil {
 				return langchainContentResponse, err
 			}
@@ -242,6 +255,7 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sdk.ChatMessage, error) {
 	messages := make([]sdk.ChatMessage, 0)
 	for _, msg := range langchainMessages {
+		toolCallsIndex := -1
 		for _, part := range msg.Parts {
 			switch p := part.(type) {
 			case llms.TextContent:
@@ -255,9 +269,16 @@ func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sd
 				setMistralChatMessageRole(&msg, &chatMsg) // #nosec G601
 				messages = append(messages, chatMsg)
 			case llms.ToolCall:
-				chatMsg := sdk.ChatMessage{Role: string(msg.Role), ToolCalls: []sdk.ToolCall{{Id: p.ID, Type: sdk.ToolTypeFunction, Function: sdk.FunctionCall{Name: p.FunctionCall.Name, Arguments: p.FunctionCall.Arguments}}}}
+				toolCall := sdk.ToolCall{Id: p.ID, Type: sdk.ToolTypeFunction, Function: sdk.FunctionCall{Name: p.FunctionCall.Name, Arguments: p.FunctionCall.Arguments}}
+				// The tool calls of a message are sent in a single message.
+				if toolCallsIndex >= 0 {
+					messages[toolCallsIndex].ToolCalls = append(messages[toolCallsIndex].ToolCalls, toolCall)
+					continue
+				}
+				chatMsg := sdk.ChatMessage{Role: string(msg.Role), ToolCalls: []sdk.ToolCall{toolCall}}
 				setMistralChatMessageRole(&msg, &chatMsg) // #nosec G601
 				messages = append(messages, chatMsg)
+				toolCallsIndex = len(messages) - 1
 			default:
 				return nil, errors.New("unsupported content type encountered while preparing chat messages to send to mistral platform")
 			}
@@ -183,6 +183,25 @@ func TestConvertToMistralChatMessages(t *testing.T) { //nolint:funlen // compreh
 			},
 			want: 3, // AI message gets split: text content and tool call become separate messages
 		},
+		{
+			name: "parallel tool calls",
+			messages: []llms.MessageContent{
+				{
+					Role: llms.ChatMessageTypeAI,
+					Parts: []llms.ContentPart{
+						llms.ToolCall{
+							ID:           "call_1",
+							FunctionCall: &llms.FunctionCall{Name: "get_weather", Arguments: `{"location": "Paris"}`},
+						},
+						llms.ToolCall{
+							ID:           "call_2",
+							FunctionCall: &llms.FunctionCall{Name: "get_weather", Arguments: `{"location": "Rome"}`},
+						},
+					},
+				},
+			},
+			want: 1, // Tool calls of a message are sent in one message
+		},
 		{
 			name: "empty text content",
 			messages: []llms.MessageContent{
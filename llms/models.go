@@ -0,0 +1,157 @@
+package llms
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ModelInfo describes the limits and capabilities of a model.
+type ModelInfo struct {
+	// Name is the model name, such as "gpt-4o" or "claude-sonnet-4". It also
+	// describes the versions and variants of the model whose names start with
+	// Name followed by one of "-", ":", "@", "." or "_", such as
+	// "claude-sonnet-4-20250514" or "llama3.1:70b". Names may start with a
+	// provider, such as "ollama/llama3.1", to describe the model only when
+	// it is looked up with that provider.
+	Name string `json:"name"`
+	// Provider is the provider serving the model, such as "openai".
+	Provider string `json:"provider,omitempty"`
+
+	// ContextSize is the maximum number of input and output tokens.
+	ContextSize int `json:"context_size,omitempty"`
+	// MaxOutputTokens is the maximum number of tokens the model generates.
+	MaxOutputTokens int `json:"max_output_tokens,omitempty"`
+
+	// Tools reports whether the model supports tool calling.
+	Tools bool `json:"tools,omitempty"`
+	// Vision reports whether the model accepts image input.
+	Vision bool `json:"vision,omitempty"`
+	// Audio reports whether the model accepts audio input.
+	Audio bool `json:"audio,omitempty"`
+	// PDF reports whether the model accepts PDF documents as input.
+	PDF bool `json:"pdf,omitempty"`
+	// StructuredOutput reports whether the model supports constraining its
+	// output to a JSON schema.
+	StructuredOutput bool `json:"structured_output,omitempty"`
+	// Reasoning reports whether the model supports reasoning/thinking tokens.
+	Reasoning bool `json:"reasoning,omitempty"`
+
+	// Tokenizer is the name of the tiktoken encoding of the model, such as
+	// "o200k_base". It is empty for models using other tokenizers, whose
+	// tokens are approximated.
+	Tokenizer string `json:"tokenizer,omitempty"`
+}
+
+// ModelRegistry describes known models. It is safe for concurrent use.
+type ModelRegistry struct {
+	mu     sync.RWMutex
+	models map[string]ModelInfo
+}
+
+// NewModelRegistry returns a registry describing models.
+func NewModelRegistry(models ...ModelInfo) *ModelRegistry {
+	r := &ModelRegistry{models: make(map[string]ModelInfo, len(models))}
+	for _, info := range models {
+		r.Register(info)
+	}
+	return r
+}
+
+// Register adds a model to the registry, replacing any model with the same
+// name.
+func (r *ModelRegistry) Register(info ModelInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.models[strings.ToLower(info.Name)] = info
+}
+
+// Lookup returns the description of model. Names are matched without regard
+// to case, first exactly and then by their longest registered prefix. Provider
+// prefixes such as "models/" or "anthropic." are ignored when the full name
+// is not registered.
+func (r *ModelRegistry) Lookup(model string) (ModelInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := lookupNames(strings.ToLower(model))
+	for _, name := range names {
+		if info, ok := r.models[name]; ok {
+			return info, true
+		}
+	}
+	var (
+		best  string
+		found ModelInfo
+	)
+	for _, name := range names {
+		for key, info := range r.models {
+			if len(key) > len(best) && isModelVariant(name, key) {
+				best, found = key, info
+			}
+		}
+	}
+	return found, best != ""
+}
+
+// Models returns the models in the registry sorted by name.
+func (r *ModelRegistry) Models() []ModelInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	models := make([]ModelInfo, 0, len(r.models))
+	for _, info := range r.models {
+		models = append(models, info)
+	}
+	slices.SortFunc(models, func(a, b ModelInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return models
+}
+
+// lookupNames returns the names to look model up with: the name itself, the
+// name without a path such as "models/" or "openai/", and the name without
+// vendor prefixes such as "us.anthropic." used by Bedrock.
+func lookupNames(model string) []string {
+	names := []string{model}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+		names = append(names, model)
+	}
+	for {
+		i := strings.Index(model, ".")
+		if i <= 0 || strings.IndexFunc(model[:i], func(r rune) bool { return r < 'a' || r > 'z' }) >= 0 {
+			break
+		}
+		model = model[i+1:]
+		names = append(names, model)
+	}
+	return names
+}
+
+// isModelVariant reports whether model is a version or variant of name.
+func isModelVariant(model, name string) bool {
+	if !strings.HasPrefix(model, name) || len(model) == len(name) {
+		return false
+	}
+	return strings.ContainsRune("-:@._", rune(model[len(name)]))
+}
+
+// nolint:gochecknoglobals
+var defaultModelRegistry = NewModelRegistry(builtinModels...)
+
+// RegisterModel adds a model to the default registry, replacing any model
+// with the same name. Use it to describe models the registry does not know,
+// or to correct the description of a model.
+func RegisterModel(info ModelInfo) {
+	defaultModelRegistry.Register(info)
+}
+
+// LookupModel returns the description of model from the default registry.
+func LookupModel(model string) (ModelInfo, bool) {
+	return defaultModelRegistry.Lookup(model)
+}
+
+// DefaultModelRegistry returns the default registry used by LookupModel,
+// GetModelContextSize, CountTokens and IsReasoningModel.
+func DefaultModelRegistry() *ModelRegistry {
+	return defaultModelRegistry
+}
@@ -0,0 +1,108 @@
+package llms
+
+const (
+	_o200k  = "o200k_base"
+	_cl100k = "cl100k_base"
+	_p50k   = "p50k_base"
+	_r50k   = "r50k_base"
+)
+
+// builtinModels are the models described by the default registry.
+//
+// nolint:gochecknoglobals,lll
+var builtinModels = []ModelInfo{
+	// OpenAI
+	{Name: "gpt-5", Provider: "openai", ContextSize: 400000, MaxOutputTokens: 128000, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "gpt-5-mini", Provider: "openai", ContextSize: 400000, MaxOutputTokens: 128000, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "gpt-5-nano", Provider: "openai", ContextSize: 400000, MaxOutputTokens: 128000, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "gpt-4.5-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 16384, Tools: true, Vision: true, StructuredOutput: true, Tokenizer: _o200k},
+	{Name: "gpt-4.1", Provider: "openai", ContextSize: 1047576, MaxOutputTokens: 32768, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Tokenizer: _o200k},
+	{Name: "gpt-4.1-mini", Provider: "openai", ContextSize: 1047576, MaxOutputTokens: 32768, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Tokenizer: _o200k},
+	{Name: "gpt-4.1-nano", Provider: "openai", ContextSize: 1047576, MaxOutputTokens: 32768, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Tokenizer: _o200k},
+	{Name: "gpt-4o", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 16384, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Tokenizer: _o200k},
+	{Name: "gpt-4o-mini", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 16384, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Tokenizer: _o200k},
+	{Name: "gpt-4o-audio-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 16384, Tools: true, Audio: true, Tokenizer: _o200k},
+	{Name: "gpt-4o-mini-audio-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 16384, Tools: true, Audio: true, Tokenizer: _o200k},
+	{Name: "gpt-4-turbo", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 4096, Tools: true, Vision: true, Tokenizer: _cl100k},
+	{Name: "gpt-4-1106-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 4096, Tools: true, Tokenizer: _cl100k},
+	{Name: "gpt-4-0125-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 4096, Tools: true, Tokenizer: _cl100k},
+	{Name: "gpt-4-vision-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 4096, Vision: true, Tokenizer: _cl100k},
+	{Name: "gpt-4", Provider: "openai", ContextSize: 8192, MaxOutputTokens: 8192, Tools: true, Tokenizer: _cl100k},
+	{Name: "gpt-4-32k", Provider: "openai", ContextSize: 32768, MaxOutputTokens: 32768, Tools: true, Tokenizer: _cl100k},
+	{Name: "gpt-3.5-turbo", Provider: "openai", ContextSize: 16385, MaxOutputTokens: 4096, Tools: true, Tokenizer: _cl100k},
+	{Name: "gpt-3.5-turbo-instruct", Provider: "openai", ContextSize: 4096, MaxOutputTokens: 4096, Tokenizer: _cl100k},
+	{Name: "o1", Provider: "openai", ContextSize: 200000, MaxOutputTokens: 100000, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "o1-mini", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 65536, Reasoning: true, Tokenizer: _o200k},
+	{Name: "o1-preview", Provider: "openai", ContextSize: 128000, MaxOutputTokens: 32768, Reasoning: true, Tokenizer: _o200k},
+	{Name: "o3", Provider: "openai", ContextSize: 200000, MaxOutputTokens: 100000, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "o3-mini", Provider: "openai", ContextSize: 200000, MaxOutputTokens: 100000, Tools: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "o4-mini", Provider: "openai", ContextSize: 200000, MaxOutputTokens: 100000, Tools: true, Vision: true, PDF: true, StructuredOutput: true, Reasoning: true, Tokenizer: _o200k},
+	{Name: "text-davinci-003", Provider: "openai", ContextSize: 4097, Tokenizer: _p50k},
+	{Name: "text-curie-001", Provider: "openai", ContextSize: 2048, Tokenizer: _r50k},
+	{Name: "text-babbage-001", Provider: "openai", ContextSize: 2048, Tokenizer: _r50k},
+	{Name: "text-ada-001", Provider: "openai", ContextSize: 2048, Tokenizer: _r50k},
+	{Name: "code-davinci-002", Provider: "openai", ContextSize: 8000, Tokenizer: _p50k},
+	{Name: "code-cushman-001", Provider: "openai", ContextSize: 2048, Tokenizer: _p50k},
+
+	// Anthropic
+	{Name: "claude-opus-4", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 32000, Tools: true, Vision: true, PDF: true, Reasoning: true},
+	{Name: "claude-opus-4-1", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 32000, Tools: true, Vision: true, PDF: true, Reasoning: true},
+	{Name: "claude-sonnet-4", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 64000, Tools: true, Vision: true, PDF: true, Reasoning: true},
+	{Name: "claude-3-7-sonnet", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 64000, Tools: true, Vision: true, PDF: true, Reasoning: true},
+	{Name: "claude-3-5-sonnet", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 8192, Tools: true, Vision: true, PDF: true},
+	{Name: "claude-3-5-haiku", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 8192, Tools: true, Vision: true},
+	{Name: "claude-3-opus", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 4096, Tools: true, Vision: true},
+	{Name: "claude-3-sonnet", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 4096, Tools: true, Vision: true},
+	{Name: "claude-3-haiku", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 4096, Tools: true, Vision: true},
+	{Name: "claude-2", Provider: "anthropic", ContextSize: 100000, MaxOutputTokens: 4096},
+	{Name: "claude-2.1", Provider: "anthropic", ContextSize: 200000, MaxOutputTokens: 4096},
+
+	// Google
+	{Name: "gemini-2.5-pro", Provider: "googleai", ContextSize: 1048576, MaxOutputTokens: 65536, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true, Reasoning: true},
+	{Name: "gemini-2.5-flash", Provider: "googleai", ContextSize: 1048576, MaxOutputTokens: 65536, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true, Reasoning: true},
+	{Name: "gemini-2.5-flash-lite", Provider: "googleai", ContextSize: 1048576, MaxOutputTokens: 65536, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true, Reasoning: true},
+	{Name: "gemini-2.0-flash", Provider: "googleai", ContextSize: 1048576, MaxOutputTokens: 8192, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true},
+	{Name: "gemini-2.0-flash-lite", Provider: "googleai", ContextSize: 1048576, MaxOutputTokens: 8192, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true},
+	{Name: "gemini-1.5-pro", Provider: "googleai", ContextSize: 2097152, MaxOutputTokens: 8192, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true},
+	{Name: "gemini-1.5-flash", Provider: "googleai", ContextSize: 1048576, MaxOutputTokens: 8192, Tools: true, Vision: true, Audio: true, PDF: true, StructuredOutput: true},
+	{Name: "gemini-1.0-pro", Provider: "googleai", ContextSize: 32760, MaxOutputTokens: 8192, Tools: true},
+
+	// Mistral
+	{Name: "mistral-large", Provider: "mistral", ContextSize: 131072, Tools: true, StructuredOutput: true},
+	{Name: "mistral-medium", Provider: "mistral", ContextSize: 131072, Tools: true, Vision: true, StructuredOutput: true},
+	{Name: "mistral-small", Provider: "mistral", ContextSize: 131072, Tools: true, Vision: true, StructuredOutput: true},
+	{Name: "pixtral-large", Provider: "mistral", ContextSize: 131072, Tools: true, Vision: true, StructuredOutput: true},
+	{Name: "codestral", Provider: "mistral", ContextSize: 256000, Tools: true},
+	{Name: "open-mistral-nemo", Provider: "mistral", ContextSize: 131072, Tools: true},
+	{Name: "magistral-medium", Provider: "mistral", ContextSize: 40000, Tools: true, Reasoning: true},
+	{Name: "magistral-small", Provider: "mistral", ContextSize: 40000, Tools: true, Reasoning: true},
+
+	// DeepSeek
+	{Name: "deepseek-chat", Provider: "deepseek", ContextSize: 65536, MaxOutputTokens: 8192, Tools: true},
+	{Name: "deepseek-reasoner", Provider: "deepseek", ContextSize: 65536, MaxOutputTokens: 32768, Reasoning: true},
+	{Name: "deepseek-r1", ContextSize: 131072, Reasoning: true},
+
+	// Cohere
+	{Name: "command-r", Provider: "cohere", ContextSize: 128000, MaxOutputTokens: 4096, Tools: true},
+	{Name: "command-r-plus", Provider: "cohere", ContextSize: 128000, MaxOutputTokens: 4096, Tools: true},
+	{Name: "command-a", Provider: "cohere", ContextSize: 256000, MaxOutputTokens: 8192, Tools: true},
+	{Name: "command", Provider: "cohere", ContextSize: 4096, MaxOutputTokens: 4096},
+
+	// Open models, as named by Ollama.
+	{Name: "llama2", ContextSize: 4096},
+	{Name: "llama3", ContextSize: 8192},
+	{Name: "llama3.1", ContextSize: 131072, Tools: true},
+	{Name: "llama3.2", ContextSize: 131072, Tools: true},
+	{Name: "llama3.2-vision", ContextSize: 131072, Vision: true},
+	{Name: "llama3.3", ContextSize: 131072, Tools: true},
+	{Name: "llama4", ContextSize: 1048576, Tools: true, Vision: true},
+	{Name: "mistral", ContextSize: 32768, Tools: true},
+	{Name: "mixtral", ContextSize: 32768, Tools: true},
+	{Name: "gemma2", ContextSize: 8192},
+	{Name: "gemma3", ContextSize: 131072, Vision: true},
+	{Name: "qwen2.5", ContextSize: 32768, Tools: true},
+	{Name: "qwen3", ContextSize: 40960, Tools: true, Reasoning: true},
+	{Name: "qwq", ContextSize: 131072, Tools: true, Reasoning: true},
+	{Name: "phi3", ContextSize: 131072},
+	{Name: "phi4", ContextSize: 16384},
+}
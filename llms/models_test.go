@@ -0,0 +1,105 @@
+package llms_test
+
+import (
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestLookupModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"gpt-4o", "gpt-4o"},
+		{"GPT-4o", "gpt-4o"},
+		{"gpt-4o-2024-08-06", "gpt-4o"},
+		{"gpt-4o-mini-2024-07-18", "gpt-4o-mini"},
+		{"gpt-4.1-mini", "gpt-4.1-mini"},
+		{"o3-2025-04-16", "o3"},
+		{"claude-sonnet-4-20250514", "claude-sonnet-4"},
+		{"claude-3-5-sonnet@20240620", "claude-3-5-sonnet"},
+		{"us.anthropic.claude-3-7-sonnet-20250219-v1:0", "claude-3-7-sonnet"},
+		{"mistral.mistral-large-2402-v1:0", "mistral-large"},
+		{"models/gemini-2.5-flash", "gemini-2.5-flash"},
+		{"llama3.1:70b", "llama3.1"},
+		{"llama3.2-vision:11b", "llama3.2-vision"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			t.Parallel()
+			info, ok := llms.LookupModel(tt.model)
+			if !ok {
+				t.Fatalf("LookupModel(%q) found no model", tt.model)
+			}
+			if info.Name != tt.want {
+				t.Errorf("LookupModel(%q) = %q, want %q", tt.model, info.Name, tt.want)
+			}
+		})
+	}
+
+	for _, model := range []string{"unknown-model", "gpt-4oo", ""} {
+		if info, ok := llms.LookupModel(model); ok {
+			t.Errorf("LookupModel(%q) = %q, want no model", model, info.Name)
+		}
+	}
+}
+
+func TestModelCapabilities(t *testing.T) {
+	t.Parallel()
+
+	if got := llms.GetModelContextSize("claude-sonnet-4-20250514"); got != 200000 {
+		t.Errorf("context size of claude-sonnet-4 = %d, want 200000", got)
+	}
+	if got := llms.GetModelContextSize("gemini-1.5-pro-002"); got != 2097152 {
+		t.Errorf("context size of gemini-1.5-pro = %d, want 2097152", got)
+	}
+	info, _ := llms.LookupModel("gpt-4o")
+	if !info.Tools || !info.Vision || !info.StructuredOutput || info.Reasoning || info.Tokenizer != "o200k_base" {
+		t.Errorf("unexpected gpt-4o capabilities: %+v", info)
+	}
+}
+
+func TestRegisterModel(t *testing.T) {
+	t.Parallel()
+
+	if llms.IsReasoningModel("test-registered-thinker") {
+		t.Fatal("unregistered model is a reasoning model")
+	}
+	llms.RegisterModel(llms.ModelInfo{Name: "test-registered-thinker", ContextSize: 32768, Reasoning: true})
+	if !llms.IsReasoningModel("test-registered-thinker-v2") {
+		t.Error("registered model is not a reasoning model")
+	}
+	if got := llms.GetModelContextSize("test-registered-thinker"); got != 32768 {
+		t.Errorf("context size = %d, want 32768", got)
+	}
+}
+
+func TestModelRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := llms.NewModelRegistry(
+		llms.ModelInfo{Name: "base", ContextSize: 1000},
+		llms.ModelInfo{Name: "ollama/base", ContextSize: 2000},
+	)
+	for model, want := range map[string]int{
+		"base":          1000,
+		"base:7b":       1000,
+		"ollama/base":   2000,
+		"other/base":    1000,
+		"ollama/base:7": 2000,
+	} {
+		info, ok := r.Lookup(model)
+		if !ok || info.ContextSize != want {
+			t.Errorf("Lookup(%q) = %d, %v, want %d", model, info.ContextSize, ok, want)
+		}
+	}
+	if got := len(r.Models()); got != 2 {
+		t.Errorf("len(Models()) = %d, want 2", got)
+	}
+	if _, ok := llms.LookupModel("ollama/base"); ok {
+		t.Error("registry models leak into the default registry")
+	}
+}
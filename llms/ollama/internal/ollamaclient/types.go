@@ -1,6 +1,7 @@
 package ollamaclient
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -53,9 +54,28 @@ type ChatRequest struct {
 	Format    string     `json:"format"`
 	KeepAlive string     `json:"keep_alive,omitempty"`
 
+	// FormatSchema is a JSON schema the response must match, sent as the
+	// format instead of Format.
+	FormatSchema json.RawMessage `json:"-"`
+
 	Options Options `json:"options"`
 }
 
+// MarshalJSON encodes FormatSchema as the format when it is set.
+func (r ChatRequest) MarshalJSON() ([]byte, error) {
+	type alias ChatRequest
+	if r.FormatSchema == nil {
+		return json.Marshal(alias(r))
+	}
+	return json.Marshal(struct {
+		alias
+		Format json.RawMessage `json:"format"`
+	}{
+		alias:  alias(r),
+		Format: r.FormatSchema,
+	})
+}
+
 type Metrics struct {
 	TotalDuration      time.Duration `json:"total_duration,omitempty"`
 	LoadDuration       time.Duration `json:"load_duration,omitempty"`
@@ -95,7 +115,8 @@ type ChatResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 	Message   *Message  `json:"message,omitempty"`
 
-	Done bool `json:"done"`
+	Done       bool   `json:"done"`
+	DoneReason string `json:"done_reason,omitempty"`
 
 	Metrics
 }
@@ -2,8 +2,10 @@ package ollama
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/tmc/langchaingo/callbacks"
@@ -28,6 +30,7 @@ type LLM struct {
 var (
 	_ llms.Model          = (*LLM)(nil)
 	_ llms.ReasoningModel = (*LLM)(nil)
+	_ llms.StreamingModel = (*LLM)(nil)
 )
 
 // New creates a new ollama LLM implementation.
@@ -71,6 +74,11 @@ func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 	return llms.GenerateFromSinglePrompt(ctx, o, prompt, options...)
 }
 
+// StreamContent implements the StreamingModel interface.
+func (o *LLM) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return llms.StreamEvents(ctx, o, messages, options...)
+}
+
 // GenerateContent implements the Model interface.
 func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { // nolint: lll, cyclop, funlen
 	if o.CallbacksHandler != nil {
@@ -142,6 +150,14 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	if opts.JSONMode {
 		format = "json"
 	}
+	var formatSchema json.RawMessage
+	if opts.ResponseSchema != nil {
+		schema, err := json.Marshal(opts.ResponseSchema.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("ollama: marshal response schema: %w", err)
+		}
+		formatSchema = schema
+	}
 
 	// Get our ollamaOptions from llms.CallOptions
 	ollamaOptions := makeOllamaOptionsFromOptions(o.options.ollamaOptions, opts)
@@ -156,11 +172,12 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		}
 	}
 	req := &ollamaclient.ChatRequest{
-		Model:    model,
-		Format:   format,
-		Messages: chatMsgs,
-		Options:  ollamaOptions,
-		Stream:   opts.StreamingFunc != nil,
+		Model:        model,
+		Format:       format,
+		FormatSchema: formatSchema,
+		Messages:     chatMsgs,
+		Options:      ollamaOptions,
+		Stream:       opts.IsStreaming(),
 	}
 
 	keepAlive := o.options.keepAlive
@@ -173,8 +190,18 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	var resp ollamaclient.ChatResponse
 
 	fn = func(response ollamaclient.ChatResponse) error {
-		if opts.StreamingFunc != nil && response.Message != nil {
-			if err := opts.StreamingFunc(ctx, []byte(response.Message.Content)); err != nil {
+		if req.Stream && response.Message != nil {
+			event := llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: response.Message.Content}
+			if err := opts.EmitStreamEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+		if req.Stream && response.Done {
+			event := llms.StreamEvent{
+				Type:  llms.StreamEventUsage,
+				Usage: llms.NewUsage(response.PromptEvalCount, response.EvalCount),
+			}
+			if err := opts.EmitStreamEvent(ctx, event); err != nil {
 				return err
 			}
 		}
@@ -238,11 +265,14 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	choices := []*llms.ContentChoice{
 		{
 			Content:        content,
+			StopReason:     resp.DoneReason,
+			FinishReason:   llms.NormalizeStopReason(resp.DoneReason),
+			Usage:          llms.NewUsage(resp.PromptEvalCount, resp.EvalCount),
 			GenerationInfo: genInfo,
 		},
 	}
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Model: resp.Model}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/tmc/langchaingo/httputil"
 	"github.com/tmc/langchaingo/llms"
 )
 
@@ -68,13 +69,13 @@ type ChatRequest struct {
 	// Valid values: "minimal" (GPT-5 only), "low", "medium", "high"
 	ReasoningEffort string `json:"reasoning_effort,omitempty"`
 
-	// StreamingFunc is a function to be called for each chunk of a streaming response.
-	// Return an error to stop streaming early.
-	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	// StreamingEventFunc is a function to be called for each typed event of a streaming response.
+	// The response is streamed when it is set. Return an error to stop streaming early.
+	StreamingEventFunc func(ctx context.Context, event llms.StreamEvent) error `json:"-"`
 
-	// StreamingReasoningFunc is a function to be called for each reasoning and content chunk of a streaming response.
-	// Return an error to stop streaming early.
-	StreamingReasoningFunc func(ctx context.Context, reasoningChunk, chunk []byte) error `json:"-"`
+	// FunctionCallStreamingFunc is a function to be called with the JSON of the function and tool
+	// call deltas of a streaming response, as StreamingFunc was before stream events.
+	FunctionCallStreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 
 	// Deprecated: use Tools instead.
 	Functions []FunctionDefinition `json:"functions,omitempty"`
@@ -225,9 +226,28 @@ type ResponseFormatJSONSchemaProperty struct {
 }
 
 type ResponseFormatJSONSchema struct {
-	Name   string                            `json:"name"`
-	Strict bool                              `json:"strict"`
-	Schema *ResponseFormatJSONSchemaProperty `json:"schema"`
+	Name        string                            `json:"name"`
+	Description string                            `json:"description,omitempty"`
+	Strict      bool                              `json:"strict"`
+	Schema      *ResponseFormatJSONSchemaProperty `json:"schema"`
+	// RawSchema is a JSON schema used instead of Schema, for schemas that
+	// ResponseFormatJSONSchemaProperty cannot describe.
+	RawSchema json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes RawSchema as the schema when it is set.
+func (s ResponseFormatJSONSchema) MarshalJSON() ([]byte, error) {
+	type alias ResponseFormatJSONSchema
+	if s.RawSchema == nil {
+		return json.Marshal(alias(s))
+	}
+	return json.Marshal(struct {
+		alias
+		Schema json.RawMessage `json:"schema"`
+	}{
+		alias:  alias(s),
+		Schema: s.RawSchema,
+	})
 }
 
 // ResponseFormat is the format of the response.
@@ -498,8 +518,13 @@ type FunctionCall struct {
 	Arguments string `json:"arguments"`
 }
 
+// isStreaming reports whether the response to the request is streamed.
+func (r *ChatRequest) isStreaming() bool {
+	return r.StreamingEventFunc != nil
+}
+
 func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCompletionResponse, error) {
-	if payload.StreamingFunc != nil || payload.StreamingReasoningFunc != nil {
+	if payload.isStreaming() {
 		payload.Stream = true
 		if payload.StreamOptions == nil {
 			payload.StreamOptions = &StreamOptions{IncludeUsage: true}
@@ -555,12 +580,12 @@ func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCom
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg)
+			return nil, httputil.NewResponseError(r, errors.New(msg))
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+		return nil, httputil.NewResponseError(r, fmt.Errorf("%s: %s", msg, errResp.Error.Message))
 	}
-	if payload.StreamingFunc != nil || payload.StreamingReasoningFunc != nil {
+	if payload.isStreaming() {
 		return parseStreamingChatResponse(ctx, r, payload)
 	}
 	// Parse response
@@ -650,10 +675,45 @@ func combineStreamingChatResponse(
 		},
 	}
 
+	emit := func(event llms.StreamEvent) error {
+		if payload.StreamingEventFunc == nil {
+			return nil
+		}
+		if err := payload.StreamingEventFunc(ctx, event); err != nil {
+			return fmt.Errorf("streaming event func returned an error: %w", err)
+		}
+		return nil
+	}
+	// endToolCalls reports the completed tool calls once, when the model
+	// finishes or the stream ends.
+	toolCallsEnded := false
+	endToolCalls := func() error {
+		if toolCallsEnded {
+			return nil
+		}
+		toolCallsEnded = true
+		for i, tc := range response.Choices[0].Message.ToolCalls {
+			if err := emit(llms.StreamEvent{Type: llms.StreamEventToolCallEnd, Index: i, ToolCall: &llms.ToolCall{
+				ID:   tc.ID,
+				Type: string(tc.Type),
+				FunctionCall: &llms.FunctionCall{
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
+				},
+			}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	for streamResponse := range responseChan {
 		if streamResponse.Error != nil {
 			return nil, streamResponse.Error
 		}
+		if streamResponse.Model != "" {
+			response.Model = streamResponse.Model
+		}
 
 		if streamResponse.Usage != nil {
 			response.Usage.CompletionTokens = streamResponse.Usage.CompletionTokens
@@ -665,43 +725,111 @@ func combineStreamingChatResponse(
 			response.Usage.CompletionTokensDetails.AcceptedPredictionTokens = streamResponse.Usage.CompletionTokensDetails.AcceptedPredictionTokens
 			response.Usage.CompletionTokensDetails.RejectedPredictionTokens = streamResponse.Usage.CompletionTokensDetails.RejectedPredictionTokens
 			response.Usage.CompletionTokensDetails.ReasoningTokens = streamResponse.Usage.CompletionTokensDetails.ReasoningTokens
+			if err := emit(llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &llms.Usage{
+				InputTokens:     streamResponse.Usage.PromptTokens,
+				OutputTokens:    streamResponse.Usage.CompletionTokens,
+				TotalTokens:     streamResponse.Usage.TotalTokens,
+				CacheReadTokens: streamResponse.Usage.PromptTokensDetails.CachedTokens,
+				ReasoningTokens: streamResponse.Usage.CompletionTokensDetails.ReasoningTokens,
+			}}); err != nil {
+				return nil, err
+			}
 		}
 
 		if len(streamResponse.Choices) == 0 {
 			continue
 		}
 		choice := streamResponse.Choices[0]
-		chunk := []byte(choice.Delta.Content)
-		reasoningChunk := []byte(choice.Delta.ReasoningContent) // TODO: not sure if there will be any reasoning related to function call later, so just pass it here
 		response.Choices[0].Message.Content += choice.Delta.Content
 		response.Choices[0].FinishReason = choice.FinishReason
 		response.Choices[0].Message.ReasoningContent += choice.Delta.ReasoningContent
 
+		var callChunk []byte
 		if choice.Delta.FunctionCall != nil {
-			chunk = updateFunctionCall(response.Choices[0].Message, choice.Delta.FunctionCall)
+			callChunk = updateFunctionCall(response.Choices[0].Message, choice.Delta.FunctionCall)
 		}
 
+		var toolEvents []llms.StreamEvent
 		if len(choice.Delta.ToolCalls) > 0 {
-			chunk, response.Choices[0].Message.ToolCalls = updateToolCalls(response.Choices[0].Message.ToolCalls,
+			toolEvents = toolCallDeltaEvents(response.Choices[0].Message.ToolCalls, choice.Delta.ToolCalls)
+			callChunk, response.Choices[0].Message.ToolCalls = updateToolCalls(response.Choices[0].Message.ToolCalls,
 				choice.Delta.ToolCalls)
 		}
 
-		if payload.StreamingFunc != nil {
-			err := payload.StreamingFunc(ctx, chunk)
-			if err != nil {
+		if choice.Delta.ReasoningContent != "" {
+			if err := emit(llms.StreamEvent{Type: llms.StreamEventReasoningDelta, Delta: choice.Delta.ReasoningContent}); err != nil {
+				return nil, err
+			}
+		}
+		if choice.Delta.Content != "" {
+			if err := emit(llms.StreamEvent{Type: llms.StreamEventTextDelta, Delta: choice.Delta.Content}); err != nil {
+				return nil, err
+			}
+		}
+		for _, event := range toolEvents {
+			if err := emit(event); err != nil {
+				return nil, err
+			}
+		}
+		if len(callChunk) > 0 && payload.FunctionCallStreamingFunc != nil {
+			if err := payload.FunctionCallStreamingFunc(ctx, callChunk); err != nil {
 				return nil, fmt.Errorf("streaming func returned an error: %w", err)
 			}
 		}
-		if payload.StreamingReasoningFunc != nil {
-			err := payload.StreamingReasoningFunc(ctx, reasoningChunk, chunk)
-			if err != nil {
-				return nil, fmt.Errorf("streaming reasoning func returned an error: %w", err)
+		if choice.FinishReason != "" {
+			if err := endToolCalls(); err != nil {
+				return nil, err
+			}
+			if err := emit(llms.StreamEvent{Type: llms.StreamEventStop, StopReason: string(choice.FinishReason)}); err != nil {
+				return nil, err
 			}
 		}
 	}
+	if err := endToolCalls(); err != nil {
+		return nil, err
+	}
 	return &response, nil
 }
 
+// toolCallDeltaEvents returns the stream events for a tool call delta, using
+// the same rules as updateToolCalls to tell new calls from argument fragments.
+func toolCallDeltaEvents(tools []ToolCall, delta []*ToolCall) []llms.StreamEvent {
+	var events []llms.StreamEvent
+	n := len(tools)
+	for _, t := range delta {
+		if t.Type == `` && t.Function.Arguments != `` {
+			if n == 0 {
+				continue
+			}
+			events = append(events, llms.StreamEvent{
+				Type:  llms.StreamEventToolCallDelta,
+				Index: n - 1,
+				Delta: t.Function.Arguments,
+			})
+			continue
+		}
+
+		events = append(events, llms.StreamEvent{
+			Type:  llms.StreamEventToolCallStart,
+			Index: n,
+			ToolCall: &llms.ToolCall{
+				ID:           t.ID,
+				Type:         string(t.Type),
+				FunctionCall: &llms.FunctionCall{Name: t.Function.Name},
+			},
+		})
+		if t.Function.Arguments != `` {
+			events = append(events, llms.StreamEvent{
+				Type:  llms.StreamEventToolCallDelta,
+				Index: n,
+				Delta: t.Function.Arguments,
+			})
+		}
+		n++
+	}
+	return events
+}
+
 func updateFunctionCall(message ChatMessage, functionCall *FunctionCall) []byte {
 	if message.FunctionCall == nil {
 		message.FunctionCall = functionCall
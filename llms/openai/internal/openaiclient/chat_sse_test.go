@@ -5,7 +5,11 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 func TestParseStreamingChatResponse_SSEComments(t *testing.T) {
@@ -58,7 +62,7 @@ data: [DONE]`,
 			}
 
 			req := &ChatRequest{
-				StreamingFunc: func(_ context.Context, _ []byte) error {
+				StreamingEventFunc: func(_ context.Context, _ llms.StreamEvent) error {
 					return nil
 				},
 			}
@@ -79,3 +83,63 @@ data: [DONE]`,
 		})
 	}
 }
+
+func TestParseStreamingChatResponse_StreamingEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	body := `data: {"id":"1","choices":[{"index":0,"delta":{"role":"assistant","content":"Let me check."},"finish_reason":null}]}
+data: {"id":"1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]},"finish_reason":null}]}
+data: {"id":"1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"location\":"}}]},"finish_reason":null}]}
+data: {"id":"1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]},"finish_reason":null}]}
+data: {"id":"1","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}
+data: {"id":"1","choices":[],"usage":{"prompt_tokens":12,"completion_tokens":7,"total_tokens":19}}
+data: [DONE]`
+
+	r := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	var events []llms.StreamEvent
+	var callChunks []string
+	req := &ChatRequest{
+		StreamingEventFunc: func(_ context.Context, event llms.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		},
+		FunctionCallStreamingFunc: func(_ context.Context, chunk []byte) error {
+			callChunks = append(callChunks, string(chunk))
+			return nil
+		},
+	}
+
+	resp, err := parseStreamingChatResponse(ctx, r, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Choices[0].Message.ToolCalls[0].Function.Arguments; got != `{"location":"Paris"}` {
+		t.Errorf("arguments mismatch: got %q", got)
+	}
+
+	want := []llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Delta: "Let me check."},
+		{Type: llms.StreamEventToolCallStart, ToolCall: &llms.ToolCall{
+			ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "get_weather"},
+		}},
+		{Type: llms.StreamEventToolCallDelta, Delta: `{"location":`},
+		{Type: llms.StreamEventToolCallDelta, Delta: `"Paris"}`},
+		{Type: llms.StreamEventToolCallEnd, ToolCall: &llms.ToolCall{
+			ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "get_weather", Arguments: `{"location":"Paris"}`},
+		}},
+		{Type: llms.StreamEventStop, StopReason: "tool_calls"},
+		{Type: llms.StreamEventUsage, Usage: &llms.Usage{InputTokens: 12, OutputTokens: 7, TotalTokens: 19}},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events mismatch:\ngot:  %+v\nwant: %+v", events, want)
+	}
+	// The tool call deltas are also passed as JSON, as to StreamingFunc before stream events.
+	if len(callChunks) != 3 || !strings.Contains(callChunks[0], `"name":"get_weather"`) {
+		t.Errorf("unexpected tool call chunks: %q", callChunks)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 )
 
 func TestParseStreamingChatResponse_FinishReason(t *testing.T) {
@@ -22,7 +23,7 @@ func TestParseStreamingChatResponse_FinishReason(t *testing.T) {
 	}
 
 	req := &ChatRequest{
-		StreamingFunc: func(_ context.Context, _ []byte) error {
+		StreamingEventFunc: func(_ context.Context, _ llms.StreamEvent) error {
 			return nil
 		},
 	}
@@ -44,7 +45,7 @@ func TestParseStreamingChatResponse_ReasoningContent(t *testing.T) {
 	}
 
 	req := &ChatRequest{
-		StreamingFunc: func(_ context.Context, _ []byte) error {
+		StreamingEventFunc: func(_ context.Context, _ llms.StreamEvent) error {
 			return nil
 		},
 	}
@@ -69,18 +70,20 @@ data: {"id":"fa7e4fc5-a05d-4e7b-9a66-a2dd89e91a4e","object":"chat.completion.chu
 		Body:       io.NopCloser(bytes.NewBufferString(mockBody)),
 	}
 
-	req := &ChatRequest{
-		StreamingReasoningFunc: func(_ context.Context, reasoningChunk, chunk []byte) error {
-			t.Logf("reasoningChunk: %s", string(reasoningChunk))
-			t.Logf("chunk: %s", string(chunk))
+	var reasoning string
+	opts := &llms.CallOptions{
+		StreamingReasoningFunc: func(_ context.Context, reasoningChunk, _ []byte) error {
+			reasoning += string(reasoningChunk)
 			return nil
 		},
 	}
+	req := &ChatRequest{StreamingEventFunc: opts.StreamEventHandler()}
 
 	resp, err := parseStreamingChatResponse(ctx, r, req)
 
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
+	assert.Equal(t, "Okay", reasoning)
 	assert.Equal(t, "", resp.Choices[0].Message.Content)
 	assert.Equal(t, "Okay", resp.Choices[0].Message.ReasoningContent)
 	assert.Equal(t, FinishReason(""), resp.Choices[0].FinishReason)
@@ -2,6 +2,8 @@ package openaiclient
 
 import (
 	"context"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 // CompletionRequest is a request to complete a completion.
@@ -87,7 +89,7 @@ func (c *Client) createCompletion(ctx context.Context, payload *CompletionReques
 		StopWords:           payload.StopWords,
 		FrequencyPenalty:    payload.FrequencyPenalty,
 		PresencePenalty:     payload.PresencePenalty,
-		StreamingFunc:       payload.StreamingFunc,
+		StreamingEventFunc:  (&llms.CallOptions{StreamingFunc: payload.StreamingFunc}).StreamEventHandler(),
 		Seed:                payload.Seed,
 	})
 }
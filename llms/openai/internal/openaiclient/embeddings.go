@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/tmc/langchaingo/httputil"
 )
 
 const (
@@ -63,10 +65,10 @@ func (c *Client) createEmbedding(ctx context.Context, payload *embeddingPayload)
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg)
+			return nil, httputil.NewResponseError(r, errors.New(msg))
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+		return nil, httputil.NewResponseError(r, fmt.Errorf("%s: %s", msg, errResp.Error.Message))
 	}
 
 	var response embeddingResponsePayload
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/internal/httprr"
+	"github.com/tmc/langchaingo/llms"
 )
 
 // setupTestClient creates a test client with httprr recording/replay
@@ -100,8 +101,8 @@ func TestClient_CreateChatCompletionStream(t *testing.T) {
 		Temperature:         0.0,
 		MaxCompletionTokens: 50,
 		Stream:              true,
-		StreamingFunc: func(ctx context.Context, chunk []byte) error {
-			chunks = append(chunks, string(chunk))
+		StreamingEventFunc: func(ctx context.Context, event llms.StreamEvent) error {
+			chunks = append(chunks, event.Delta)
 			return nil
 		},
 	}
@@ -2,7 +2,9 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"regexp"
 	"strings"
 
@@ -80,6 +82,7 @@ func getModelCapabilities(model string) ModelCapability {
 var (
 	_ llms.Model          = (*LLM)(nil)
 	_ llms.ReasoningModel = (*LLM)(nil)
+	_ llms.StreamingModel = (*LLM)(nil)
 )
 
 // New returns a new OpenAI LLM.
@@ -262,16 +265,15 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	}
 
 	req := &openaiclient.ChatRequest{
-		Model:                  opts.Model,
-		StopWords:              opts.StopWords,
-		Messages:               chatMsgs,
-		StreamingFunc:          opts.StreamingFunc,
-		StreamingReasoningFunc: opts.StreamingReasoningFunc,
-		Temperature:            opts.Temperature,
-		N:                      opts.N,
-		FrequencyPenalty:       opts.FrequencyPenalty,
-		PresencePenalty:        opts.PresencePenalty,
-		ReasoningEffort:        reasoningEffort,
+		Model:              opts.Model,
+		StopWords:          opts.StopWords,
+		Messages:           chatMsgs,
+		StreamingEventFunc: opts.StreamEventHandler(),
+		Temperature:        opts.Temperature,
+		N:                  opts.N,
+		FrequencyPenalty:   opts.FrequencyPenalty,
+		PresencePenalty:    opts.PresencePenalty,
+		ReasoningEffort:    reasoningEffort,
 
 		// Token handling: check metadata flag for legacy behavior
 		// By default use max_completion_tokens (modern field)
@@ -298,6 +300,8 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	if opts.JSONMode {
 		req.ResponseFormat = ResponseFormatJSON
 	}
+	// The streaming functions receive the JSON of streamed tool calls too.
+	req.FunctionCallStreamingFunc = functionCallStreamingFunc(&opts)
 
 	// since req.Functions is deprecated, we need to use the new Tools API.
 	for _, fn := range opts.Functions {
@@ -324,6 +328,13 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	if o.client.ResponseFormat != nil {
 		req.ResponseFormat = o.client.ResponseFormat
 	}
+	if opts.ResponseSchema != nil {
+		format, err := responseFormatFromSchema(opts.ResponseSchema)
+		if err != nil {
+			return nil, err
+		}
+		req.ResponseFormat = format
+	}
 
 	result, err := o.client.CreateChat(ctx, req)
 	if err != nil {
@@ -339,6 +350,14 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 			Content:          c.Message.Content,
 			ReasoningContent: c.Message.ReasoningContent,
 			StopReason:       fmt.Sprint(c.FinishReason),
+			FinishReason:     llms.NormalizeStopReason(string(c.FinishReason)),
+			Usage: &llms.Usage{
+				InputTokens:     result.Usage.PromptTokens,
+				OutputTokens:    result.Usage.CompletionTokens,
+				TotalTokens:     result.Usage.TotalTokens,
+				CacheReadTokens: result.Usage.PromptTokensDetails.CachedTokens,
+				ReasoningTokens: result.Usage.CompletionTokensDetails.ReasoningTokens,
+			},
 			GenerationInfo: map[string]any{
 				"CompletionTokens":  result.Usage.CompletionTokens,
 				"PromptTokens":      result.Usage.PromptTokens,
@@ -378,13 +397,18 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 			choices[i].FuncCall = choices[i].ToolCalls[0].FunctionCall
 		}
 	}
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Model: result.Model}
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
 	}
 	return response, nil
 }
 
+// StreamContent implements the StreamingModel interface.
+func (o *LLM) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return llms.StreamEvents(ctx, o, messages, options...)
+}
+
 // SupportsReasoning implements the ReasoningModel interface.
 // Returns true if the current model supports reasoning/thinking tokens.
 func (o *LLM) SupportsReasoning() bool {
@@ -500,6 +524,24 @@ func toolCallFromToolCall(tc llms.ToolCall) openaiclient.ToolCall {
 	}
 }
 
+// responseFormatFromSchema converts a llms.ResponseSchema to a json_schema
+// response format.
+func responseFormatFromSchema(schema *llms.ResponseSchema) (*ResponseFormat, error) {
+	raw, err := json.Marshal(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("openai: marshal response schema: %w", err)
+	}
+	return &ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &ResponseFormatJSONSchema{
+			Name:        schema.Name,
+			Description: schema.Description,
+			Strict:      schema.Strict,
+			RawSchema:   raw,
+		},
+	}, nil
+}
+
 // webSearchOptionsFromCallOptions converts llms.WebSearchOptions to openaiclient.WebSearchOptions.
 func webSearchOptionsFromCallOptions(opts *llms.WebSearchOptions) *openaiclient.WebSearchOptions {
 	if opts == nil {
@@ -522,3 +564,23 @@ func webSearchOptionsFromCallOptions(opts *llms.WebSearchOptions) *openaiclient.
 	}
 	return result
 }
+
+// functionCallStreamingFunc returns a function passing the JSON of the streamed
+// function and tool calls to the streaming functions of opts as content
+// chunks, or nil if they are not set.
+func functionCallStreamingFunc(opts *llms.CallOptions) func(context.Context, []byte) error {
+	if opts.StreamingFunc == nil && opts.StreamingReasoningFunc == nil {
+		return nil
+	}
+	return func(ctx context.Context, chunk []byte) error {
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, chunk); err != nil {
+				return err
+			}
+		}
+		if opts.StreamingReasoningFunc != nil {
+			return opts.StreamingReasoningFunc(ctx, nil, chunk)
+		}
+		return nil
+	}
+}
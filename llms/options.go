@@ -1,6 +1,9 @@
 package llms
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // CallOption is a function that configures a CallOptions.
 type CallOption func(*CallOptions)
@@ -18,12 +21,17 @@ type CallOptions struct {
 	Temperature float64 `json:"temperature"`
 	// StopWords is a list of words to stop on.
 	StopWords []string `json:"stop_words"`
-	// StreamingFunc is a function to be called for each chunk of a streaming response.
-	// Return an error to stop streaming early.
+	// StreamingFunc is a function to be called for each chunk of text of a streaming response.
+	// The OpenAI provider also passes the JSON of the streamed function and tool calls; use
+	// StreamingEventFunc for the tool calls of the other providers. Return an error to stop
+	// streaming early.
 	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 	// StreamingReasoningFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
 	StreamingReasoningFunc func(ctx context.Context, reasoningChunk, chunk []byte) error `json:"-"`
+	// StreamingEventFunc is a function to be called for each typed event of a streaming response.
+	// Return an error to stop streaming early.
+	StreamingEventFunc func(ctx context.Context, event StreamEvent) error `json:"-"`
 	// TopK is the number of tokens to consider for top-k sampling.
 	TopK int `json:"top_k"`
 	// TopP is the cumulative probability for top-p sampling.
@@ -70,6 +78,20 @@ type CallOptions struct {
 	// application/json: JSON response in the response candidates.
 	ResponseMIMEType string `json:"response_mime_type,omitempty"`
 
+	// ResponseSchema constrains the response to JSON matching a schema, using
+	// the structured output support of the provider.
+	// Provider support varies - check your provider's documentation.
+	ResponseSchema *ResponseSchema `json:"response_schema,omitempty"`
+
+	// StructuredOutputRetries is the number of times
+	// outputparser.GenerateStructured asks the model to fix a response that
+	// does not match the schema.
+	StructuredOutputRetries *int `json:"structured_output_retries,omitempty"`
+
+	// MaxToolTurns is the maximum number of times RunTools executes the tool
+	// calls of the model.
+	MaxToolTurns *int `json:"max_tool_turns,omitempty"`
+
 	// WebSearchOptions configures web search behavior for models that support it.
 	// Currently supported by OpenAI models like gpt-4o-search-preview.
 	WebSearchOptions *WebSearchOptions `json:"web_search_options,omitempty"`
@@ -113,6 +135,35 @@ type FunctionReference struct {
 // FunctionCallBehavior is the behavior to use when calling functions.
 type FunctionCallBehavior string
 
+// ResponseSchema describes the JSON a model must respond with.
+type ResponseSchema struct {
+	// Name identifies the schema, such as "weather_report".
+	Name string `json:"name"`
+	// Description tells the model what the response is for.
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema of the response. It can be any value that
+	// marshals to a JSON schema, such as a map[string]any or a json.RawMessage.
+	Schema any `json:"schema"`
+	// Strict asks providers that support it to enforce the schema exactly.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// SchemaMap returns the schema as a map, as decoded from its JSON encoding.
+func (s *ResponseSchema) SchemaMap() (map[string]any, error) {
+	if m, ok := s.Schema.(map[string]any); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(s.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // WebSearchOptions configures web search behavior for models that support web search.
 // This is currently supported by OpenAI models like gpt-4o-search-preview.
 type WebSearchOptions struct {
@@ -210,6 +261,14 @@ func WithStreamingReasoningFunc(streamingReasoningFunc func(ctx context.Context,
 	}
 }
 
+// WithStreamingEventFunc specifies the streaming function to call with typed
+// events. Most callers should use StreamContent instead.
+func WithStreamingEventFunc(streamingEventFunc func(ctx context.Context, event StreamEvent) error) CallOption {
+	return func(o *CallOptions) {
+		o.StreamingEventFunc = streamingEventFunc
+	}
+}
+
 // WithTopK will add an option to use top-k sampling.
 func WithTopK(topK int) CallOption {
 	return func(o *CallOptions) {
@@ -329,6 +388,33 @@ func WithResponseMIMEType(responseMIMEType string) CallOption {
 	}
 }
 
+// WithResponseSchema will add an option to constrain the response to JSON
+// matching a schema. Provider support varies - check your provider's
+// documentation, or use outputparser.GenerateStructured, which also works
+// with models without structured output support.
+func WithResponseSchema(schema *ResponseSchema) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseSchema = schema
+	}
+}
+
+// WithStructuredOutputRetries sets the number of times
+// outputparser.GenerateStructured asks the model to fix a response that does
+// not match the schema. The default is 2.
+func WithStructuredOutputRetries(retries int) CallOption {
+	return func(o *CallOptions) {
+		o.StructuredOutputRetries = &retries
+	}
+}
+
+// WithMaxToolTurns sets the maximum number of times RunTools executes the
+// tool calls of the model. The default is 10.
+func WithMaxToolTurns(turns int) CallOption {
+	return func(o *CallOptions) {
+		o.MaxToolTurns = &turns
+	}
+}
+
 // WithWebSearch enables web search for models that support it.
 // Use with OpenAI models like gpt-4o-search-preview and gpt-4o-mini-search-preview.
 // Pass nil for default web search behavior, or provide WebSearchOptions to customize.
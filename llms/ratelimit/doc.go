@@ -0,0 +1,19 @@
+// Package ratelimit provides client-side rate limiting for models and
+// embedders, enforcing requests-per-minute and tokens-per-minute budgets
+// before requests reach the provider.
+//
+// A Limiter holds the budgets. Share one Limiter between every model and
+// embedder that uses the same API key, across goroutines and chains:
+//
+//	limiter := ratelimit.Shared(apiKey, ratelimit.Limits{
+//		RequestsPerMinute: 500,
+//		TokensPerMinute:   200000,
+//	})
+//	llm = ratelimit.NewModel(llm, limiter, ratelimit.WithModelName("gpt-4o"))
+//	embedder, err := embeddings.NewEmbedder(ratelimit.NewEmbedderClient(client, limiter))
+//
+// Before each request, its tokens are estimated with llms.CountTokens,
+// counting the prompt and the requested maximum output tokens. Once a model
+// responds, the estimate is replaced with the usage the provider reported.
+// Callers over budget wait in the order they arrived rather than failing.
+package ratelimit
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+// EmbedderClient is an embeddings.EmbedderClient wrapper that waits for
+// capacity on a Limiter before each request. Each call to CreateEmbedding
+// counts as one request, so it works with the batches of
+// embeddings.BatchedEmbed.
+type EmbedderClient struct {
+	client  embeddings.EmbedderClient
+	limiter *Limiter
+	opts    options
+}
+
+var _ embeddings.EmbedderClient = (*EmbedderClient)(nil)
+
+// NewEmbedderClient wraps client so that its requests are limited by limiter.
+func NewEmbedderClient(client embeddings.EmbedderClient, limiter *Limiter, opts ...Option) *EmbedderClient {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &EmbedderClient{
+		client:  client,
+		limiter: limiter,
+		opts:    o,
+	}
+}
+
+// CreateEmbedding waits for capacity for the texts and calls the wrapped
+// client. The capacity is returned if the request fails without reaching the
+// provider.
+func (e *EmbedderClient) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	var tokens int
+	if e.limiter.Limits().TokensPerMinute > 0 {
+		for _, text := range texts {
+			tokens += e.opts.tokenCounter(e.opts.model, text)
+		}
+	}
+	reservation, err := e.limiter.Wait(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+	embeddings, err := e.client.CreateEmbedding(ctx, texts)
+	if err != nil && !sent(err) {
+		reservation.Cancel()
+	}
+	return embeddings, err
+}
@@ -0,0 +1,236 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limits are the budgets enforced by a Limiter. Zero values are unlimited.
+type Limits struct {
+	// RequestsPerMinute is the maximum number of requests per minute.
+	RequestsPerMinute int
+	// TokensPerMinute is the maximum number of tokens per minute.
+	TokensPerMinute int
+}
+
+// Limiter enforces request and token budgets shared by all the callers using
+// it. Each budget is a token bucket holding up to a minute's worth of capacity
+// that refills continuously. Callers that exceed the budget wait in FIFO
+// order until capacity is available instead of failing.
+//
+// A Limiter is safe for concurrent use. Share a single Limiter between all
+// models and embedders that use the same API key.
+type Limiter struct {
+	limits Limits
+
+	mu       sync.Mutex
+	requests float64
+	tokens   float64
+	last     time.Time
+	queue    []chan struct{}
+}
+
+// NewLimiter returns a Limiter enforcing limits. The budgets start full.
+func NewLimiter(limits Limits) *Limiter {
+	return &Limiter{
+		limits:   limits,
+		requests: float64(limits.RequestsPerMinute),
+		tokens:   float64(limits.TokensPerMinute),
+		last:     time.Now(),
+	}
+}
+
+// Limits returns the budgets enforced by the limiter.
+func (l *Limiter) Limits() Limits {
+	return l.limits
+}
+
+// Reservation is capacity taken from a Limiter for a single request.
+type Reservation struct {
+	l      *Limiter
+	tokens int
+}
+
+// Tokens returns the number of tokens currently reserved.
+func (r *Reservation) Tokens() int {
+	return r.tokens
+}
+
+// Reconcile replaces the estimated token count of the reservation with the
+// number of tokens the request actually used. Unused tokens are returned to
+// the limiter; extra tokens are taken from its budget, delaying later
+// callers if necessary.
+func (r *Reservation) Reconcile(actualTokens int) {
+	if r == nil || r.l == nil || r.l.limits.TokensPerMinute <= 0 {
+		return
+	}
+	l := r.l
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.tokens += float64(r.tokens - actualTokens)
+	r.tokens = actualTokens
+	l.wakeHead()
+}
+
+// Cancel returns the capacity of the reservation to the limiter, for
+// requests that were not sent. The reservation can't be used afterwards.
+func (r *Reservation) Cancel() {
+	if r == nil || r.l == nil {
+		return
+	}
+	l := r.l
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if rpm := float64(l.limits.RequestsPerMinute); rpm > 0 {
+		l.requests = math.Min(rpm, l.requests+1)
+	}
+	if tpm := float64(l.limits.TokensPerMinute); tpm > 0 {
+		l.tokens = math.Min(tpm, l.tokens+float64(r.tokens))
+	}
+	r.l, r.tokens = nil, 0
+	l.wakeHead()
+}
+
+// Wait blocks until the limiter has capacity for one request using tokens
+// tokens, or ctx is done. Requests larger than the token budget wait for a
+// full budget. Callers are served in the order they called Wait.
+func (l *Limiter) Wait(ctx context.Context, tokens int) (*Reservation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	wake := make(chan struct{}, 1)
+	l.mu.Lock()
+	l.queue = append(l.queue, wake)
+	for {
+		var delay time.Duration
+		if l.queue[0] == wake {
+			now := time.Now()
+			l.refill(now)
+			delay = l.delay(tokens)
+			if delay == 0 {
+				l.take(tokens)
+				l.queue = l.queue[1:]
+				l.wakeHead()
+				l.mu.Unlock()
+				return &Reservation{l: l, tokens: tokens}, nil
+			}
+		}
+		l.mu.Unlock()
+
+		if err := sleep(ctx, wake, delay); err != nil {
+			l.mu.Lock()
+			l.remove(wake)
+			l.wakeHead()
+			l.mu.Unlock()
+			return nil, err
+		}
+		l.mu.Lock()
+	}
+}
+
+// sleep waits until wake is signaled, delay has passed if it is positive, or
+// ctx is done.
+func sleep(ctx context.Context, wake <-chan struct{}, delay time.Duration) error {
+	var timeout <-chan time.Time
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-wake:
+		return nil
+	case <-timeout:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// refill adds the capacity accrued since the last refill. It must be called
+// with l.mu held.
+func (l *Limiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Minutes()
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+	if rpm := float64(l.limits.RequestsPerMinute); rpm > 0 {
+		l.requests = math.Min(rpm, l.requests+elapsed*rpm)
+	}
+	if tpm := float64(l.limits.TokensPerMinute); tpm > 0 {
+		l.tokens = math.Min(tpm, l.tokens+elapsed*tpm)
+	}
+}
+
+// delay returns how long to wait until a request using tokens tokens fits in
+// the budgets. It must be called with l.mu held.
+func (l *Limiter) delay(tokens int) time.Duration {
+	var wait float64
+	if rpm := float64(l.limits.RequestsPerMinute); rpm > 0 && l.requests < 1 {
+		wait = (1 - l.requests) / rpm
+	}
+	if tpm := float64(l.limits.TokensPerMinute); tpm > 0 {
+		need := math.Min(float64(tokens), tpm)
+		if l.tokens < need {
+			wait = math.Max(wait, (need-l.tokens)/tpm)
+		}
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Round up so the budget has refilled when the caller wakes up.
+	return time.Duration(math.Ceil(wait * float64(time.Minute)))
+}
+
+// take removes the capacity of a request from the budgets. It must be
+// called with l.mu held.
+func (l *Limiter) take(tokens int) {
+	if l.limits.RequestsPerMinute > 0 {
+		l.requests--
+	}
+	if l.limits.TokensPerMinute > 0 {
+		l.tokens -= float64(tokens)
+	}
+}
+
+// wakeHead wakes the caller at the head of the queue so it can recheck the
+// budgets. It must be called with l.mu held.
+func (l *Limiter) wakeHead() {
+	if len(l.queue) == 0 {
+		return
+	}
+	select {
+	case l.queue[0] <- struct{}{}:
+	default:
+	}
+}
+
+// remove removes a waiter from the queue. It must be called with l.mu held.
+func (l *Limiter) remove(wake chan struct{}) {
+	for i, w := range l.queue {
+		if w == wake {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// nolint:gochecknoglobals
+var shared sync.Map
+
+// Shared returns the process-wide Limiter for key, such as an API key or a
+// hash of it, creating it with limits on first use. Later calls for the same
+// key return the same Limiter regardless of limits.
+func Shared(key string, limits Limits) *Limiter {
+	if l, ok := shared.Load(key); ok {
+		return l.(*Limiter) //nolint:forcetypeassert
+	}
+	l, _ := shared.LoadOrStore(key, NewLimiter(limits))
+	return l.(*Limiter) //nolint:forcetypeassert
+}
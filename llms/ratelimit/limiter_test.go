@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterRequests(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(Limits{RequestsPerMinute: 2})
+	ctx := context.Background()
+	for range 2 {
+		_, err := l.Wait(ctx, 0)
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err := l.Wait(ctx, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, l.queue, "canceled callers leave the queue")
+}
+
+func TestLimiterTokensReconcile(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(Limits{TokensPerMinute: 100})
+	ctx := context.Background()
+
+	r, err := l.Wait(ctx, 90)
+	require.NoError(t, err)
+	require.Equal(t, 90, r.Tokens())
+
+	// The request used fewer tokens than estimated, so the next one fits.
+	r.Reconcile(20)
+	require.Equal(t, 20, r.Tokens())
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Wait(waitCtx, 70)
+	require.NoError(t, err)
+
+	// Using more than estimated puts the budget in debt.
+	r.Reconcile(100)
+	waitCtx, cancel = context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Wait(waitCtx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLimiterFIFO(t *testing.T) {
+	t.Parallel()
+
+	// 6000 tokens per minute refill at 100 tokens per second.
+	l := NewLimiter(Limits{TokensPerMinute: 6000})
+	ctx := context.Background()
+	_, err := l.Wait(ctx, 6000)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	wait := func(name string, tokens int) {
+		defer wg.Done()
+		_, err := l.Wait(ctx, tokens)
+		require.NoError(t, err)
+		mu.Lock()
+		order = append(order, name)
+		mu.Unlock()
+	}
+
+	wg.Add(2)
+	go wait("large", 5)
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return len(l.queue) == 1
+	}, time.Second, time.Millisecond)
+	// The small request would fit first, but waits for the large one.
+	go wait("small", 1)
+	wg.Wait()
+
+	require.Equal(t, []string{"large", "small"}, order)
+}
+
+func TestLimiterOversizedRequest(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(Limits{TokensPerMinute: 10})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := l.Wait(ctx, 1000)
+	require.NoError(t, err, "requests larger than the budget wait for a full budget")
+}
+
+func TestShared(t *testing.T) {
+	t.Parallel()
+
+	a := Shared("test-key", Limits{RequestsPerMinute: 10})
+	b := Shared("test-key", Limits{RequestsPerMinute: 20})
+	c := Shared("other-key", Limits{RequestsPerMinute: 10})
+	require.Same(t, a, b)
+	require.NotSame(t, a, c)
+	require.Equal(t, 10, b.Limits().RequestsPerMinute)
+}
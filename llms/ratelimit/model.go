@@ -0,0 +1,142 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Model is an llms.Model wrapper that waits for capacity on a Limiter before
+// each request.
+type Model struct {
+	llm     llms.Model
+	limiter *Limiter
+	opts    options
+}
+
+var (
+	_ llms.Model          = (*Model)(nil)
+	_ llms.StreamingModel = (*Model)(nil)
+)
+
+// NewModel wraps llm so that its requests are limited by limiter.
+func NewModel(llm llms.Model, limiter *Limiter, opts ...Option) *Model {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Model{
+		llm:     llm,
+		limiter: limiter,
+		opts:    o,
+	}
+}
+
+// Middleware returns an llms.Middleware that limits requests like NewModel.
+func Middleware(limiter *Limiter, opts ...Option) llms.Middleware {
+	return func(next llms.Model) llms.Model {
+		return NewModel(next, limiter, opts...)
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (m *Model) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// GenerateContent waits for capacity for the request and calls the wrapped
+// model. The token estimate is reconciled with the usage in the response, and
+// the capacity is returned if the request fails without reaching the
+// provider.
+func (m *Model) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	reservation, err := m.limiter.Wait(ctx, m.estimate(messages, options))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.llm.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		if !sent(err) {
+			reservation.Cancel()
+		}
+		return nil, err
+	}
+	if usage := responseUsage(resp); usage != nil {
+		reservation.Reconcile(usage.TotalTokens)
+	}
+	return resp, nil
+}
+
+// StreamContent waits for capacity for the request and streams the response
+// of the wrapped model. The token estimate is reconciled with the usage
+// event of the stream.
+func (m *Model) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return func(yield func(llms.StreamEvent, error) bool) {
+		reservation, err := m.limiter.Wait(ctx, m.estimate(messages, options))
+		if err != nil {
+			yield(llms.StreamEvent{}, err)
+			return
+		}
+		started := false
+		for event, err := range llms.StreamContent(ctx, m.llm, messages, options...) {
+			if err != nil && !started && !sent(err) {
+				reservation.Cancel()
+			}
+			started = true
+			if err == nil && event.Type == llms.StreamEventUsage && event.Usage != nil {
+				reservation.Reconcile(event.Usage.TotalTokens)
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// sent reports whether a failed request reached the provider, which is
+// known when the error reports its HTTP response. Requests failing without
+// one, such as those whose context is canceled before they are sent or that
+// can't be built, don't count against the limits.
+func sent(err error) bool {
+	var respErr *httputil.ResponseError
+	return errors.As(err, &respErr)
+}
+
+// estimate returns the estimated tokens of a request: its prompt and the
+// maximum number of tokens it may generate.
+func (m *Model) estimate(messages []llms.MessageContent, options []llms.CallOption) int {
+	if m.limiter.Limits().TokensPerMinute <= 0 {
+		return 0
+	}
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	model := m.opts.model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	return m.opts.tokenCounter(model, llms.MessagesText(messages)) + opts.MaxTokens
+}
+
+// responseUsage returns the usage reported in resp, if any. Providers report
+// the usage of the whole request on every choice.
+func responseUsage(resp *llms.ContentResponse) *llms.Usage {
+	if resp == nil {
+		return nil
+	}
+	for _, c := range resp.Choices {
+		if c.Usage != nil && c.Usage.TotalTokens > 0 {
+			return c.Usage
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
+)
+
+type mockLLM struct {
+	usage *llms.Usage
+	err   error
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok", Usage: m.usage}}}, nil
+}
+
+func lengthCounter(_, text string) int {
+	return len(text)
+}
+
+func TestModelReconcilesUsage(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(Limits{TokensPerMinute: 1000})
+	model := NewModel(&mockLLM{usage: llms.NewUsage(10, 5)}, limiter, WithTokenCounter(lengthCounter))
+
+	_, err := llms.GenerateFromSinglePrompt(context.Background(), model, "hello", llms.WithMaxTokens(500))
+	require.NoError(t, err)
+
+	// The estimate of 506 tokens was replaced with the 15 tokens used.
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	require.InDelta(t, 985, limiter.tokens, 1)
+}
+
+func TestModelRefundsUnsentRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		requests float64
+		tokens   float64
+	}{
+		{"canceled", context.Canceled, 10, 1000},
+		{"provider error", &httputil.ResponseError{StatusCode: http.StatusTooManyRequests}, 9, 494},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := NewLimiter(Limits{RequestsPerMinute: 10, TokensPerMinute: 1000})
+			model := NewModel(&mockLLM{err: tt.err}, limiter, WithTokenCounter(lengthCounter))
+
+			_, err := llms.GenerateFromSinglePrompt(context.Background(), model, "hello", llms.WithMaxTokens(500))
+			require.ErrorIs(t, err, tt.err)
+
+			limiter.mu.Lock()
+			defer limiter.mu.Unlock()
+			require.InDelta(t, tt.requests, limiter.requests, 0.1)
+			require.InDelta(t, tt.tokens, limiter.tokens, 1)
+		})
+	}
+}
+
+func TestModelStreamContent(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(Limits{TokensPerMinute: 1000})
+	model := NewModel(&mockLLM{usage: llms.NewUsage(10, 5)}, limiter, WithTokenCounter(lengthCounter))
+
+	msgs := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hello")}
+	for _, err := range model.StreamContent(context.Background(), msgs, llms.WithMaxTokens(500)) {
+		require.NoError(t, err)
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	require.InDelta(t, 985, limiter.tokens, 1)
+}
+
+func TestEmbedderClient(t *testing.T) {
+	t.Parallel()
+
+	var batches int
+	client := embeddings.EmbedderClientFunc(func(_ context.Context, texts []string) ([][]float32, error) {
+		batches++
+		return make([][]float32, len(texts)), nil
+	})
+	limiter := NewLimiter(Limits{RequestsPerMinute: 100, TokensPerMinute: 1000})
+	embedder, err := embeddings.NewEmbedder(NewEmbedderClient(client, limiter, WithTokenCounter(lengthCounter)),
+		embeddings.WithBatchSize(2))
+	require.NoError(t, err)
+
+	vectors, err := embedder.EmbedDocuments(context.Background(), []string{"aa", "bb", "cc"})
+	require.NoError(t, err)
+	require.Len(t, vectors, 3)
+	require.Equal(t, 2, batches)
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	require.InDelta(t, 98, limiter.requests, 0.1)
+	require.InDelta(t, 994, limiter.tokens, 1)
+}
+
+func TestEmbedderClientRefundsUnsentRequests(t *testing.T) {
+	t.Parallel()
+
+	client := embeddings.EmbedderClientFunc(func(ctx context.Context, _ []string) ([][]float32, error) {
+		return nil, ctx.Err()
+	})
+	limiter := NewLimiter(Limits{RequestsPerMinute: 10, TokensPerMinute: 1000})
+	embedder := NewEmbedderClient(client, limiter, WithTokenCounter(lengthCounter))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := embedder.CreateEmbedding(ctx, []string{"aa"})
+	require.ErrorIs(t, err, context.Canceled)
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	require.InDelta(t, 10, limiter.requests, 0.1)
+	require.InDelta(t, 1000, limiter.tokens, 1)
+}
@@ -0,0 +1,33 @@
+package ratelimit
+
+import "github.com/tmc/langchaingo/llms"
+
+// Option is a function that configures a Model or EmbedderClient.
+type Option func(*options)
+
+type options struct {
+	model        string
+	tokenCounter func(model, text string) int
+}
+
+func defaultOptions() options {
+	return options{
+		tokenCounter: llms.CountTokens,
+	}
+}
+
+// WithModelName sets the model name used to count tokens. For models, the
+// name passed with llms.WithModel takes precedence.
+func WithModelName(model string) Option {
+	return func(o *options) {
+		o.model = model
+	}
+}
+
+// WithTokenCounter sets the function used to estimate the tokens of a
+// request before it is sent. The default is llms.CountTokens.
+func WithTokenCounter(counter func(model, text string) int) Option {
+	return func(o *options) {
+		o.tokenCounter = counter
+	}
+}
@@ -155,8 +155,13 @@ func SupportsReasoningModel(llm interface{}) bool {
 
 // DefaultIsReasoningModel provides the default reasoning model detection logic.
 // This can be used by LLM implementations that want to extend rather than replace
-// the default detection logic.
+// the default detection logic. Models in the default model registry report
+// their Reasoning capability; other models are matched by name.
 func DefaultIsReasoningModel(model string) bool {
+	if info, ok := LookupModel(model); ok {
+		return info.Reasoning
+	}
+
 	modelLower := strings.ToLower(model)
 
 	// OpenAI reasoning models
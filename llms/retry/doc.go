@@ -0,0 +1,17 @@
+// Package retry provides an llms.Model wrapper that retries requests failing
+// with transient errors, such as rate limits, provider outages and timeouts.
+//
+// Retries wait with jittered exponential backoff. When a provider client
+// returns an *httputil.ResponseError, as the openai, anthropic and mistral
+// clients do, the Retry-After and rate limit reset headers of the response are
+// honored:
+//
+//	llm = retry.New(llm, retry.WithMaxRetries(5))
+//
+// Retries are stream-safe: once the wrapped model has passed any output to the
+// caller's streaming functions, its error is returned instead of retrying, so
+// callers never see the output of two attempts in one response.
+//
+// To retry at the HTTP level instead, use httputil.RetryTransport as the
+// transport of the provider's HTTP client.
+package retry
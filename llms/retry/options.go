@@ -0,0 +1,63 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Option is a function that configures a Retrier.
+type Option func(*options)
+
+type options struct {
+	maxRetries int
+	backoff    httputil.Backoff
+	retryOn    []llms.ErrorCode
+	onRetry    func(ctx context.Context, retry int, err error, wait time.Duration)
+}
+
+func defaultOptions() options {
+	return options{
+		maxRetries: httputil.DefaultMaxRetries,
+		retryOn: []llms.ErrorCode{
+			llms.ErrCodeRateLimit,
+			llms.ErrCodeProviderUnavailable,
+			llms.ErrCodeTimeout,
+		},
+	}
+}
+
+// WithMaxRetries sets the maximum number of retries after the first attempt.
+// The default is httputil.DefaultMaxRetries.
+func WithMaxRetries(n int) Option {
+	return func(o *options) {
+		o.maxRetries = n
+	}
+}
+
+// WithBackoff sets the backoff used to compute the delay between retries.
+func WithBackoff(backoff httputil.Backoff) Option {
+	return func(o *options) {
+		o.backoff = backoff
+	}
+}
+
+// WithRetryOn sets the error codes that are retried. The default is
+// llms.ErrCodeRateLimit, llms.ErrCodeProviderUnavailable and
+// llms.ErrCodeTimeout.
+func WithRetryOn(codes ...llms.ErrorCode) Option {
+	return func(o *options) {
+		o.retryOn = codes
+	}
+}
+
+// WithOnRetry sets a function that is called before each retry with the
+// retry number, counting from zero, the error that caused it and the delay
+// before the retry.
+func WithOnRetry(fn func(ctx context.Context, retry int, err error, wait time.Duration)) Option {
+	return func(o *options) {
+		o.onRetry = fn
+	}
+}
@@ -0,0 +1,125 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Retrier is an llms.Model wrapper that retries requests failing with
+// transient errors.
+type Retrier struct {
+	llm  llms.Model
+	opts options
+}
+
+var (
+	_ llms.Model          = (*Retrier)(nil)
+	_ llms.StreamingModel = (*Retrier)(nil)
+)
+
+// New wraps llm so that its requests are retried on transient errors.
+func New(llm llms.Model, opts ...Option) *Retrier {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Retrier{
+		llm:  llm,
+		opts: o,
+	}
+}
+
+// Middleware returns an llms.Middleware that retries requests like New.
+func Middleware(opts ...Option) llms.Middleware {
+	return func(next llms.Model) llms.Model {
+		return New(next, opts...)
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Retrier) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent calls the wrapped model, retrying when it fails with a
+// retryable error before streaming any output.
+func (r *Retrier) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	for retry := 0; ; retry++ {
+		callOptions, streamed := llms.TrackStreaming(options)
+		resp, err := r.llm.GenerateContent(ctx, messages, callOptions...)
+		if err == nil || streamed() {
+			return resp, err
+		}
+		if err := r.wait(ctx, retry, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// StreamContent streams the response of the wrapped model, retrying when it
+// fails with a retryable error before producing any events.
+func (r *Retrier) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return func(yield func(llms.StreamEvent, error) bool) {
+		for retry := 0; ; retry++ {
+			var streamed bool
+			var streamErr error
+			for event, err := range llms.StreamContent(ctx, r.llm, messages, options...) {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				streamed = true
+				if !yield(event, nil) {
+					return
+				}
+			}
+			if streamErr == nil {
+				return
+			}
+			if streamed {
+				yield(llms.StreamEvent{}, streamErr)
+				return
+			}
+			if err := r.wait(ctx, retry, streamErr); err != nil {
+				yield(llms.StreamEvent{}, err)
+				return
+			}
+		}
+	}
+}
+
+// wait sleeps before the given retry after err. It returns err if the
+// request should not be retried, and the context's error if ctx is done
+// while waiting.
+func (r *Retrier) wait(ctx context.Context, retry int, err error) error {
+	if retry >= r.opts.maxRetries || ctx.Err() != nil || !slices.Contains(r.opts.retryOn, llms.ErrorCodeOf(err)) {
+		return err
+	}
+
+	var retryAfter time.Duration
+	var respErr *httputil.ResponseError
+	if errors.As(err, &respErr) {
+		retryAfter = respErr.RetryAfter()
+	}
+	wait, ok := r.opts.backoff.Wait(retry, retryAfter)
+	if !ok {
+		return err
+	}
+
+	if r.opts.onRetry != nil {
+		r.opts.onRetry(ctx, retry, err, wait)
+	}
+	return httputil.Sleep(ctx, wait)
+}
@@ -0,0 +1,159 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/httputil"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// mockLLM fails with errs, one per call, before streaming anything. Once errs
+// are used up it streams chunks and then fails with streamErr, if set.
+// not synchronized, don't use concurrently!
+type mockLLM struct {
+	errs      []error
+	chunks    []string
+	streamErr error
+	called    int
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.called++
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+		return nil, err
+	}
+	for _, chunk := range m.chunks {
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, []byte(chunk)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if m.streamErr != nil {
+		return nil, m.streamErr
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "done"}}}, nil
+}
+
+var fastBackoff = WithBackoff(httputil.Backoff{Initial: time.Millisecond, Jitter: -1})
+
+func TestRetrierRetries(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{errs: []error{
+		llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down"),
+		errors.New("API returned unexpected status code: 503"),
+	}}
+	var retries []int
+	r := New(llm, fastBackoff, WithOnRetry(func(_ context.Context, retry int, _ error, _ time.Duration) {
+		retries = append(retries, retry)
+	}))
+
+	got, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi")
+	require.NoError(t, err)
+	require.Equal(t, "done", got)
+	require.Equal(t, 3, llm.called)
+	require.Equal(t, []int{0, 1}, retries)
+}
+
+func TestRetrierHonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	respErr := httputil.NewResponseError(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After-Ms": {"20"}},
+	}, errors.New("API returned unexpected status code: 429"))
+	llm := &mockLLM{errs: []error{respErr}}
+	var wait time.Duration
+	r := New(llm, fastBackoff, WithOnRetry(func(_ context.Context, _ int, _ error, d time.Duration) {
+		wait = d
+	}))
+
+	_, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi")
+	require.NoError(t, err)
+	require.Equal(t, 20*time.Millisecond, wait)
+}
+
+func TestRetrierGivesUp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non-retryable error", func(t *testing.T) {
+		t.Parallel()
+		authErr := llms.NewError(llms.ErrCodeAuthentication, "openai", "bad key")
+		llm := &mockLLM{errs: []error{authErr}}
+		_, err := llms.GenerateFromSinglePrompt(context.Background(), New(llm, fastBackoff), "hi")
+		require.ErrorIs(t, err, authErr)
+		require.Equal(t, 1, llm.called)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		t.Parallel()
+		rateErr := llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")
+		llm := &mockLLM{errs: []error{rateErr, rateErr, rateErr}}
+		_, err := llms.GenerateFromSinglePrompt(context.Background(), New(llm, fastBackoff, WithMaxRetries(2)), "hi")
+		require.True(t, llms.IsRateLimitError(err))
+		require.Equal(t, 3, llm.called)
+	})
+
+	t.Run("after streaming", func(t *testing.T) {
+		t.Parallel()
+		llm := &mockLLM{
+			chunks:    []string{"partial"},
+			streamErr: llms.NewError(llms.ErrCodeTimeout, "openai", "timeout"),
+		}
+		var streamed string
+		_, err := llms.GenerateFromSinglePrompt(context.Background(), New(llm, fastBackoff), "hi",
+			llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+				streamed += string(chunk)
+				return nil
+			}))
+		require.True(t, llms.IsTimeoutError(err))
+		require.Equal(t, "partial", streamed)
+		require.Equal(t, 1, llm.called)
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		llm := &mockLLM{errs: []error{llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")}}
+		r := New(llm, WithBackoff(httputil.Backoff{Initial: time.Hour, Max: time.Hour}),
+			WithOnRetry(func(context.Context, int, error, time.Duration) { cancel() }))
+		_, err := llms.GenerateFromSinglePrompt(ctx, r, "hi")
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, llm.called)
+	})
+}
+
+func TestRetrierStreamContent(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{
+		errs:   []error{llms.NewError(llms.ErrCodeProviderUnavailable, "anthropic", "overloaded")},
+		chunks: []string{"do", "ne"},
+	}
+
+	var text string
+	for event, err := range New(llm, fastBackoff).StreamContent(context.Background(), nil) {
+		require.NoError(t, err)
+		if event.Type == llms.StreamEventTextDelta {
+			text += event.Delta
+		}
+	}
+	require.Equal(t, "done", text)
+	require.Equal(t, 2, llm.called)
+}
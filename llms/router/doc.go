@@ -0,0 +1,19 @@
+// Package router provides an llms.Model that spreads requests over an ordered
+// list of models. Each request goes to the first model that can serve it; when
+// that model fails with a transient error, such as a rate limit or an outage,
+// the request fails over to the next one.
+//
+// Models can declare the capabilities they support and their context size, so
+// requests that need tools or images, or that would not fit in a small
+// context window, skip models that cannot serve them:
+//
+//	llm := router.New([]router.Route{
+//		{Model: openaiLLM, Name: "gpt-4o"},
+//		{Model: anthropicLLM, ContextSize: 200000},
+//		{Model: localLLM, ContextSize: 8192, Capabilities: []router.Capability{router.CapabilityTools}},
+//	})
+//
+// Streaming callbacks are passed through to the selected model. Once a model
+// has streamed any output, its errors are returned instead of failing over, so
+// callers never see the output of two models in one response.
+package router
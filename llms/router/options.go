@@ -0,0 +1,53 @@
+package router
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Option is a function that configures a Router.
+type Option func(*options)
+
+type options struct {
+	failoverCodes []llms.ErrorCode
+	tokenCounter  func(model, text string) int
+	onFailover    func(ctx context.Context, route Route, err error)
+}
+
+func defaultOptions() options {
+	return options{
+		failoverCodes: []llms.ErrorCode{
+			llms.ErrCodeRateLimit,
+			llms.ErrCodeProviderUnavailable,
+			llms.ErrCodeTimeout,
+			llms.ErrCodeTokenLimit,
+		},
+		tokenCounter: llms.CountTokens,
+	}
+}
+
+// WithFailoverOn sets the error codes that make the router try the next
+// model. The default is llms.ErrCodeRateLimit, llms.ErrCodeProviderUnavailable,
+// llms.ErrCodeTimeout and llms.ErrCodeTokenLimit.
+func WithFailoverOn(codes ...llms.ErrorCode) Option {
+	return func(o *options) {
+		o.failoverCodes = codes
+	}
+}
+
+// WithTokenCounter sets the function used to estimate the prompt size of a
+// request for routes with a context size. The default is llms.CountTokens.
+func WithTokenCounter(counter func(model, text string) int) Option {
+	return func(o *options) {
+		o.tokenCounter = counter
+	}
+}
+
+// WithOnFailover sets a function that is called whenever a route fails with
+// an error that makes the router try the next model.
+func WithOnFailover(fn func(ctx context.Context, route Route, err error)) Option {
+	return func(o *options) {
+		o.onFailover = fn
+	}
+}
@@ -0,0 +1,255 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+var (
+	// ErrNoRoute is returned when no model can serve a request.
+	ErrNoRoute = errors.New("router: no model can serve the request")
+	// ErrAllFailed is returned when every model that could serve a request
+	// failed. It wraps the error of each model.
+	ErrAllFailed = errors.New("router: all models failed")
+)
+
+// Capability is a feature a request may need from a model.
+type Capability string
+
+const (
+	// CapabilityTools is needed by requests that pass tools or functions.
+	CapabilityTools Capability = "tools"
+	// CapabilityVision is needed by requests with image or binary content.
+	CapabilityVision Capability = "vision"
+	// CapabilityReasoning is needed by requests that enable thinking.
+	CapabilityReasoning Capability = "reasoning"
+)
+
+// Route is a model the router can send requests to.
+type Route struct {
+	// Model is the model that serves the request.
+	Model llms.Model
+
+	// Name is the model name used to count prompt tokens and, unless
+	// ContextSize is set, to look up the context size with
+	// llms.GetModelContextSize. Routes without a name or context size
+	// accept prompts of any size.
+	Name string
+
+	// ContextSize is the context window of the model in tokens.
+	ContextSize int
+
+	// Capabilities lists the features the model supports. A nil list means
+	// the model is not filtered by capability.
+	Capabilities []Capability
+
+	// Options are call options appended to every request sent to this route,
+	// such as llms.WithModel.
+	Options []llms.CallOption
+}
+
+// Router is an llms.Model that sends each request to the first route that
+// can serve it and fails over to the next route on transient errors.
+type Router struct {
+	routes []Route
+	opts   options
+}
+
+var (
+	_ llms.Model          = (*Router)(nil)
+	_ llms.StreamingModel = (*Router)(nil)
+)
+
+// New creates a Router that tries routes in order.
+func New(routes []Route, opts ...Option) *Router {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Router{
+		routes: slices.Clone(routes),
+		opts:   o,
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Router) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent sends the request to the first route that can serve it,
+// failing over to the next route when a model returns an error with one of
+// the failover codes before streaming any output.
+func (r *Router) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	routes, err := r.candidates(messages, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, route := range routes {
+		callOptions, streamed := llms.TrackStreaming(route.callOptions(options))
+		resp, err := route.Model.GenerateContent(ctx, messages, callOptions...)
+		if err == nil {
+			return resp, nil
+		}
+		if streamed() || !r.shouldFailover(ctx, err) {
+			return nil, err
+		}
+		r.failover(ctx, route, err)
+		errs = append(errs, err)
+	}
+	return nil, allFailed(errs)
+}
+
+// StreamContent streams the response of the first route that can serve the
+// request, failing over to the next route when a model fails before it has
+// produced any events.
+func (r *Router) StreamContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) iter.Seq2[llms.StreamEvent, error] { //nolint:lll
+	return func(yield func(llms.StreamEvent, error) bool) {
+		routes, err := r.candidates(messages, options)
+		if err != nil {
+			yield(llms.StreamEvent{}, err)
+			return
+		}
+
+		var errs []error
+		for _, route := range routes {
+			var streamed bool
+			var streamErr error
+			for event, err := range llms.StreamContent(ctx, route.Model, messages, route.callOptions(options)...) {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				streamed = true
+				if !yield(event, nil) {
+					return
+				}
+			}
+			if streamErr == nil {
+				return
+			}
+			if streamed || !r.shouldFailover(ctx, streamErr) {
+				yield(llms.StreamEvent{}, streamErr)
+				return
+			}
+			r.failover(ctx, route, streamErr)
+			errs = append(errs, streamErr)
+		}
+		yield(llms.StreamEvent{}, allFailed(errs))
+	}
+}
+
+// candidates returns the routes that can serve the request, in order.
+func (r *Router) candidates(messages []llms.MessageContent, options []llms.CallOption) ([]Route, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	required := requiredCapabilities(messages, &opts)
+
+	var text string
+	tokens := map[string]int{}
+	promptTokens := func(name string) int {
+		if n, ok := tokens[name]; ok {
+			return n
+		}
+		if text == "" {
+			text = llms.MessagesText(messages)
+		}
+		tokens[name] = r.opts.tokenCounter(name, text)
+		return tokens[name]
+	}
+
+	var routes []Route
+	for _, route := range r.routes {
+		if !route.supports(required) {
+			continue
+		}
+		if size := route.contextSize(); size > 0 && promptTokens(route.Name)+opts.MaxTokens > size {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	if len(routes) == 0 {
+		return nil, ErrNoRoute
+	}
+	return routes, nil
+}
+
+// shouldFailover reports whether err should make the router try the next
+// route. Errors caused by the caller's context never fail over.
+func (r *Router) shouldFailover(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return slices.Contains(r.opts.failoverCodes, llms.ErrorCodeOf(err))
+}
+
+func (r *Router) failover(ctx context.Context, route Route, err error) {
+	if r.opts.onFailover != nil {
+		r.opts.onFailover(ctx, route, err)
+	}
+}
+
+func allFailed(errs []error) error {
+	return fmt.Errorf("%w: %w", ErrAllFailed, errors.Join(errs...))
+}
+
+func (route Route) callOptions(options []llms.CallOption) []llms.CallOption {
+	return append(slices.Clone(options), route.Options...)
+}
+
+func (route Route) supports(required []Capability) bool {
+	if route.Capabilities == nil {
+		return true
+	}
+	for _, c := range required {
+		if !slices.Contains(route.Capabilities, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (route Route) contextSize() int {
+	if route.ContextSize > 0 {
+		return route.ContextSize
+	}
+	if route.Name != "" {
+		return llms.GetModelContextSize(route.Name)
+	}
+	return 0
+}
+
+// requiredCapabilities returns the capabilities a request needs.
+func requiredCapabilities(messages []llms.MessageContent, opts *llms.CallOptions) []Capability {
+	var required []Capability
+	if len(opts.Tools) > 0 || len(opts.Functions) > 0 {
+		required = append(required, CapabilityTools)
+	}
+	if config := llms.GetThinkingConfig(opts); config != nil && config.Mode != llms.ThinkingModeNone {
+		required = append(required, CapabilityReasoning)
+	}
+	for _, m := range messages {
+		for _, part := range m.Parts {
+			switch part.(type) {
+			case llms.ImageURLContent, llms.BinaryContent:
+				return append(required, CapabilityVision)
+			}
+		}
+	}
+	return required
+}
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// not synchronized, don't use concurrently!
+type mockLLM struct {
+	name   string
+	chunks []string
+	err    error
+	called int
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.called++
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	for _, chunk := range m.chunks {
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, []byte(chunk)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.name}}}, nil
+}
+
+func approxTokens(_, text string) int {
+	return len(text) / 4
+}
+
+func TestRouterFailover(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{name: "primary", err: llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")}
+	backup := &mockLLM{name: "backup"}
+	var failedOver []error
+	r := New([]Route{{Model: primary}, {Model: backup}}, WithOnFailover(func(_ context.Context, _ Route, err error) {
+		failedOver = append(failedOver, err)
+	}))
+
+	got, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi")
+	require.NoError(t, err)
+	require.Equal(t, "backup", got)
+	require.Equal(t, 1, primary.called)
+	require.Len(t, failedOver, 1)
+}
+
+func TestRouterFailoverMapsPlainErrors(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{err: errors.New("API returned unexpected status code: 503: service unavailable")}
+	backup := &mockLLM{name: "backup"}
+	r := New([]Route{{Model: primary}, {Model: backup}})
+
+	got, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi")
+	require.NoError(t, err)
+	require.Equal(t, "backup", got)
+}
+
+func TestRouterNoFailover(t *testing.T) {
+	t.Parallel()
+
+	authErr := llms.NewError(llms.ErrCodeAuthentication, "openai", "bad key")
+	primary := &mockLLM{err: authErr}
+	backup := &mockLLM{name: "backup"}
+	r := New([]Route{{Model: primary}, {Model: backup}})
+
+	_, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi")
+	require.ErrorIs(t, err, authErr)
+	require.Equal(t, 0, backup.called)
+}
+
+func TestRouterNoFailoverAfterStreaming(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{chunks: []string{"partial"}, err: llms.NewError(llms.ErrCodeTimeout, "openai", "timeout")}
+	backup := &mockLLM{name: "backup"}
+	r := New([]Route{{Model: primary}, {Model: backup}})
+
+	var streamed strings.Builder
+	_, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi",
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			streamed.Write(chunk)
+			return nil
+		}))
+	require.True(t, llms.IsTimeoutError(err))
+	require.Equal(t, "partial", streamed.String())
+	require.Equal(t, 0, backup.called)
+}
+
+func TestRouterAllFailed(t *testing.T) {
+	t.Parallel()
+
+	r := New([]Route{
+		{Model: &mockLLM{err: llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")}},
+		{Model: &mockLLM{err: llms.NewError(llms.ErrCodeProviderUnavailable, "anthropic", "overloaded")}},
+	})
+
+	_, err := llms.GenerateFromSinglePrompt(context.Background(), r, "hi")
+	require.ErrorIs(t, err, ErrAllFailed)
+	require.True(t, llms.IsRateLimitError(err))
+	require.ErrorIs(t, err, llms.ErrProviderUnavailable)
+}
+
+func TestRouterRouting(t *testing.T) {
+	t.Parallel()
+
+	small := &mockLLM{name: "small"}
+	textOnly := &mockLLM{name: "text-only"}
+	large := &mockLLM{name: "large"}
+	r := New([]Route{
+		{Model: small, ContextSize: 10},
+		{Model: textOnly, ContextSize: 1000, Capabilities: []Capability{}},
+		{Model: large, ContextSize: 1000, Capabilities: []Capability{CapabilityTools, CapabilityVision}},
+	}, WithTokenCounter(approxTokens))
+
+	ctx := context.Background()
+	got, err := llms.GenerateFromSinglePrompt(ctx, r, "short")
+	require.NoError(t, err)
+	require.Equal(t, "small", got)
+
+	long := strings.Repeat("word ", 20)
+	got, err = llms.GenerateFromSinglePrompt(ctx, r, long)
+	require.NoError(t, err)
+	require.Equal(t, "text-only", got)
+
+	got, err = llms.GenerateFromSinglePrompt(ctx, r, long, llms.WithTools([]llms.Tool{{Type: "function"}}))
+	require.NoError(t, err)
+	require.Equal(t, "large", got)
+
+	_, err = llms.GenerateFromSinglePrompt(ctx, r, strings.Repeat("word ", 1000))
+	require.ErrorIs(t, err, ErrNoRoute)
+}
+
+func TestRouterStreamContentFailover(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{err: llms.NewError(llms.ErrCodeProviderUnavailable, "openai", "down")}
+	backup := &mockLLM{name: "backup", chunks: []string{"back", "up"}}
+	r := New([]Route{{Model: primary}, {Model: backup}})
+
+	var text string
+	for event, err := range r.StreamContent(context.Background(), nil) {
+		require.NoError(t, err)
+		if event.Type == llms.StreamEventTextDelta {
+			text += event.Delta
+		}
+	}
+	require.Equal(t, "backup", text)
+	require.Equal(t, 1, primary.called)
+}
+
+func TestRouterCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	primary := &mockLLM{err: llms.NewError(llms.ErrCodeTimeout, "openai", "timeout")}
+	backup := &mockLLM{name: "backup"}
+	r := New([]Route{{Model: primary}, {Model: backup}})
+
+	_, err := llms.GenerateFromSinglePrompt(ctx, r, "hi")
+	require.Error(t, err)
+	require.Equal(t, 0, backup.called)
+}
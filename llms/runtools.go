@@ -0,0 +1,151 @@
+package llms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrMaxToolTurns is returned by RunTools when the model still calls tools
+// after the maximum number of turns.
+var ErrMaxToolTurns = errors.New("maximum number of tool turns reached")
+
+const _defaultMaxToolTurns = 10
+
+// ToolHandler executes a tool call with the JSON arguments generated by the
+// model, and returns the content of the response sent back to the model.
+type ToolHandler func(ctx context.Context, arguments string) (string, error)
+
+// Toolset is a set of function tools with the handlers executing their calls.
+// The zero value is an empty set ready to use.
+type Toolset struct {
+	tools    []Tool
+	handlers map[string]ToolHandler
+}
+
+// NewToolset returns an empty Toolset.
+func NewToolset() *Toolset {
+	return &Toolset{}
+}
+
+// Add adds a function tool executed by handler to the set, replacing any
+// tool with the same name. It returns the set, so calls can be chained.
+func (s *Toolset) Add(function FunctionDefinition, handler ToolHandler) *Toolset {
+	if s.handlers == nil {
+		s.handlers = map[string]ToolHandler{}
+	}
+	s.tools = slices.DeleteFunc(s.tools, func(t Tool) bool { return t.Function.Name == function.Name })
+	s.tools = append(s.tools, Tool{Type: "function", Function: &function})
+	s.handlers[function.Name] = handler
+	return s
+}
+
+// Tools returns the tools in the set, to pass to WithTools.
+func (s *Toolset) Tools() []Tool {
+	return slices.Clone(s.tools)
+}
+
+// Execute executes a tool call with the handler of the tool, and returns the
+// response to send back to the model.
+func (s *Toolset) Execute(ctx context.Context, call ToolCall) (ToolCallResponse, error) {
+	if call.FunctionCall == nil {
+		return ToolCallResponse{}, fmt.Errorf("tool call %q has no function call", call.ID)
+	}
+	handler, ok := s.handlers[call.FunctionCall.Name]
+	if !ok {
+		return ToolCallResponse{}, fmt.Errorf("unknown tool %q", call.FunctionCall.Name)
+	}
+	content, err := handler(ctx, call.FunctionCall.Arguments)
+	if err != nil {
+		return ToolCallResponse{}, err
+	}
+	return ToolCallResponse{ToolCallID: call.ID, Name: call.FunctionCall.Name, Content: content}, nil
+}
+
+// RunTools generates content with the tools of toolset, executes the tool
+// calls of the model and sends their responses back to it, until the model
+// responds without calling tools. It returns the transcript: messages
+// followed by the responses of the model and the tool responses.
+//
+// Each response of the model is added to the transcript as one AI message
+// holding its text and tool calls, followed by one tool message for each
+// call. Tool calls are executed in order. When a call fails, because the tool
+// is unknown or its handler returns an error, the error is sent to the model
+// as the response, so it can correct the call. The run stops with the error
+// of the context when it is done.
+//
+// The tools are passed to the model with WithTools, after the options. The
+// model is called until it stops calling tools or the tool calls have been
+// executed the number of times set by WithMaxToolTurns. When the model calls
+// tools again after that, RunTools returns the transcript without that
+// response and ErrMaxToolTurns.
+func RunTools(ctx context.Context, model Model, messages []MessageContent, toolset *Toolset, options ...CallOption) ([]MessageContent, error) { //nolint:lll
+	opts := CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	maxTurns := _defaultMaxToolTurns
+	if opts.MaxToolTurns != nil {
+		maxTurns = max(*opts.MaxToolTurns, 0)
+	}
+	options = append(slices.Clone(options), WithTools(toolset.Tools()))
+
+	transcript := slices.Clone(messages)
+	for turn := 0; ; turn++ {
+		resp, err := model.GenerateContent(ctx, transcript, options...)
+		if err != nil {
+			return transcript, err
+		}
+		msg, calls := responseMessage(resp)
+		if len(calls) == 0 {
+			if len(msg.Parts) > 0 {
+				transcript = append(transcript, msg)
+			}
+			return transcript, nil
+		}
+		if turn >= maxTurns {
+			return transcript, fmt.Errorf("%w (%d)", ErrMaxToolTurns, maxTurns)
+		}
+		transcript = append(transcript, msg)
+		for _, call := range calls {
+			response, err := toolset.Execute(ctx, call)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return transcript, ctxErr
+			}
+			if err != nil {
+				response = ToolCallResponse{ToolCallID: call.ID, Name: call.FunctionCall.Name, Content: "Error: " + err.Error()}
+			}
+			transcript = append(transcript, MessageContent{Role: ChatMessageTypeTool, Parts: []ContentPart{response}})
+		}
+	}
+}
+
+// responseMessage returns the AI message for a response and its tool calls.
+// Some providers return each block of content as a choice, so the text and
+// tool calls of all choices make up the message.
+func responseMessage(resp *ContentResponse) (MessageContent, []ToolCall) {
+	msg := MessageContent{Role: ChatMessageTypeAI}
+	var calls []ToolCall
+	if resp == nil {
+		return msg, nil
+	}
+	for _, choice := range resp.Choices {
+		if choice.Content != "" {
+			msg.Parts = append(msg.Parts, TextContent{Text: choice.Content})
+		}
+		for _, call := range choice.ToolCalls {
+			if call.FunctionCall == nil {
+				continue
+			}
+			if call.Type == "" {
+				call.Type = "function"
+			}
+			calls = append(calls, call)
+		}
+	}
+	for _, call := range calls {
+		msg.Parts = append(msg.Parts, call)
+	}
+	return msg, calls
+}
@@ -0,0 +1,118 @@
+package llms_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// toolModel responds with its responses in order and records the messages
+// and options of each request.
+type toolModel struct {
+	responses []*llms.ContentResponse
+	messages  [][]llms.MessageContent
+	options   []llms.CallOptions
+}
+
+func (m *toolModel) GenerateContent(_ context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.messages = append(m.messages, messages)
+	m.options = append(m.options, opts)
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+func (m *toolModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func toolCall(id, name, arguments string) llms.ToolCall {
+	return llms.ToolCall{ID: id, Type: "function", FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments}}
+}
+
+func toolResponse(id, name, content string) llms.MessageContent {
+	return llms.MessageContent{
+		Role:  llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: id, Name: name, Content: content}},
+	}
+}
+
+func weatherToolset() *llms.Toolset {
+	return llms.NewToolset().Add(llms.FunctionDefinition{Name: "weather"}, func(_ context.Context, args string) (string, error) {
+		if !strings.Contains(args, "city") {
+			return "", errors.New("missing city")
+		}
+		return "sunny", nil
+	})
+}
+
+func TestRunTools(t *testing.T) {
+	t.Parallel()
+
+	model := &toolModel{responses: []*llms.ContentResponse{
+		// Providers may return the text and each tool call as a choice.
+		{Choices: []*llms.ContentChoice{
+			{Content: "Let me check."},
+			{ToolCalls: []llms.ToolCall{toolCall("1", "weather", `{"city": "Paris"}`)}},
+			{ToolCalls: []llms.ToolCall{toolCall("2", "forecast", `{}`)}},
+		}},
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCall("3", "weather", `{}`)}}}},
+		{Choices: []*llms.ContentChoice{{Content: "It is sunny."}}},
+	}}
+	prompt := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Paris?")}
+
+	transcript, err := llms.RunTools(context.Background(), model, prompt, weatherToolset())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []llms.MessageContent{
+		prompt[0],
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{
+			llms.TextContent{Text: "Let me check."},
+			toolCall("1", "weather", `{"city": "Paris"}`),
+			toolCall("2", "forecast", `{}`),
+		}},
+		toolResponse("1", "weather", "sunny"),
+		toolResponse("2", "forecast", `Error: unknown tool "forecast"`),
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{toolCall("3", "weather", `{}`)}},
+		toolResponse("3", "weather", "Error: missing city"),
+		llms.TextParts(llms.ChatMessageTypeAI, "It is sunny."),
+	}
+	if !reflect.DeepEqual(transcript, want) {
+		t.Errorf("transcript mismatch:\ngot:  %+v\nwant: %+v", transcript, want)
+	}
+	if n := len(model.options[0].Tools); n != 1 {
+		t.Errorf("got %d tools, want 1", n)
+	}
+	if n := len(model.messages[2]); n != 6 {
+		t.Errorf("the last request has %d messages, want 6", n)
+	}
+}
+
+func TestRunToolsMaxTurns(t *testing.T) {
+	t.Parallel()
+
+	call := &llms.ContentResponse{Choices: []*llms.ContentChoice{
+		{ToolCalls: []llms.ToolCall{toolCall("1", "weather", `{"city": "Paris"}`)}},
+	}}
+	model := &toolModel{responses: []*llms.ContentResponse{call, call}}
+	prompt := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Paris?")}
+
+	transcript, err := llms.RunTools(context.Background(), model, prompt, weatherToolset(), llms.WithMaxToolTurns(1))
+	if !errors.Is(err, llms.ErrMaxToolTurns) {
+		t.Fatalf("got error %v, want ErrMaxToolTurns", err)
+	}
+	// The calls of the last response are not in the transcript, as they
+	// have no responses.
+	if n := len(transcript); n != 3 {
+		t.Errorf("got %d messages, want 3", n)
+	}
+}
@@ -0,0 +1,338 @@
+package llms
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
+// StreamEventType identifies the kind of a StreamEvent.
+type StreamEventType string
+
+const (
+	// StreamEventTextDelta carries a fragment of the response text in Delta.
+	StreamEventTextDelta StreamEventType = "text_delta"
+	// StreamEventReasoningDelta carries a fragment of the model's reasoning in Delta.
+	StreamEventReasoningDelta StreamEventType = "reasoning_delta"
+	// StreamEventToolCallStart announces a new tool call. ToolCall holds its ID
+	// and function name; the arguments follow in StreamEventToolCallDelta events.
+	StreamEventToolCallStart StreamEventType = "tool_call_start"
+	// StreamEventToolCallDelta carries a fragment of a tool call's JSON arguments in Delta.
+	StreamEventToolCallDelta StreamEventType = "tool_call_delta"
+	// StreamEventToolCallEnd marks a tool call as complete. ToolCall holds the
+	// full call including its arguments.
+	StreamEventToolCallEnd StreamEventType = "tool_call_end"
+	// StreamEventUsage reports token usage for the request in Usage.
+	StreamEventUsage StreamEventType = "usage"
+	// StreamEventStop reports why the model stopped generating in StopReason.
+	StreamEventStop StreamEventType = "stop"
+)
+
+// StreamEvent is a typed event emitted while a model streams a response.
+type StreamEvent struct {
+	// Type is the kind of event.
+	Type StreamEventType
+
+	// Delta is the text, reasoning or tool call argument fragment of delta events.
+	Delta string
+
+	// Index is the position of the tool call within the response for tool call events.
+	Index int
+
+	// ToolCall is the tool call of StreamEventToolCallStart and StreamEventToolCallEnd events.
+	ToolCall *ToolCall
+
+	// Usage is the token usage of StreamEventUsage events.
+	Usage *Usage
+
+	// StopReason is the provider's stop reason of StreamEventStop events.
+	StopReason string
+
+	// FinishReason is the normalized stop reason of StreamEventStop events.
+	FinishReason StopReason
+}
+
+// StreamingModel is an interface for models that can stream typed events
+// while generating content.
+type StreamingModel interface {
+	Model
+
+	// StreamContent generates content from a sequence of messages like
+	// GenerateContent, yielding events as the response is produced. Iteration
+	// stops at the first error; breaking out of the loop cancels the request.
+	StreamContent(ctx context.Context, messages []MessageContent, options ...CallOption) iter.Seq2[StreamEvent, error]
+}
+
+// StreamContent streams the response of any model as typed events. Models
+// implementing StreamingModel are used directly. Other models report their
+// events through StreamingEventFunc, and the events they do not report are
+// derived from the final response.
+func StreamContent(ctx context.Context, model Model, messages []MessageContent, options ...CallOption) iter.Seq2[StreamEvent, error] {
+	if sm, ok := model.(StreamingModel); ok {
+		return sm.StreamContent(ctx, messages, options...)
+	}
+	return StreamEvents(ctx, model, messages, options...)
+}
+
+// StreamEvents runs GenerateContent on model with a StreamingEventFunc and
+// yields the events it reports. Events the model did not report, such as the
+// stop reason of a provider that only sends it in the final response, are
+// derived from the response once GenerateContent returns. Providers use it to
+// implement StreamingModel.
+func StreamEvents(ctx context.Context, model Model, messages []MessageContent, options ...CallOption) iter.Seq2[StreamEvent, error] {
+	return func(yield func(StreamEvent, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events := make(chan StreamEvent)
+		opts := slices.Clone(options)
+		opts = append(opts, WithStreamingEventFunc(func(_ context.Context, ev StreamEvent) error {
+			select {
+			case events <- ev:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}))
+
+		done := make(chan generateResult, 1)
+		go func() {
+			resp, err := model.GenerateContent(ctx, messages, opts...)
+			done <- generateResult{resp: resp, err: err}
+		}()
+
+		var seen streamSeen
+		for {
+			select {
+			case ev := <-events:
+				if ev.Type == StreamEventStop && ev.FinishReason == "" {
+					ev.FinishReason = NormalizeStopReason(ev.StopReason)
+				}
+				seen.observe(ev)
+				if !yield(ev, nil) {
+					return
+				}
+			case res := <-done:
+				if res.err != nil {
+					yield(StreamEvent{}, res.err)
+					return
+				}
+				for _, ev := range seen.missing(res.resp) {
+					if !yield(ev, nil) {
+						return
+					}
+				}
+				return
+			}
+		}
+	}
+}
+
+type generateResult struct {
+	resp *ContentResponse
+	err  error
+}
+
+// IsStreaming reports whether any of the streaming functions is set, in which
+// case providers stream the response.
+func (o *CallOptions) IsStreaming() bool {
+	return o.StreamingFunc != nil || o.StreamingReasoningFunc != nil || o.StreamingEventFunc != nil
+}
+
+// StreamEventHandler returns EmitStreamEvent if any of the streaming
+// functions is set, and nil otherwise, for provider clients that stream the
+// response when given a function to report its events to.
+func (o *CallOptions) StreamEventHandler() func(context.Context, StreamEvent) error {
+	if !o.IsStreaming() {
+		return nil
+	}
+	return o.EmitStreamEvent
+}
+
+// TextStreamHandler returns a function reporting chunks of text as text delta
+// events with EmitStreamEvent if any of the streaming functions is set, and
+// nil otherwise, for provider clients that only stream text.
+func (o *CallOptions) TextStreamHandler() func(context.Context, []byte) error {
+	if !o.IsStreaming() {
+		return nil
+	}
+	return func(ctx context.Context, chunk []byte) error {
+		return o.EmitStreamEvent(ctx, StreamEvent{Type: StreamEventTextDelta, Delta: string(chunk)})
+	}
+}
+
+// EmitStreamEvent reports an event of a streamed response to the streaming
+// functions of the options. StreamingEventFunc receives every event, while
+// StreamingFunc and StreamingReasoningFunc receive the text and reasoning
+// deltas. Providers report streamed output only through it, so that all the
+// streaming functions see the same output, except for the OpenAI provider
+// which also passes the JSON of streamed tool calls to StreamingFunc, as it
+// did before stream events.
+func (o *CallOptions) EmitStreamEvent(ctx context.Context, ev StreamEvent) error {
+	switch ev.Type {
+	case StreamEventTextDelta:
+		if ev.Delta == "" {
+			return nil
+		}
+		if o.StreamingFunc != nil {
+			if err := o.StreamingFunc(ctx, []byte(ev.Delta)); err != nil {
+				return err
+			}
+		}
+		if o.StreamingReasoningFunc != nil {
+			if err := o.StreamingReasoningFunc(ctx, nil, []byte(ev.Delta)); err != nil {
+				return err
+			}
+		}
+	case StreamEventReasoningDelta:
+		if ev.Delta == "" {
+			return nil
+		}
+		if o.StreamingReasoningFunc != nil {
+			if err := o.StreamingReasoningFunc(ctx, []byte(ev.Delta), nil); err != nil {
+				return err
+			}
+		}
+	case StreamEventStop:
+		if ev.FinishReason == "" {
+			ev.FinishReason = NormalizeStopReason(ev.StopReason)
+		}
+	}
+	if o.StreamingEventFunc == nil {
+		return nil
+	}
+	return o.StreamingEventFunc(ctx, ev)
+}
+
+// streamSeen records which kinds of events a model reported while streaming.
+type streamSeen struct {
+	text, reasoning, toolCalls, usage, stop bool
+}
+
+func (s *streamSeen) observe(ev StreamEvent) {
+	switch ev.Type {
+	case StreamEventTextDelta:
+		s.text = true
+	case StreamEventReasoningDelta:
+		s.reasoning = true
+	case StreamEventToolCallStart, StreamEventToolCallDelta, StreamEventToolCallEnd:
+		s.toolCalls = true
+	case StreamEventUsage:
+		s.usage = true
+	case StreamEventStop:
+		s.stop = true
+	}
+}
+
+// missing derives the events that were not reported while streaming from the
+// final response.
+func (s *streamSeen) missing(resp *ContentResponse) []StreamEvent {
+	if resp == nil {
+		return nil
+	}
+	var events []StreamEvent
+	if !s.reasoning {
+		for _, c := range resp.Choices {
+			if c.ReasoningContent != "" {
+				events = append(events, StreamEvent{Type: StreamEventReasoningDelta, Delta: c.ReasoningContent})
+			}
+		}
+	}
+	if !s.text {
+		for _, c := range resp.Choices {
+			if c.Content != "" {
+				events = append(events, StreamEvent{Type: StreamEventTextDelta, Delta: c.Content})
+			}
+		}
+	}
+	if !s.toolCalls {
+		index := 0
+		for _, c := range resp.Choices {
+			for _, tc := range c.ToolCalls {
+				events = append(events, ToolCallEvents(index, tc)...)
+				index++
+			}
+		}
+	}
+	if !s.usage {
+		for _, c := range resp.Choices {
+			if c.Usage != nil {
+				usage := *c.Usage
+				events = append(events, StreamEvent{Type: StreamEventUsage, Usage: &usage})
+				break
+			}
+		}
+	}
+	if !s.stop {
+		for _, c := range resp.Choices {
+			if c.StopReason != "" || c.FinishReason != "" {
+				finishReason := c.FinishReason
+				if finishReason == "" {
+					finishReason = NormalizeStopReason(c.StopReason)
+				}
+				events = append(events, StreamEvent{
+					Type:         StreamEventStop,
+					StopReason:   c.StopReason,
+					FinishReason: finishReason,
+				})
+				break
+			}
+		}
+	}
+	return events
+}
+
+// ToolCallEvents returns the start, argument and end events of a complete
+// tool call, for providers that receive tool calls in one piece.
+func ToolCallEvents(index int, tc ToolCall) []StreamEvent {
+	start := ToolCall{ID: tc.ID, Type: tc.Type}
+	var args string
+	if tc.FunctionCall != nil {
+		start.FunctionCall = &FunctionCall{Name: tc.FunctionCall.Name}
+		args = tc.FunctionCall.Arguments
+	}
+	end := tc
+	events := []StreamEvent{{Type: StreamEventToolCallStart, Index: index, ToolCall: &start}}
+	if args != "" {
+		events = append(events, StreamEvent{Type: StreamEventToolCallDelta, Index: index, Delta: args})
+	}
+	return append(events, StreamEvent{Type: StreamEventToolCallEnd, Index: index, ToolCall: &end})
+}
+
+// TrackStreaming returns options that replace the streaming functions set by
+// options with ones that record whether any output was passed to them, and a
+// function reporting whether that happened. Wrappers that retry or fail over
+// requests use it to avoid sending the output of two attempts to the caller.
+func TrackStreaming(options []CallOption) ([]CallOption, func() bool) {
+	var opts CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var streamed atomic.Bool
+	var wrapped []CallOption
+	if fn := opts.StreamingFunc; fn != nil {
+		wrapped = append(wrapped, WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			if len(chunk) > 0 {
+				streamed.Store(true)
+			}
+			return fn(ctx, chunk)
+		}))
+	}
+	if fn := opts.StreamingReasoningFunc; fn != nil {
+		wrapped = append(wrapped, WithStreamingReasoningFunc(func(ctx context.Context, reasoningChunk, chunk []byte) error {
+			if len(reasoningChunk) > 0 || len(chunk) > 0 {
+				streamed.Store(true)
+			}
+			return fn(ctx, reasoningChunk, chunk)
+		}))
+	}
+	if fn := opts.StreamingEventFunc; fn != nil {
+		wrapped = append(wrapped, WithStreamingEventFunc(func(ctx context.Context, event StreamEvent) error {
+			streamed.Store(true)
+			return fn(ctx, event)
+		}))
+	}
+	return append(slices.Clone(options), wrapped...), streamed.Load
+}
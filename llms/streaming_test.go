@@ -0,0 +1,210 @@
+package llms_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// streamTestModel reports its events through StreamingEventFunc and returns
+// resp.
+type streamTestModel struct {
+	events []llms.StreamEvent
+	resp   *llms.ContentResponse
+	err    error
+}
+
+func (m *streamTestModel) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	for _, event := range m.events {
+		if opts.StreamingEventFunc != nil {
+			if err := opts.StreamingEventFunc(ctx, event); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m.resp, m.err
+}
+
+func (m *streamTestModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func collectEvents(stream iter.Seq2[llms.StreamEvent, error]) ([]llms.StreamEvent, error) {
+	var events []llms.StreamEvent
+	for event, err := range stream {
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func TestStreamContentFallback(t *testing.T) {
+	t.Parallel()
+
+	model := &streamTestModel{
+		resp: &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+			Content:    "Hello",
+			StopReason: "tool_calls",
+			Usage:      llms.NewUsage(10, 5),
+			ToolCalls: []llms.ToolCall{{
+				ID:           "call_1",
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: "lookup", Arguments: `{"q":"x"}`},
+			}},
+		}}},
+	}
+
+	events, err := collectEvents(llms.StreamContent(context.Background(), model, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// All the events are derived from the response of a model that reports
+	// none.
+	want := []llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Delta: "Hello"},
+		{Type: llms.StreamEventToolCallStart, ToolCall: &llms.ToolCall{
+			ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "lookup"},
+		}},
+		{Type: llms.StreamEventToolCallDelta, Delta: `{"q":"x"}`},
+		{Type: llms.StreamEventToolCallEnd, ToolCall: &llms.ToolCall{
+			ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "lookup", Arguments: `{"q":"x"}`},
+		}},
+		{Type: llms.StreamEventUsage, Usage: llms.NewUsage(10, 5)},
+		{Type: llms.StreamEventStop, StopReason: "tool_calls", FinishReason: llms.StopReasonToolUse},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events mismatch:\ngot:  %+v\nwant: %+v", events, want)
+	}
+}
+
+func TestStreamEventsReportedByModel(t *testing.T) {
+	t.Parallel()
+
+	model := &streamTestModel{
+		events: []llms.StreamEvent{
+			{Type: llms.StreamEventTextDelta, Delta: "Hi"},
+			{Type: llms.StreamEventStop, StopReason: "stop"},
+		},
+		resp: &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "Hi", StopReason: "stop"}}},
+	}
+
+	events, err := collectEvents(llms.StreamEvents(context.Background(), model, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Events reported by the model are not repeated from the final response,
+	// and stop events gain a normalized reason.
+	want := []llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Delta: "Hi"},
+		{Type: llms.StreamEventStop, StopReason: "stop", FinishReason: llms.StopReasonEndTurn},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events mismatch:\ngot:  %+v\nwant: %+v", events, want)
+	}
+}
+
+func TestStreamEventsError(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("boom")
+	model := &streamTestModel{
+		events: []llms.StreamEvent{{Type: llms.StreamEventTextDelta, Delta: "partial"}},
+		err:    wantErr,
+	}
+
+	events, err := collectEvents(llms.StreamEvents(context.Background(), model, nil))
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected the partial event before the error, got %+v", events)
+	}
+}
+
+func TestStreamEventsBreakCancels(t *testing.T) {
+	t.Parallel()
+
+	model := &streamTestModel{
+		events: []llms.StreamEvent{
+			{Type: llms.StreamEventTextDelta, Delta: "one"},
+			{Type: llms.StreamEventTextDelta, Delta: "two"},
+		},
+		resp: &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "onetwo"}}},
+	}
+
+	var got []string
+	for event, err := range llms.StreamEvents(context.Background(), model, nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, event.Delta)
+		break
+	}
+	if !reflect.DeepEqual(got, []string{"one"}) {
+		t.Errorf("expected a single event, got %v", got)
+	}
+}
+
+func TestEmitStreamEvent(t *testing.T) {
+	t.Parallel()
+
+	var text, reasoning []string
+	var events []llms.StreamEvent
+	opts := &llms.CallOptions{}
+	for _, opt := range []llms.CallOption{
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			text = append(text, string(chunk))
+			return nil
+		}),
+		llms.WithStreamingReasoningFunc(func(_ context.Context, reasoningChunk, chunk []byte) error {
+			reasoning = append(reasoning, string(reasoningChunk)+"|"+string(chunk))
+			return nil
+		}),
+		llms.WithStreamingEventFunc(func(_ context.Context, event llms.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		}),
+	} {
+		opt(opts)
+	}
+
+	for _, event := range []llms.StreamEvent{
+		{Type: llms.StreamEventReasoningDelta, Delta: "Think"},
+		{Type: llms.StreamEventTextDelta, Delta: "Hel"},
+		{Type: llms.StreamEventTextDelta},
+		{Type: llms.StreamEventTextDelta, Delta: "lo"},
+		{Type: llms.StreamEventStop, StopReason: "stop"},
+	} {
+		if err := opts.EmitStreamEvent(context.Background(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Each function sees the same output, without empty deltas.
+	if !reflect.DeepEqual(text, []string{"Hel", "lo"}) {
+		t.Errorf("unexpected text chunks: %q", text)
+	}
+	if !reflect.DeepEqual(reasoning, []string{"Think|", "|Hel", "|lo"}) {
+		t.Errorf("unexpected reasoning chunks: %q", reasoning)
+	}
+	want := []llms.StreamEvent{
+		{Type: llms.StreamEventReasoningDelta, Delta: "Think"},
+		{Type: llms.StreamEventTextDelta, Delta: "Hel"},
+		{Type: llms.StreamEventTextDelta, Delta: "lo"},
+		{Type: llms.StreamEventStop, StopReason: "stop", FinishReason: llms.StopReasonEndTurn},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events mismatch:\ngot:  %+v\nwant: %+v", events, want)
+	}
+}
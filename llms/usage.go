@@ -0,0 +1,100 @@
+package llms
+
+import "strings"
+
+// Usage is the token usage reported by a provider for a single generation.
+// Counts a provider does not report are left at zero.
+type Usage struct {
+	// InputTokens is the number of tokens in the prompt, including any tokens
+	// read from or written to the prompt cache.
+	InputTokens int `json:"input_tokens"`
+	// OutputTokens is the number of tokens generated by the model, including
+	// reasoning tokens.
+	OutputTokens int `json:"output_tokens"`
+	// TotalTokens is the total number of tokens used by the request.
+	TotalTokens int `json:"total_tokens"`
+	// CacheReadTokens is the number of input tokens read from the prompt cache.
+	CacheReadTokens int `json:"cache_read_tokens,omitempty"`
+	// CacheWriteTokens is the number of input tokens written to the prompt cache.
+	CacheWriteTokens int `json:"cache_write_tokens,omitempty"`
+	// ReasoningTokens is the number of output tokens spent on reasoning.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+}
+
+// NewUsage returns a Usage for the given input and output token counts with
+// TotalTokens set to their sum.
+func NewUsage(inputTokens, outputTokens int) *Usage {
+	return &Usage{
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		TotalTokens:  inputTokens + outputTokens,
+	}
+}
+
+// StopReason is a provider-independent reason for a model to stop generating.
+type StopReason string
+
+const (
+	// StopReasonEndTurn means the model finished its response naturally.
+	StopReasonEndTurn StopReason = "end_turn"
+	// StopReasonMaxTokens means the response hit the output token limit.
+	StopReasonMaxTokens StopReason = "max_tokens"
+	// StopReasonStopSequence means the model produced one of the stop words.
+	StopReasonStopSequence StopReason = "stop_sequence"
+	// StopReasonToolUse means the model stopped to call one or more tools.
+	StopReasonToolUse StopReason = "tool_use"
+	// StopReasonContentFilter means the response was cut off by a safety filter.
+	StopReasonContentFilter StopReason = "content_filter"
+	// StopReasonOther is any provider stop reason without a normalized equivalent.
+	StopReasonOther StopReason = "other"
+)
+
+// nolint:gochecknoglobals
+var providerStopReasons = map[string]StopReason{
+	// Natural completion.
+	"stop":        StopReasonEndTurn,
+	"end_turn":    StopReasonEndTurn,
+	"complete":    StopReasonEndTurn,
+	"finish":      StopReasonEndTurn,
+	"endoftext":   StopReasonEndTurn,
+	"eos":         StopReasonEndTurn,
+	"eos_token":   StopReasonEndTurn,
+	"end_of_text": StopReasonEndTurn,
+	"normal":      StopReasonEndTurn,
+	// Output token limit.
+	"length":       StopReasonMaxTokens,
+	"max_tokens":   StopReasonMaxTokens,
+	"max_length":   StopReasonMaxTokens,
+	"token_limit":  StopReasonMaxTokens,
+	"model_length": StopReasonMaxTokens,
+	// Stop words.
+	"stop_sequence":     StopReasonStopSequence,
+	"stop_sequences":    StopReasonStopSequence,
+	"stop_sequence_hit": StopReasonStopSequence,
+	// Tool calls.
+	"tool_calls":    StopReasonToolUse,
+	"tool_use":      StopReasonToolUse,
+	"function_call": StopReasonToolUse,
+	// Safety filters.
+	"content_filter":       StopReasonContentFilter,
+	"content_filtered":     StopReasonContentFilter,
+	"safety":               StopReasonContentFilter,
+	"recitation":           StopReasonContentFilter,
+	"blocklist":            StopReasonContentFilter,
+	"prohibited_content":   StopReasonContentFilter,
+	"spii":                 StopReasonContentFilter,
+	"guardrail_intervened": StopReasonContentFilter,
+}
+
+// NormalizeStopReason maps a provider-specific stop or finish reason, such as
+// OpenAI's "length" or Gemini's "MAX_TOKENS", to a StopReason. It returns the
+// empty string for an empty reason and StopReasonOther for unknown reasons.
+func NormalizeStopReason(reason string) StopReason {
+	if reason == "" {
+		return ""
+	}
+	if r, ok := providerStopReasons[strings.ToLower(reason)]; ok {
+		return r
+	}
+	return StopReasonOther
+}
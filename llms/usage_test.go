@@ -0,0 +1,46 @@
+package llms_test
+
+import (
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestNormalizeStopReason(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		reason string
+		want   llms.StopReason
+	}{
+		{"", ""},
+		{"stop", llms.StopReasonEndTurn},
+		{"end_turn", llms.StopReasonEndTurn},
+		{"COMPLETE", llms.StopReasonEndTurn},
+		{"EOS_TOKEN", llms.StopReasonEndTurn},
+		{"length", llms.StopReasonMaxTokens},
+		{"MAX_TOKENS", llms.StopReasonMaxTokens},
+		{"stop_sequence", llms.StopReasonStopSequence},
+		{"tool_calls", llms.StopReasonToolUse},
+		{"tool_use", llms.StopReasonToolUse},
+		{"content_filter", llms.StopReasonContentFilter},
+		{"SAFETY", llms.StopReasonContentFilter},
+		{"guardrail_intervened", llms.StopReasonContentFilter},
+		{"something_new", llms.StopReasonOther},
+	}
+	for _, tt := range tests {
+		if got := llms.NormalizeStopReason(tt.reason); got != tt.want {
+			t.Errorf("NormalizeStopReason(%q) = %q, want %q", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestNewUsage(t *testing.T) {
+	t.Parallel()
+
+	got := llms.NewUsage(12, 30)
+	want := &llms.Usage{InputTokens: 12, OutputTokens: 30, TotalTokens: 42}
+	if *got != *want {
+		t.Errorf("NewUsage(12, 30) = %+v, want %+v", got, want)
+	}
+}
@@ -57,9 +57,13 @@ func (wx *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConte
 	}
 
 	resp := &llms.ContentResponse{
+		Model: wx.modelID,
 		Choices: []*llms.ContentChoice{
 			{
-				Content: result.Text,
+				Content:      result.Text,
+				StopReason:   result.StopReason,
+				FinishReason: llms.NormalizeStopReason(result.StopReason),
+				Usage:        llms.NewUsage(result.InputTokenCount, result.GeneratedTokenCount),
 			},
 		},
 	}
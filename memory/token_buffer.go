@@ -12,6 +12,9 @@ type ConversationTokenBuffer struct {
 	ConversationBuffer
 	LLM           llms.Model
 	MaxTokenLimit int
+	// ModelName is the name of the model used to count tokens with its
+	// tokenizer from the model registry. If empty, tokens are approximated.
+	ModelName string
 }
 
 // Statically assert that ConversationTokenBuffer implement the memory interface.
@@ -105,5 +108,5 @@ func (tb *ConversationTokenBuffer) getNumTokensFromMessages(ctx context.Context)
 		return 0, err
 	}
 
-	return llms.CountTokens("", bufferString), nil
+	return llms.CountTokens(tb.ModelName, bufferString), nil
 }
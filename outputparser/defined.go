@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/schema"
 )
@@ -14,8 +16,28 @@ import (
 // Defined parses JSON output from an LLM into Go structs. By providing
 // the NewDefined constructor with a struct, one or more TypeScript interfaces
 // are generated to help LLMs format responses with the desired JSON structure.
+// Parsed output is validated against the JSON schema of the struct with the
+// WithValidation option.
 type Defined[T any] struct {
-	schema string
+	schema     string
+	jsonSchema *jsonschema.Definition
+	validate   bool
+}
+
+// DefinedOption is an option of NewDefined.
+type DefinedOption func(*definedOptions)
+
+type definedOptions struct {
+	validate bool
+}
+
+// WithValidation validates the parsed output against the JSON schema of the
+// struct, so that output with missing or unknown properties, or values out
+// of their bounds, is rejected.
+func WithValidation() DefinedOption {
+	return func(o *definedOptions) {
+		o.validate = true
+	}
 }
 
 // NewDefined creates an output parser that structures data according to
@@ -23,8 +45,12 @@ type Defined[T any] struct {
 // field with "json" will explicitly use that value as the field name. Tagging
 // with "describe" will add a line comment for the LLM to understand how to
 // generate data, helpful when the field's name is insufficient.
-func NewDefined[T any](source T) (Defined[T], error) {
+func NewDefined[T any](source T, opts ...DefinedOption) (Defined[T], error) {
 	var empty Defined[T]
+	var o definedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 
 	sourceType := reflect.TypeOf(source)
 	if k := sourceType.Kind(); k != reflect.Struct {
@@ -46,7 +72,28 @@ func NewDefined[T any](source T) (Defined[T], error) {
 	default:
 		return empty, fmt.Errorf("unable to marshal '%s' field type", sourceType.Kind())
 	}
-	return Defined[T]{result.String()}, nil
+	jsonSchema, err := reflectSchema(source)
+	if err != nil {
+		return empty, err
+	}
+	return Defined[T]{schema: result.String(), jsonSchema: jsonSchema, validate: o.validate}, nil
+}
+
+// reflectSchema returns the JSON schema of source, or the error of the
+// invalid tags jsonschema.Reflect panics on.
+func reflectSchema(source any) (def *jsonschema.Definition, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return jsonschema.Reflect(source), nil
+}
+
+// JSONSchema returns the JSON schema of the struct, as derived by
+// jsonschema.Reflect. It can be passed to models supporting structured output.
+func (p Defined[T]) JSONSchema() *jsonschema.Definition {
+	return p.jsonSchema
 }
 
 var _ schema.OutputParser[any] = Defined[any]{}
@@ -57,17 +104,21 @@ func (p Defined[T]) GetFormatInstructions() string {
 	return fmt.Sprintf(instructions, p.schema)
 }
 
-// Parse parses the output of an LLM call.
+// Parse parses the output of an LLM call: a JSON object, in a Markdown code
+// block as asked by the format instructions or as returned by models with
+// structured output support.
 func (p Defined[T]) Parse(text string) (T, error) {
 	var target T
 
-	// Removes '```json' and '```' from the start and end of the text.
-	const opening = "```json"
-	const closing = "```"
-	if text[:len(opening)] != opening || text[len(text)-len(closing):] != closing {
-		return target, fmt.Errorf("input text should start with %s and end with %s", opening, closing)
+	parseableJSON := extractJSON(text)
+	if parseableJSON == "" {
+		return target, errors.New("no JSON object found in the output")
+	}
+	if p.validate {
+		if err := jsonschema.Validate(p.jsonSchema, []byte(parseableJSON)); err != nil {
+			return target, fmt.Errorf("generated JSON does not match the schema: %w", err)
+		}
 	}
-	parseableJSON := text[len(opening) : len(text)-len(closing)]
 	if err := json.Unmarshal([]byte(parseableJSON), &target); err != nil {
 		return target, fmt.Errorf("could not parse generated JSON: %w", err)
 	}
@@ -94,27 +145,34 @@ func marshalStruct(vType reflect.Type, name string) ([]byte, error) { // nolint:
 	moreStructs := make([][]byte, 0, numStructs)
 	for i := 0; i < vType.NumField(); i++ {
 		field := vType.Field(i)
-		b.WriteString("\t")
-		name := field.Tag.Get("json")
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
 		if name == "" {
 			name = field.Name
 		}
+		b.WriteString("\t")
 		b.WriteString(name)
 		b.WriteString(": ")
-		typeName := field.Type.Name()
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		typeName := fieldType.Name()
 		if typeName == "" {
 			typeName = field.Name
 		}
-		switch field.Type.Kind() { // nolint:exhaustive
+		switch fieldType.Kind() { // nolint:exhaustive
 		case reflect.Struct:
-			marshaled, err := marshalStruct(field.Type, typeName)
+			marshaled, err := marshalStruct(fieldType, typeName)
 			if err != nil {
 				return []byte{}, err
 			}
 			moreStructs = append(moreStructs, marshaled)
 			b.WriteString(typeName)
 		case reflect.Array, reflect.Slice:
-			elemType := field.Type.Elem()
+			elemType := fieldType.Elem()
 			switch elemType.Kind() { // nolint:exhaustive
 			case reflect.Struct:
 				marshaled, err := marshalStruct(elemType, typeName)
@@ -124,7 +182,7 @@ func marshalStruct(vType reflect.Type, name string) ([]byte, error) { // nolint:
 				moreStructs = append(moreStructs, marshaled)
 				b.WriteString(typeName)
 			default:
-				b.WriteString(field.Type.Elem().Kind().String())
+				b.WriteString(elemType.Kind().String())
 			}
 			b.WriteString("[]")
 		default:
@@ -144,3 +202,21 @@ func marshalStruct(vType reflect.Type, name string) ([]byte, error) { // nolint:
 	}
 	return b.Bytes(), nil
 }
+
+// extractJSON returns the JSON object in text, removing Markdown code fences
+// and any text around it.
+func extractJSON(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.Index(text, "```"); i >= 0 {
+		rest := strings.TrimPrefix(text[i+3:], "json")
+		if j := strings.Index(rest, "```"); j >= 0 {
+			text = strings.TrimSpace(rest[:j])
+		}
+	}
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return ""
+	}
+	return text[start : end+1]
+}
@@ -58,6 +58,19 @@ interface Shape {
 			expected: `interface _Root {
 	shape: Shape; // most common 4 sided shape
 }
+interface Shape {
+	shapeName: string; // shape name
+	numSides: int; // number of sides
+}`,
+		},
+		"pointer field and tag options": {
+			input: struct {
+				Shape  *Shape `json:"shape,omitempty"`
+				Hidden string `json:"-"`
+			}{},
+			expected: `interface _Root {
+	shape: Shape;
+}
 interface Shape {
 	shapeName: string; // shape name
 	numSides: int; // number of sides
@@ -130,4 +143,50 @@ func TestDefinedParse(t *testing.T) {
 			t.Errorf("got '%s'; want '%s'", chapter.Title, title)
 		}
 	}
+
+	// Output of models with structured output support is not in a code block.
+	raw, parseErr := parser.Parse(`{"chapters": [{"title": "Only"}]}`)
+	if parseErr != nil {
+		t.Error(parseErr)
+	}
+	if count := len(raw.Chapters); count != 1 {
+		t.Errorf("got %d chapters; want 1", count)
+	}
+}
+
+func TestDefinedParseValidates(t *testing.T) {
+	t.Parallel()
+	type Shape struct {
+		Name     string `json:"shapeName"`
+		NumSides int    `json:"numSides" jsonschema:"minimum=3"`
+	}
+	// Output is only validated with WithValidation.
+	lenient, err := NewDefined(Shape{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lenient.Parse("```json\n{\"numSides\": 4, \"color\": \"red\"}\n```"); err != nil {
+		t.Errorf("unexpected error without validation: %v", err)
+	}
+
+	parser, err := NewDefined(Shape{}, WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.Parse("```json\n{\"shapeName\": \"line\", \"numSides\": 1}\n```"); err == nil {
+		t.Error("missing expected error for a value below the minimum")
+	}
+	if _, err := parser.Parse("```json\n{\"numSides\": 4}\n```"); err == nil {
+		t.Error("missing expected error for a missing property")
+	}
+	if got := parser.JSONSchema().Required; len(got) != 2 {
+		t.Errorf("got required properties %v; want 2", got)
+	}
+
+	type Invalid struct {
+		NumSides int `json:"numSides" jsonschema:"minimum=three"`
+	}
+	if _, err := NewDefined(Invalid{}); err == nil {
+		t.Error("missing expected error for an invalid tag")
+	}
 }
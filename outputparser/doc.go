@@ -17,5 +17,8 @@ The outputparser package includes the following parsers:
     and returns map[string]string of the regex groups.
   - RegexDict: a parser that searches a string for values in a dictionary format,
     and returns a map[string]string of the keys and their associated value.
+
+GenerateStructured generates values of Go types with a model, using its
+structured output support or the format instructions of a Defined parser.
 */
 package outputparser
@@ -0,0 +1,216 @@
+package outputparser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ErrInvalidStructuredOutput is returned by GenerateStructured when the model
+// does not respond with JSON matching the schema.
+var ErrInvalidStructuredOutput = errors.New("invalid structured output")
+
+const _defaultStructuredOutputRetries = 2
+
+// Validator can be implemented by the types generated with
+// GenerateStructured to check the values they decode. Validation errors are
+// sent back to the model to fix.
+type Validator interface {
+	Validate() error
+}
+
+// GenerateStructured asks the model to respond with JSON matching the schema
+// of T, and decodes the response into a T.
+//
+// The response is parsed and validated by a Defined parser of T, whose JSON
+// schema is passed to the model with llms.WithResponseSchema, for providers
+// with structured output support, and whose format instructions are added to
+// the prompt for the others. Types other than structs are generated as the "value" property
+// of an object. Set a ResponseSchema with llms.WithResponseSchema to use
+// another schema or name; responses are validated against it too.
+//
+// Responses that are not valid JSON, don't match the schema or fail the
+// Validate method of T are sent back to the model with the error, up to the
+// number of times set by llms.WithStructuredOutputRetries.
+func GenerateStructured[T any](ctx context.Context, model llms.Model, messages []llms.MessageContent, options ...llms.CallOption) (T, error) { //nolint:lll
+	var zero T
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	parser, err := newStructuredParser[T]()
+	if err != nil {
+		return zero, err
+	}
+	schema := opts.ResponseSchema
+	var custom *jsonschema.Definition
+	if schema == nil {
+		schema = &llms.ResponseSchema{Name: structuredName(reflect.TypeFor[T]()), Schema: parser.schema}
+	} else if custom, err = schemaDefinition(schema); err != nil {
+		return zero, fmt.Errorf("decode response schema: %w", err)
+	}
+	retries := _defaultStructuredOutputRetries
+	if opts.StructuredOutputRetries != nil {
+		retries = max(*opts.StructuredOutputRetries, 0)
+	}
+
+	msgs := withInstructions(messages, parser.instructions)
+	options = append(slices.Clone(options), llms.WithResponseSchema(schema))
+	for attempt := 0; ; attempt++ {
+		resp, err := model.GenerateContent(ctx, msgs, options...)
+		if err != nil {
+			return zero, err
+		}
+		text := structuredText(resp)
+		value, err := parser.parse(text, custom)
+		if err == nil {
+			return value, nil
+		}
+		if attempt >= retries {
+			return zero, fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+		}
+		msgs = append(msgs,
+			llms.TextParts(llms.ChatMessageTypeAI, text),
+			llms.TextParts(llms.ChatMessageTypeHuman, fmt.Sprintf(_structuredRepairPrompt, err)),
+		)
+	}
+}
+
+const _structuredRepairPrompt = "Your response is invalid: %v. Respond again with only the corrected JSON."
+
+// structuredValue wraps the values of types other than structs, as
+// providers require an object schema.
+type structuredValue[T any] struct {
+	Value T `json:"value"`
+}
+
+// structuredParser parses the responses of GenerateStructured with a Defined
+// parser.
+type structuredParser[T any] struct {
+	instructions string
+	schema       *jsonschema.Definition
+	parse        func(text string, custom *jsonschema.Definition) (T, error)
+}
+
+func newStructuredParser[T any]() (structuredParser[T], error) {
+	var zero T
+	if reflect.TypeFor[T]().Kind() == reflect.Struct {
+		defined, err := NewDefined(zero, WithValidation())
+		if err != nil {
+			return structuredParser[T]{}, err
+		}
+		return structuredParser[T]{
+			instructions: defined.GetFormatInstructions(),
+			schema:       defined.JSONSchema(),
+			parse: func(text string, custom *jsonschema.Definition) (T, error) {
+				return parseDefined(defined, text, custom)
+			},
+		}, nil
+	}
+	defined, err := NewDefined(structuredValue[T]{}, WithValidation())
+	if err != nil {
+		return structuredParser[T]{}, err
+	}
+	return structuredParser[T]{
+		instructions: defined.GetFormatInstructions(),
+		schema:       defined.JSONSchema(),
+		parse: func(text string, custom *jsonschema.Definition) (T, error) {
+			v, err := parseDefined(defined, text, custom)
+			return v.Value, err
+		},
+	}, nil
+}
+
+// parseDefined parses text with defined, validates it against the custom
+// schema, if any, and with the Validate method of T.
+func parseDefined[T any](defined Defined[T], text string, custom *jsonschema.Definition) (T, error) {
+	value, err := defined.Parse(text)
+	if err != nil {
+		return value, err
+	}
+	if custom != nil {
+		if err := jsonschema.Validate(custom, []byte(extractJSON(text))); err != nil {
+			return value, fmt.Errorf("generated JSON does not match the schema: %w", err)
+		}
+	}
+	if v, ok := any(&value).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return value, err
+		}
+	}
+	return value, nil
+}
+
+// withInstructions returns messages with the instructions added to the last
+// human message.
+func withInstructions(messages []llms.MessageContent, text string) []llms.MessageContent {
+	instructions := llms.TextContent{Text: text}
+	msgs := slices.Clone(messages)
+	if n := len(msgs); n > 0 && msgs[n-1].Role == llms.ChatMessageTypeHuman {
+		last := msgs[n-1]
+		last.Parts = append(slices.Clone(last.Parts), instructions)
+		msgs[n-1] = last
+		return msgs
+	}
+	return append(msgs, llms.MessageContent{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{instructions}})
+}
+
+// structuredText returns the JSON text of a response. Providers that use a
+// forced tool call for structured output return it as the tool arguments.
+func structuredText(resp *llms.ContentResponse) string {
+	if resp == nil {
+		return ""
+	}
+	for _, c := range resp.Choices {
+		if strings.TrimSpace(c.Content) != "" {
+			return c.Content
+		}
+	}
+	for _, c := range resp.Choices {
+		for _, tc := range c.ToolCalls {
+			if tc.FunctionCall != nil {
+				return tc.FunctionCall.Arguments
+			}
+		}
+	}
+	return ""
+}
+
+// structuredName returns the schema name of t, as accepted by providers.
+func structuredName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		return "response"
+	}
+	return name
+}
+
+// schemaDefinition returns the schema of s as a jsonschema.Definition.
+func schemaDefinition(s *llms.ResponseSchema) (*jsonschema.Definition, error) {
+	switch schema := s.Schema.(type) {
+	case *jsonschema.Definition:
+		return schema, nil
+	case jsonschema.Definition:
+		return &schema, nil
+	}
+	data, err := json.Marshal(s.Schema)
+	if err != nil {
+		return nil, err
+	}
+	def := &jsonschema.Definition{}
+	if err := json.Unmarshal(data, def); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
@@ -0,0 +1,150 @@
+package outputparser_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/outputparser"
+)
+
+// scriptedModel responds with its responses in order and records the
+// messages and options of each request.
+type scriptedModel struct {
+	responses []string
+	messages  [][]llms.MessageContent
+	options   []llms.CallOptions
+}
+
+func (m *scriptedModel) GenerateContent(_ context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.messages = append(m.messages, messages)
+	m.options = append(m.options, opts)
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: resp}}}, nil
+}
+
+func (m *scriptedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+type address struct {
+	City    string `json:"city" describe:"the city name"`
+	Country string `json:"country,omitempty"`
+}
+
+type person struct {
+	Name    string   `json:"name"`
+	Age     int      `json:"age"`
+	Address *address `json:"address"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+func (p *person) Validate() error {
+	if p.Age < 0 {
+		return errors.New("age must not be negative")
+	}
+	return nil
+}
+
+func TestGenerateStructured(t *testing.T) {
+	t.Parallel()
+
+	model := &scriptedModel{responses: []string{
+		"Here it is:\n```json\n{\"name\": \"Ada\", \"age\": 36, \"address\": {\"city\": \"London\"}}\n```",
+	}}
+	msgs := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Who wrote the first program?")}
+	got, err := outputparser.GenerateStructured[person](context.Background(), model, msgs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := person{Name: "Ada", Age: 36, Address: &address{City: "London"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	schema := model.options[0].ResponseSchema
+	if schema == nil || schema.Name != "person" {
+		t.Fatalf("response schema = %+v, want a schema named person", schema)
+	}
+	def, err := schema.SchemaMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if required := def["required"]; !reflect.DeepEqual(required, []any{"name", "age", "address"}) {
+		t.Errorf("required = %v, want [name age address]", required)
+	}
+	city := def["properties"].(map[string]any)["address"].(map[string]any)["properties"].(map[string]any)["city"]
+	if desc := city.(map[string]any)["description"]; desc != "the city name" {
+		t.Errorf("city description = %v, want %q", desc, "the city name")
+	}
+
+	parts := model.messages[0][0].Parts
+	if len(parts) != 2 || !strings.Contains(parts[1].(llms.TextContent).Text, "city: string; // the city name") {
+		t.Errorf("the format instructions were not added to the prompt: %+v", parts)
+	}
+}
+
+func TestGenerateStructuredRepair(t *testing.T) {
+	t.Parallel()
+
+	model := &scriptedModel{responses: []string{
+		`{"name": "Ada"}`,
+		`{"name": "Ada", "age": -1, "address": null}`,
+		`{"name": "Ada", "age": 36, "address": null}`,
+	}}
+	got, err := outputparser.GenerateStructured[person](context.Background(), model,
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Who?")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Age != 36 {
+		t.Errorf("got %+v, want age 36", got)
+	}
+	if n := len(model.messages); n != 3 {
+		t.Fatalf("got %d requests, want 3", n)
+	}
+	last := model.messages[2]
+	for i, want := range []string{`missing required property "age"`, "age must not be negative"} {
+		repair := last[2+2*i].Parts[0].(llms.TextContent).Text
+		if !strings.Contains(repair, want) {
+			t.Errorf("repair prompt %q does not contain %q", repair, want)
+		}
+	}
+}
+
+func TestGenerateStructuredRetriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	model := &scriptedModel{responses: []string{"no", "still no"}}
+	_, err := outputparser.GenerateStructured[person](context.Background(), model,
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Who?")},
+		llms.WithStructuredOutputRetries(1))
+	if !errors.Is(err, outputparser.ErrInvalidStructuredOutput) {
+		t.Fatalf("got error %v, want ErrInvalidStructuredOutput", err)
+	}
+	if n := len(model.messages); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
+
+func TestGenerateStructuredScalar(t *testing.T) {
+	t.Parallel()
+
+	model := &scriptedModel{responses: []string{`{"value": ["a", "b"]}`}}
+	got, err := outputparser.GenerateStructured[[]string](context.Background(), model,
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "List two letters.")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
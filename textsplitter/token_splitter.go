@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/pkoukk/tiktoken-go"
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -47,9 +48,12 @@ func (s TokenSplitter) SplitText(text string) ([]string, error) {
 	// Get the tokenizer
 	var tk *tiktoken.Tiktoken
 	var err error
-	if s.EncodingName != "" {
+	switch info, ok := llms.LookupModel(s.ModelName); {
+	case s.EncodingName != "":
 		tk, err = tiktoken.GetEncoding(s.EncodingName)
-	} else {
+	case ok && info.Tokenizer != "":
+		tk, err = tiktoken.GetEncoding(info.Tokenizer)
+	default:
 		tk, err = tiktoken.EncodingForModel(s.ModelName)
 	}
 	if err != nil {
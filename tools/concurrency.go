@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// ConcurrencySafe is implemented by tools telling whether their calls can run
+// concurrently. Agent executors running tool calls in parallel treat tools not
+// implementing it as safe, and run the calls of unsafe tools one at a time.
+type ConcurrencySafe interface {
+	ConcurrencySafe() bool
+}
+
+// IsConcurrencySafe reports whether the calls of a tool can run concurrently.
+func IsConcurrencySafe(t Tool) bool {
+	if cs, ok := t.(ConcurrencySafe); ok {
+		return cs.ConcurrencySafe()
+	}
+	return true
+}
+
+// Sequential returns a tool calling t whose calls are not run concurrently,
+// for tools that are not concurrency safe. The tool is a SchemaTool or a
+// RawSchemaTool when t is.
+func Sequential(t Tool) Tool { //nolint:ireturn
+	if rt, ok := t.(RawSchemaTool); ok {
+		return sequentialRawSchemaTool{sequentialSchemaTool{sequentialTool{rt}, rt}, rt}
+	}
+	if st, ok := t.(SchemaTool); ok {
+		return sequentialSchemaTool{sequentialTool{st}, st}
+	}
+	return sequentialTool{t}
+}
+
+type sequentialTool struct {
+	tool Tool
+}
+
+func (t sequentialTool) Name() string {
+	return t.tool.Name()
+}
+
+func (t sequentialTool) Description() string {
+	return t.tool.Description()
+}
+
+func (t sequentialTool) ConcurrencySafe() bool {
+	return false
+}
+
+func (t sequentialTool) Call(ctx context.Context, input string) (string, error) {
+	return t.tool.Call(ctx, input)
+}
+
+type sequentialSchemaTool struct {
+	sequentialTool
+	schemaTool SchemaTool
+}
+
+func (t sequentialSchemaTool) Schema() *jsonschema.Definition {
+	return t.schemaTool.Schema()
+}
+
+type sequentialRawSchemaTool struct {
+	sequentialSchemaTool
+	rawSchemaTool RawSchemaTool
+}
+
+func (t sequentialRawSchemaTool) RawSchema() json.RawMessage {
+	return t.rawSchemaTool.RawSchema()
+}
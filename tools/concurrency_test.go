@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// rawSchemaTool is a tool whose raw schema holds keywords its Definition
+// doesn't.
+type rawSchemaTool struct{}
+
+func (rawSchemaTool) Name() string        { return "round" }
+func (rawSchemaTool) Description() string { return "Rounds a number." }
+
+func (rawSchemaTool) Call(_ context.Context, input string) (string, error) {
+	return input, nil
+}
+
+func (rawSchemaTool) Schema() *jsonschema.Definition {
+	return &jsonschema.Definition{Type: jsonschema.Object}
+}
+
+func (rawSchemaTool) RawSchema() json.RawMessage {
+	return json.RawMessage(`{"type":"object","properties":{"digits":{"type":"integer","enum":[0,1,2]}}}`)
+}
+
+func TestSequential(t *testing.T) {
+	t.Parallel()
+
+	tool := Sequential(rawSchemaTool{})
+	require.False(t, IsConcurrencySafe(tool))
+	require.Implements(t, (*RawSchemaTool)(nil), tool)
+	data, err := json.Marshal(FunctionDefinition(tool).Parameters)
+	require.NoError(t, err)
+	require.JSONEq(t, string(rawSchemaTool{}.RawSchema()), string(data))
+
+	tool = Sequential(weatherFunc())
+	require.Implements(t, (*SchemaTool)(nil), tool)
+	require.NotImplements(t, (*RawSchemaTool)(nil), tool)
+	require.False(t, IsConcurrencySafe(Sequential(Calculator{})))
+}
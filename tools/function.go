@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// SchemaTool is a Tool whose input is a JSON object described by a JSON
+// schema. Models supporting tool calls are given the schema as the
+// parameters of the tool, and the arguments they generate are passed to Call.
+type SchemaTool interface {
+	Tool
+	// Schema returns the JSON schema of the input of the tool.
+	Schema() *jsonschema.Definition
+}
+
+// RawSchemaTool is a SchemaTool whose schema is also available as JSON. The
+// JSON is given to models as is, so that keywords jsonschema.Definition
+// doesn't support are kept.
+type RawSchemaTool interface {
+	SchemaTool
+	// RawSchema returns the JSON schema of the input of the tool.
+	RawSchema() json.RawMessage
+}
+
+// Func is a SchemaTool calling a Go function with its input decoded from JSON.
+type Func[In, Out any] struct {
+	name        string
+	description string
+	schema      *jsonschema.Definition
+	wrapped     bool
+	fn          func(ctx context.Context, input In) (Out, error)
+}
+
+var _ SchemaTool = (*Func[struct{}, string])(nil)
+
+// NewFunc returns a tool calling fn. The schema of the input is derived from
+// In with jsonschema.For, so its fields are described with json and
+// jsonschema tags. Inputs that are not objects are wrapped in an object with
+// an "input" property, as tool arguments must be objects.
+//
+// The output is returned as is when it is a string, and encoded to JSON
+// otherwise.
+func NewFunc[In, Out any](name, description string, fn func(ctx context.Context, input In) (Out, error)) *Func[In, Out] { //nolint:lll
+	schema := jsonschema.For[In]()
+	schema.Nullable = false
+	wrapped := false
+	if schema.Type != jsonschema.Object {
+		defs := schema.Defs
+		schema.Defs = nil
+		schema = &jsonschema.Definition{
+			Type:                 jsonschema.Object,
+			Properties:           map[string]jsonschema.Definition{"input": *schema},
+			Required:             []string{"input"},
+			AdditionalProperties: false,
+			Defs:                 defs,
+		}
+		wrapped = true
+	}
+	return &Func[In, Out]{
+		name:        name,
+		description: description,
+		schema:      schema,
+		wrapped:     wrapped,
+		fn:          fn,
+	}
+}
+
+// Name returns the name of the tool.
+func (f *Func[In, Out]) Name() string {
+	return f.name
+}
+
+// Description returns the description of the tool.
+func (f *Func[In, Out]) Description() string {
+	return f.description
+}
+
+// Schema returns the JSON schema of the input of the tool.
+func (f *Func[In, Out]) Schema() *jsonschema.Definition {
+	return f.schema
+}
+
+// Call decodes the JSON input, calls the function and encodes its output. If
+// the input does not match the schema the error is given in the result to
+// give the agent the ability to retry. Errors of the function are returned.
+func (f *Func[In, Out]) Call(ctx context.Context, input string) (string, error) {
+	in, err := f.decode(input)
+	if err != nil {
+		return fmt.Sprintf("invalid input: %s", err.Error()), nil //nolint:nilerr
+	}
+	out, err := f.fn(ctx, in)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := any(out).(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("encode output of tool %s: %w", f.name, err)
+	}
+	return string(data), nil
+}
+
+func (f *Func[In, Out]) decode(input string) (In, error) {
+	var in In
+	data := []byte(input)
+	if !json.Valid(data) {
+		// Agents without tool calls pass plain text, which is the input of
+		// functions taking a string.
+		if f.wrapped && reflect.TypeFor[In]().Kind() == reflect.String {
+			reflect.ValueOf(&in).Elem().SetString(input)
+			return in, nil
+		}
+		return in, fmt.Errorf("input is not valid JSON")
+	}
+	if err := jsonschema.Validate(f.schema, data); err != nil {
+		return in, err
+	}
+	if f.wrapped {
+		var w struct {
+			Input json.RawMessage `json:"input"`
+		}
+		if err := json.Unmarshal(data, &w); err != nil {
+			return in, err
+		}
+		data = w.Input
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return in, err
+	}
+	return in, nil
+}
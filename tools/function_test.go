@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+type weatherInput struct {
+	City string `json:"city" jsonschema:"description=The city name"`
+	Days int    `json:"days,omitempty" jsonschema:"minimum=1,maximum=7"`
+}
+
+type weatherOutput struct {
+	Forecast string `json:"forecast"`
+}
+
+func weatherFunc() *Func[weatherInput, weatherOutput] {
+	return NewFunc("weather", "Gets the weather forecast.",
+		func(_ context.Context, in weatherInput) (weatherOutput, error) {
+			return weatherOutput{Forecast: "sunny in " + in.City}, nil
+		})
+}
+
+func TestFunc(t *testing.T) {
+	t.Parallel()
+
+	tool := weatherFunc()
+	require.Equal(t, []string{"city"}, tool.Schema().Required)
+	require.Equal(t, "The city name", tool.Schema().Properties["city"].Description)
+
+	out, err := tool.Call(context.Background(), `{"city": "Paris", "days": 2}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"forecast": "sunny in Paris"}`, out)
+
+	out, err = tool.Call(context.Background(), `{"days": 9}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "invalid input")
+	require.Contains(t, out, `missing required property "city"`)
+	require.Contains(t, out, "days: must be at most 7")
+}
+
+func TestFuncWrappedInput(t *testing.T) {
+	t.Parallel()
+
+	tool := NewFunc("shout", "Shouts the input.", func(_ context.Context, in string) (string, error) {
+		return in + "!", nil
+	})
+	require.Equal(t, []string{"input"}, tool.Schema().Required)
+
+	out, err := tool.Call(context.Background(), `{"input": "hello"}`)
+	require.NoError(t, err)
+	require.Equal(t, "hello!", out)
+
+	// Agents without tool calls pass plain text.
+	out, err = tool.Call(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello!", out)
+}
+
+func TestFunctionDefinition(t *testing.T) {
+	t.Parallel()
+
+	def := FunctionDefinition(weatherFunc())
+	require.Equal(t, "weather", def.Name)
+	data, err := json.Marshal(def.Parameters)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"city"`)
+
+	def = FunctionDefinition(Calculator{})
+	data, err = json.Marshal(def.Parameters)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"__arg1"`)
+}
+
+func TestToolset(t *testing.T) {
+	t.Parallel()
+
+	set := Toolset(weatherFunc(), Calculator{})
+	require.Len(t, set.Tools(), 2)
+
+	resp, err := set.Execute(context.Background(), llms.ToolCall{
+		ID:           "1",
+		FunctionCall: &llms.FunctionCall{Name: "calculator", Arguments: `{"__arg1": "1 + 2"}`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "3", resp.Content)
+
+	resp, err = set.Execute(context.Background(), llms.ToolCall{
+		ID:           "2",
+		FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city": "Rome"}`},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"forecast": "sunny in Rome"}`, resp.Content)
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// FunctionDefinition returns the definition of a tool for models supporting
+// tool calls. The parameters of a SchemaTool are its schema, as JSON for a
+// RawSchemaTool. Other tools take their input as a single "__arg1" string.
+func FunctionDefinition(t Tool) llms.FunctionDefinition {
+	def := llms.FunctionDefinition{
+		Name:        t.Name(),
+		Description: t.Description(),
+	}
+	if rt, ok := t.(RawSchemaTool); ok && len(rt.RawSchema()) > 0 {
+		def.Parameters = rt.RawSchema()
+		return def
+	}
+	if st, ok := t.(SchemaTool); ok {
+		def.Parameters = st.Schema()
+		return def
+	}
+	def.Parameters = map[string]any{
+		"properties": map[string]any{
+			"__arg1": map[string]string{"title": "__arg1", "type": "string"},
+		},
+		"required": []string{"__arg1"},
+		"type":     "object",
+	}
+	return def
+}
+
+// LLMTools returns the definitions of tools, to pass to llms.WithTools.
+func LLMTools(tools []Tool) []llms.Tool {
+	res := make([]llms.Tool, 0, len(tools))
+	for _, t := range tools {
+		def := FunctionDefinition(t)
+		res = append(res, llms.Tool{Type: "function", Function: &def})
+	}
+	return res
+}
+
+// Toolset returns a set of tools for llms.RunTools. The arguments of tool
+// calls are passed to SchemaTools as is, and the "__arg1" argument to other
+// tools.
+func Toolset(tools ...Tool) *llms.Toolset {
+	set := llms.NewToolset()
+	for _, t := range tools {
+		set.Add(FunctionDefinition(t), func(ctx context.Context, arguments string) (string, error) {
+			return t.Call(ctx, ToolInput(t, arguments))
+		})
+	}
+	return set
+}
+
+// ToolInput returns the input of a tool from the arguments of a tool call:
+// the arguments themselves for a SchemaTool, and the "__arg1" argument for
+// other tools if there is one.
+func ToolInput(t Tool, arguments string) string {
+	if _, ok := t.(SchemaTool); ok {
+		return arguments
+	}
+	var args map[string]any
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return arguments
+	}
+	if arg1, ok := args["__arg1"].(string); ok {
+		return arg1
+	}
+	return arguments
+}
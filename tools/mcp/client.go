@@ -0,0 +1,235 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// Client is a client of an MCP server. It is safe for concurrent use.
+type Client struct {
+	transport Transport
+	nextID    atomic.Int64
+
+	// ServerInfo describes the server.
+	ServerInfo Implementation
+	// ProtocolVersion is the version of the protocol used by the server.
+	ProtocolVersion string
+	// Capabilities are the features supported by the server.
+	Capabilities ServerCapabilities
+	// Instructions describe how to use the server, if the server gives any.
+	Instructions string
+}
+
+// Option is an option of a client.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	info Implementation
+}
+
+// WithClientInfo sets the name and the version of the client sent to the
+// server.
+func WithClientInfo(name, version string) Option {
+	return func(o *clientOptions) {
+		o.info = Implementation{Name: name, Version: version}
+	}
+}
+
+// NewClient connects to a server with a transport and initializes the
+// session. The transport is closed if the initialization fails.
+func NewClient(ctx context.Context, transport Transport, opts ...Option) (*Client, error) {
+	options := clientOptions{info: Implementation{Name: "langchaingo", Version: "1.0.0"}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := &Client{transport: transport}
+	var result InitializeResult
+	err := c.call(ctx, "initialize", InitializeParams{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      options.info,
+	}, &result)
+	if err == nil {
+		err = c.notify(ctx, "notifications/initialized")
+	}
+	if err != nil {
+		transport.Close()
+		return nil, err
+	}
+	c.ServerInfo = result.ServerInfo
+	c.ProtocolVersion = result.ProtocolVersion
+	c.Capabilities = result.Capabilities
+	c.Instructions = result.Instructions
+	return c, nil
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	return c.transport.Close()
+}
+
+// Ping checks that the server is alive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, "ping", nil, nil)
+}
+
+// ListTools returns the tools of the server.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var res []Tool
+	err := c.list(ctx, "tools/list", func(data json.RawMessage) (string, error) {
+		var page ListToolsResult
+		err := json.Unmarshal(data, &page)
+		res = append(res, page.Tools...)
+		return page.NextCursor, err
+	})
+	return res, err
+}
+
+// CallTool calls a tool of the server. Errors of the tool are reported in the
+// result rather than as errors.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]any) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.call(ctx, "tools/call", CallToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListPrompts returns the prompt templates of the server.
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	var res []Prompt
+	err := c.list(ctx, "prompts/list", func(data json.RawMessage) (string, error) {
+		var page ListPromptsResult
+		err := json.Unmarshal(data, &page)
+		res = append(res, page.Prompts...)
+		return page.NextCursor, err
+	})
+	return res, err
+}
+
+// GetPrompt renders a prompt template of the server with arguments.
+func (c *Client) GetPrompt(ctx context.Context, name string, arguments map[string]string) (*GetPromptResult, error) {
+	var result GetPromptResult
+	if err := c.call(ctx, "prompts/get", GetPromptParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListResources returns the resources of the server.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	var res []Resource
+	err := c.list(ctx, "resources/list", func(data json.RawMessage) (string, error) {
+		var page ListResourcesResult
+		err := json.Unmarshal(data, &page)
+		res = append(res, page.Resources...)
+		return page.NextCursor, err
+	})
+	return res, err
+}
+
+// ReadResource reads a resource of the server.
+func (c *Client) ReadResource(ctx context.Context, uri string) ([]ResourceContents, error) {
+	var result ReadResourceResult
+	if err := c.call(ctx, "resources/read", ReadResourceParams{URI: uri}, &result); err != nil {
+		return nil, err
+	}
+	return result.Contents, nil
+}
+
+// Tools returns the tools of the server as tools.Tool, whose schemas are the
+// input schemas of the tools.
+//
+// Invalid tools are skipped: the valid tools are returned along with an error
+// wrapping ErrInvalidTool for each of the others.
+func (c *Client) Tools(ctx context.Context) ([]tools.Tool, error) {
+	serverTools, err := c.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]tools.Tool, 0, len(serverTools))
+	var errs []error
+	for _, t := range serverTools {
+		tool, err := newServerTool(c, t)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		res = append(res, tool)
+	}
+	return res, errors.Join(errs...)
+}
+
+// LLMTools returns the definitions of the tools of the server, to pass to
+// llms.WithTools. As with Tools, invalid tools are skipped and reported in
+// the error.
+func (c *Client) LLMTools(ctx context.Context) ([]llms.Tool, error) {
+	serverTools, err := c.Tools(ctx)
+	if serverTools == nil {
+		return nil, err
+	}
+	return tools.LLMTools(serverTools), err
+}
+
+// list calls a list method, following the cursors of the pages.
+func (c *Client) list(ctx context.Context, method string, page func(json.RawMessage) (string, error)) error {
+	var cursor string
+	for {
+		var data json.RawMessage
+		if err := c.call(ctx, method, ListParams{Cursor: cursor}, &data); err != nil {
+			return err
+		}
+		next, err := page(data)
+		if err != nil {
+			return fmt.Errorf("%s: decode result: %w", method, err)
+		}
+		if next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// call sends a request and decodes its result into result, if not nil.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	msg, err := NewRequest(c.nextID.Add(1), method, params)
+	if err != nil {
+		return err
+	}
+	resp, err := c.transport.RoundTrip(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if resp == nil {
+		return fmt.Errorf("%s: %w: no response", method, ErrClosed)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %w", method, resp.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("%s: decode result: %w", method, err)
+	}
+	return nil
+}
+
+// notify sends a notification.
+func (c *Client) notify(ctx context.Context, method string) error {
+	msg, err := NewRequest(nil, method, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := c.transport.RoundTrip(ctx, msg); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package mcp_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/mcp"
+)
+
+// stubServer is an in-process MCP server with a weather tool, a failing tool,
+// a prompt and a resource. Its tools are listed in two pages.
+type stubServer struct {
+	mu      sync.Mutex
+	methods []string
+}
+
+func (s *stubServer) handle(msg *mcp.Message) *mcp.Message {
+	s.mu.Lock()
+	s.methods = append(s.methods, msg.Method)
+	s.mu.Unlock()
+	if msg.IsNotification() {
+		return nil
+	}
+
+	var params struct {
+		Cursor    string         `json:"cursor"`
+		Name      string         `json:"name"`
+		URI       string         `json:"uri"`
+		Arguments map[string]any `json:"arguments"`
+	}
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return mcp.NewErrorResponse(msg.ID, mcp.CodeInvalidParams, err.Error())
+		}
+	}
+
+	var result any
+	switch msg.Method {
+	case "initialize":
+		result = mcp.InitializeResult{
+			ProtocolVersion: mcp.ProtocolVersion,
+			Capabilities:    mcp.ServerCapabilities{Tools: &mcp.Capability{}, Prompts: &mcp.Capability{}},
+			ServerInfo:      mcp.Implementation{Name: "stub", Version: "0.1.0"},
+			Instructions:    "Ask about the weather.",
+		}
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		if params.Cursor == "" {
+			result = mcp.ListToolsResult{
+				Tools: []mcp.Tool{{
+					Name:        "weather",
+					Description: "Returns the weather in a city.",
+					InputSchema: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}`),
+				}},
+				NextCursor: "2",
+			}
+		} else {
+			result = mcp.ListToolsResult{Tools: []mcp.Tool{{
+				Name:        "fail",
+				Description: "Always fails.",
+				InputSchema: json.RawMessage(`{"type":"object"}`),
+			}, {
+				Name:        "broken",
+				InputSchema: json.RawMessage(`"object"`),
+			}, {
+				Name:        "convert",
+				Description: "Converts temperatures.",
+				InputSchema: json.RawMessage(_convertSchema),
+			}}}
+		}
+	case "tools/call":
+		switch params.Name {
+		case "weather":
+			result = mcp.CallToolResult{Content: []mcp.Content{
+				mcp.TextContent(fmt.Sprintf("Sunny in %v", params.Arguments["city"])),
+				mcp.TextContent("22°C"),
+			}}
+		case "fail":
+			result = mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent("out of order")}, IsError: true}
+		default:
+			return mcp.NewErrorResponse(msg.ID, mcp.CodeInvalidParams, "unknown tool "+params.Name)
+		}
+	case "prompts/list":
+		result = mcp.ListPromptsResult{Prompts: []mcp.Prompt{{
+			Name:      "forecast",
+			Arguments: []mcp.PromptArgument{{Name: "city", Required: true}},
+		}}}
+	case "prompts/get":
+		result = mcp.GetPromptResult{Messages: []mcp.PromptMessage{
+			{Role: "user", Content: mcp.TextContent(fmt.Sprintf("Forecast for %v?", params.Arguments["city"]))},
+			{Role: "assistant", Content: mcp.TextContent("Let me check.")},
+		}}
+	case "resources/list":
+		result = mcp.ListResourcesResult{Resources: []mcp.Resource{{URI: "weather://cities", Name: "cities"}}}
+	case "resources/read":
+		result = mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			{URI: params.URI, MimeType: "text/plain", Text: "Paris\nTokyo"},
+		}}
+	default:
+		return mcp.NewErrorResponse(msg.ID, mcp.CodeMethodNotFound, "method not found")
+	}
+	resp, err := mcp.NewResponse(msg.ID, result)
+	if err != nil {
+		return mcp.NewErrorResponse(msg.ID, mcp.CodeInternalError, err.Error())
+	}
+	return resp
+}
+
+// serveStdio serves the stub server over pipes and returns a transport
+// connected to it.
+func (s *stubServer) serveStdio(t *testing.T) mcp.Transport {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	go func() {
+		defer serverWriter.Close()
+		scanner := bufio.NewScanner(serverReader)
+		for scanner.Scan() {
+			var msg mcp.Message
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				return
+			}
+			// Servers may log to their output.
+			fmt.Fprintln(serverWriter, "handling", msg.Method)
+			if resp := s.handle(&msg); resp != nil {
+				data, _ := json.Marshal(resp)
+				fmt.Fprintf(serverWriter, "%s\n", data)
+			}
+		}
+	}()
+	return mcp.NewStdioTransport(clientReader, clientWriter)
+}
+
+// serveHTTP serves the stub server with the streamable HTTP transport,
+// responding with event streams if sse is set.
+func (s *stubServer) serveHTTP(t *testing.T, sse bool) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, fmt.Sprintf("%s session=%s version=%s auth=%s", r.Method,
+			r.Header.Get("Mcp-Session-Id"), r.Header.Get("Mcp-Protocol-Version"), r.Header.Get("Authorization")))
+		mu.Unlock()
+		if r.Method == http.MethodDelete {
+			return
+		}
+
+		var msg mcp.Message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if msg.Method == "initialize" {
+			w.Header().Set("Mcp-Session-Id", "session-1")
+		}
+		resp := s.handle(&msg)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		data, _ := json.Marshal(resp)
+		if !sse {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubServer{}
+	srv, _ := stub.serveHTTP(t, false)
+	srvSSE, _ := stub.serveHTTP(t, true)
+	transports := map[string]func() mcp.Transport{
+		"stdio": func() mcp.Transport { return stub.serveStdio(t) },
+		"http":  func() mcp.Transport { return mcp.NewHTTPTransport(srv.URL) },
+		"sse":   func() mcp.Transport { return mcp.NewHTTPTransport(srvSSE.URL) },
+	}
+	for name, transport := range transports {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			client, err := mcp.NewClient(ctx, transport())
+			require.NoError(t, err)
+			defer client.Close()
+			require.Equal(t, "stub", client.ServerInfo.Name)
+			require.Equal(t, mcp.ProtocolVersion, client.ProtocolVersion)
+			require.Equal(t, "Ask about the weather.", client.Instructions)
+			require.NotNil(t, client.Capabilities.Tools)
+			require.Nil(t, client.Capabilities.Resources)
+			require.NoError(t, client.Ping(ctx))
+
+			serverTools, err := client.ListTools(ctx)
+			require.NoError(t, err)
+			require.Len(t, serverTools, 4)
+
+			prompts, err := client.ListPrompts(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "forecast", prompts[0].Name)
+			prompt, err := client.GetPrompt(ctx, "forecast", map[string]string{"city": "Paris"})
+			require.NoError(t, err)
+			require.Equal(t, []llms.MessageContent{
+				llms.TextParts(llms.ChatMessageTypeHuman, "Forecast for Paris?"),
+				llms.TextParts(llms.ChatMessageTypeAI, "Let me check."),
+			}, prompt.MessageContents())
+
+			resources, err := client.ListResources(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "weather://cities", resources[0].URI)
+			contents, err := client.ReadResource(ctx, "weather://cities")
+			require.NoError(t, err)
+			require.Equal(t, "Paris\nTokyo", contents[0].Text)
+
+			_, err = client.CallTool(ctx, "missing", nil)
+			var rpcErr *mcp.Error
+			require.ErrorAs(t, err, &rpcErr)
+			require.Equal(t, mcp.CodeInvalidParams, rpcErr.Code)
+		})
+	}
+}
+
+// _convertSchema uses keywords that jsonschema.Definition doesn't support.
+const _convertSchema = `{"type":"object","properties":{` +
+	`"degrees":{"type":"number","default":0},` +
+	`"unit":{"type":"string","const":"celsius"},` +
+	`"precision":{"type":"integer","enum":[0,1,2]}}}`
+
+func TestClientTools(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	stub := &stubServer{}
+	client, err := mcp.NewClient(ctx, stub.serveStdio(t))
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Invalid tools are skipped and reported.
+	serverTools, err := client.Tools(ctx)
+	require.ErrorIs(t, err, mcp.ErrInvalidTool)
+	require.ErrorContains(t, err, "broken")
+	require.Len(t, serverTools, 3)
+	weather := serverTools[0]
+	require.Equal(t, "weather", weather.Name())
+	require.Equal(t, "Returns the weather in a city.", weather.Description())
+	schemaTool, ok := weather.(tools.SchemaTool)
+	require.True(t, ok)
+	require.Equal(t, jsonschema.String, schemaTool.Schema().Properties["city"].Type)
+	require.Equal(t, []string{"city"}, schemaTool.Schema().Required)
+
+	out, err := weather.Call(ctx, `{"city":"Paris"}`)
+	require.NoError(t, err)
+	require.Equal(t, "Sunny in Paris\n22°C", out)
+	// Tools with a single string argument take plain inputs.
+	out, err = weather.Call(ctx, "Tokyo")
+	require.NoError(t, err)
+	require.Equal(t, "Sunny in Tokyo\n22°C", out)
+
+	_, err = serverTools[1].Call(ctx, "{}")
+	require.ErrorIs(t, err, mcp.ErrToolFailed)
+	require.ErrorContains(t, err, "out of order")
+	_, err = serverTools[1].Call(ctx, "not json")
+	require.ErrorContains(t, err, "input is not a JSON object")
+
+	llmTools, err := client.LLMTools(ctx)
+	require.ErrorIs(t, err, mcp.ErrInvalidTool)
+	require.Len(t, llmTools, 3)
+	require.Equal(t, "function", llmTools[0].Type)
+	require.Equal(t, "weather", llmTools[0].Function.Name)
+	// Schemas are passed to models as sent by the server.
+	require.Equal(t, "convert", llmTools[2].Function.Name)
+	params, err := json.Marshal(llmTools[2].Function.Parameters)
+	require.NoError(t, err)
+	require.JSONEq(t, _convertSchema, string(params))
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	require.Equal(t, []string{"initialize", "notifications/initialized", "tools/list", "tools/list"}, stub.methods[:4])
+}
+
+func TestHTTPTransportSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	srv, requests := (&stubServer{}).serveHTTP(t, true)
+	client, err := mcp.NewClient(ctx, mcp.NewHTTPTransport(srv.URL, mcp.WithHeader("Authorization", "Bearer token")))
+	require.NoError(t, err)
+	require.NoError(t, client.Ping(ctx))
+	require.NoError(t, client.Close())
+
+	version := "version=" + mcp.ProtocolVersion
+	require.Equal(t, []string{
+		"POST session= version= auth=Bearer token",
+		"POST session=session-1 " + version + " auth=Bearer token",
+		"POST session=session-1 " + version + " auth=Bearer token",
+		"DELETE session=session-1 " + version + " auth=Bearer token",
+	}, *requests)
+}
+
+func TestClientInitializeError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	_, err := mcp.NewClient(context.Background(), mcp.NewHTTPTransport(srv.URL))
+	require.EqualError(t, err, "initialize: mcp: unexpected status 401 Unauthorized: unauthorized")
+}
@@ -0,0 +1,37 @@
+// Package mcp contains a client and a server of the Model Context Protocol.
+//
+// A Client connects to a server with a Transport, over the standard input and
+// output of a process started with NewCommandTransport, over any streams with
+// NewStdioTransport, or over streamable HTTP with NewHTTPTransport. It lists
+// the tools, prompts and resources of the server, and exposes its tools as
+// tools.Tool for agents and as llms.Tool definitions for native tool calling:
+//
+//	transport, err := mcp.NewCommandTransport(exec.Command("weather-server"))
+//	if err != nil {
+//		return err
+//	}
+//	client, err := mcp.NewClient(ctx, transport)
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//	serverTools, err := client.Tools(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	agent := agents.NewToolCallingAgent(llm, serverTools)
+//
+// A Server serves tools, retrievers and prompt templates to MCP clients such
+// as desktop assistants and IDE agents, over standard input and output or over
+// streamable HTTP:
+//
+//	server := mcp.NewServer("weather", "1.0.0")
+//	if err := server.AddTools(weatherTool); err != nil {
+//		return err
+//	}
+//	if err := server.AddRetriever("search_reports", "Searches the weather reports.", retriever); err != nil {
+//		return err
+//	}
+//	server.AddPrompt("forecast", "Asks for a forecast.", forecastTemplate)
+//	return server.ServeStdio(ctx, os.Stdin, os.Stdout)
+package mcp
@@ -0,0 +1,224 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	sessionIDHeader       = "Mcp-Session-Id"
+	protocolVersionHeader = "Mcp-Protocol-Version"
+)
+
+// httpTransport sends messages to a server with the streamable HTTP transport.
+type httpTransport struct {
+	url     string
+	client  *http.Client
+	headers http.Header
+
+	mu              sync.Mutex
+	sessionID       string
+	protocolVersion string
+}
+
+var _ Transport = (*httpTransport)(nil)
+
+// HTTPOption is an option of the streamable HTTP transport.
+type HTTPOption func(*httpTransport)
+
+// WithHTTPClient sets the HTTP client sending the requests. The default is
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(t *httpTransport) {
+		t.client = client
+	}
+}
+
+// WithHeader adds a header to the requests, for instance for authentication.
+func WithHeader(key, value string) HTTPOption {
+	return func(t *httpTransport) {
+		t.headers.Add(key, value)
+	}
+}
+
+// NewHTTPTransport returns a transport sending messages to the MCP endpoint
+// of a server with the streamable HTTP transport. Responses are read from
+// JSON bodies or from server-sent event streams.
+func NewHTTPTransport(url string, opts ...HTTPOption) Transport { //nolint:ireturn
+	t := &httpTransport{
+		url:     url,
+		client:  http.DefaultClient,
+		headers: make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// RoundTrip posts a message and reads the response to requests.
+func (t *httpTransport) RoundTrip(ctx context.Context, msg *Message) (*Message, error) {
+	resp, err := t.post(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if sessionID := resp.Header.Get(sessionIDHeader); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+	if !msg.IsRequest() || msg.IsNotification() {
+		if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+			return nil, httpError(resp)
+		}
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError(resp)
+	}
+
+	var result *Message
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/event-stream":
+		result, err = t.readEvents(ctx, resp.Body, msg.ID)
+	case "application/json":
+		result = &Message{}
+		err = json.NewDecoder(resp.Body).Decode(result)
+	default:
+		err = fmt.Errorf("mcp: unexpected content type %q", mediaType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if msg.Method == "initialize" && result.Error == nil {
+		var init struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if err := json.Unmarshal(result.Result, &init); err == nil {
+			t.mu.Lock()
+			t.protocolVersion = init.ProtocolVersion
+			t.mu.Unlock()
+		}
+	}
+	return result, nil
+}
+
+// Close ends the session with the server, if it has one.
+func (t *httpTransport) Close() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.sessionID = ""
+	t.mu.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, t.url, nil)
+	if err != nil {
+		return err
+	}
+	t.setHeaders(req, sessionID)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	// Servers not allowing clients to end sessions respond 405.
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusMethodNotAllowed {
+		return httpError(resp)
+	}
+	return nil
+}
+
+func (t *httpTransport) post(ctx context.Context, msg *Message) (*http.Response, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	t.setHeaders(req, sessionID)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	return t.client.Do(req)
+}
+
+func (t *httpTransport) setHeaders(req *http.Request, sessionID string) {
+	for key, values := range t.headers {
+		req.Header[key] = values
+	}
+	if sessionID != "" {
+		req.Header.Set(sessionIDHeader, sessionID)
+	}
+	t.mu.Lock()
+	if t.protocolVersion != "" {
+		req.Header.Set(protocolVersionHeader, t.protocolVersion)
+	}
+	t.mu.Unlock()
+}
+
+// readEvents reads a server-sent event stream until the response to the
+// request with the given ID, answering the requests of the server on the way.
+func (t *httpTransport) readEvents(ctx context.Context, r io.Reader, id json.RawMessage) (*Message, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			if value, ok := strings.CutPrefix(line, "data:"); ok {
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(strings.TrimPrefix(value, " "))
+			}
+			continue
+		}
+		// A blank line dispatches the event.
+		if data.Len() == 0 {
+			continue
+		}
+		var msg Message
+		err := json.Unmarshal([]byte(data.String()), &msg)
+		data.Reset()
+		if err != nil {
+			continue
+		}
+		switch {
+		case msg.IsNotification():
+		case msg.IsRequest():
+			resp, err := t.post(ctx, serverRequestResponse(&msg))
+			if err != nil {
+				return nil, err
+			}
+			resp.Body.Close()
+		case bytes.Equal(msg.ID, id):
+			return &msg, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrClosed, err)
+	}
+	return nil, fmt.Errorf("%w: event stream ended without response", ErrClosed)
+}
+
+func httpError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("mcp: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
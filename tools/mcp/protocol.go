@@ -0,0 +1,212 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ProtocolVersion is the version of the Model Context Protocol requested by
+// clients.
+const ProtocolVersion = "2025-06-18"
+
+// Implementation describes a client or a server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ServerCapabilities are the features supported by a server. Absent features
+// are nil.
+type ServerCapabilities struct {
+	Tools     *Capability    `json:"tools,omitempty"`
+	Prompts   *Capability    `json:"prompts,omitempty"`
+	Resources *Capability    `json:"resources,omitempty"`
+	Logging   map[string]any `json:"logging,omitempty"`
+}
+
+// Capability is a feature supported by a server.
+type Capability struct {
+	// ListChanged tells whether the server notifies changes of the list.
+	ListChanged bool `json:"listChanged,omitempty"`
+	// Subscribe tells whether clients can subscribe to changes of resources.
+	Subscribe bool `json:"subscribe,omitempty"`
+}
+
+// InitializeParams are the parameters of the initialize request.
+type InitializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      Implementation `json:"clientInfo"`
+}
+
+// InitializeResult is the result of the initialize request.
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// Tool is a tool of a server.
+type Tool struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// InputSchema is the JSON schema of the arguments of the tool.
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// CallToolParams are the parameters of the tools/call request.
+type CallToolParams struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// CallToolResult is the result of a tool call. Errors of the tool are
+// reported with IsError rather than as errors of the request.
+type CallToolResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Content is a content block of a tool result or of a prompt message.
+type Content struct {
+	// Type is "text", "image", "audio", "resource_link" or "resource".
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	// Data is the base64 encoding of images and audio.
+	Data     string            `json:"data,omitempty"`
+	MimeType string            `json:"mimeType,omitempty"`
+	URI      string            `json:"uri,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Resource *ResourceContents `json:"resource,omitempty"`
+}
+
+// TextContent returns a text content block.
+func TextContent(text string) Content {
+	return Content{Type: "text", Text: text}
+}
+
+// part returns the content as a part of a message for models.
+func (c Content) part() llms.ContentPart {
+	switch c.Type {
+	case "image", "audio":
+		data, err := base64.StdEncoding.DecodeString(c.Data)
+		if err == nil {
+			return llms.BinaryPart(c.MimeType, data)
+		}
+	case "resource_link":
+		return llms.TextPart(c.URI)
+	case "resource":
+		if c.Resource != nil {
+			if c.Resource.Text == "" && c.Resource.Blob != "" {
+				data, err := base64.StdEncoding.DecodeString(c.Resource.Blob)
+				if err == nil {
+					return llms.BinaryPart(c.Resource.MimeType, data)
+				}
+			}
+			return llms.TextPart(c.Resource.Text)
+		}
+	}
+	return llms.TextPart(c.Text)
+}
+
+// Prompt is a prompt template of a server.
+type Prompt struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument is an argument of a prompt template.
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// GetPromptParams are the parameters of the prompts/get request.
+type GetPromptParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// GetPromptResult is a prompt rendered by a server.
+type GetPromptResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+// MessageContents returns the messages of the prompt, to pass to models.
+func (r *GetPromptResult) MessageContents() []llms.MessageContent {
+	res := make([]llms.MessageContent, 0, len(r.Messages))
+	for _, m := range r.Messages {
+		role := llms.ChatMessageTypeHuman
+		if m.Role == "assistant" {
+			role = llms.ChatMessageTypeAI
+		}
+		res = append(res, llms.MessageContent{Role: role, Parts: []llms.ContentPart{m.Content.part()}})
+	}
+	return res
+}
+
+// PromptMessage is a message of a prompt.
+type PromptMessage struct {
+	// Role is "user" or "assistant".
+	Role    string  `json:"role"`
+	Content Content `json:"content"`
+}
+
+// Resource is a resource of a server.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ReadResourceParams are the parameters of the resources/read request.
+type ReadResourceParams struct {
+	URI string `json:"uri"`
+}
+
+// ReadResourceResult is the result of the resources/read request.
+type ReadResourceResult struct {
+	Contents []ResourceContents `json:"contents"`
+}
+
+// ResourceContents are the contents of a resource, either text or a base64
+// encoded blob.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// ListParams are the parameters of the list requests.
+type ListParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListToolsResult is a page of the result of the tools/list request.
+type ListToolsResult struct {
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ListPromptsResult is a page of the result of the prompts/list request.
+type ListPromptsResult struct {
+	Prompts    []Prompt `json:"prompts"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
+
+// ListResourcesResult is a page of the result of the resources/list request.
+type ListResourcesResult struct {
+	Resources  []Resource `json:"resources"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,280 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ServeStdio serves newline-delimited JSON messages read from r, writing the
+// responses to w, until r ends or the context is done. Requests are handled
+// concurrently, up to a limit. Servers run as commands serve their standard
+// input and output:
+//
+//	err := server.ServeStdio(ctx, os.Stdin, os.Stdout)
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	lines := make(chan stdioLine)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			data, err := readLine(reader, _maxRequestBytes)
+			tooLong := errors.Is(err, errLineTooLong)
+			if len(data) > 0 || tooLong {
+				select {
+				case lines <- stdioLine{data: data, tooLong: tooLong}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil && !tooLong {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		writeMu  sync.Mutex
+		handling = make(chan struct{}, _maxConcurrentRequests)
+	)
+	defer wg.Wait()
+	write := func(msg *Message) {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return
+		}
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_, _ = w.Write(append(data, '\n'))
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case line := <-lines:
+			if line.tooLong {
+				write(NewErrorResponse(nil, CodeParseError, errLineTooLong.Error()))
+				continue
+			}
+			var msg Message
+			if err := json.Unmarshal(line.data, &msg); err != nil {
+				write(NewErrorResponse(nil, CodeParseError, err.Error()))
+				continue
+			}
+			select {
+			case handling <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-handling }()
+				if resp := s.Handle(ctx, &msg); resp != nil {
+					write(resp)
+				}
+			}()
+		}
+	}
+}
+
+// stdioLine is a line read from standard input, or a line skipped for being
+// too long.
+type stdioLine struct {
+	data    []byte
+	tooLong bool
+}
+
+// _maxConcurrentRequests is the maximum number of requests read from standard
+// input handled at once. Further requests are read when one is handled.
+const _maxConcurrentRequests = 64
+
+// errLineTooLong is returned by readLine for lines longer than its limit.
+var errLineTooLong = errors.New("request too large")
+
+// readLine reads a line of at most limit bytes. Longer lines are skipped and
+// reported with errLineTooLong.
+func readLine(r *bufio.Reader, limit int) ([]byte, error) {
+	var line []byte
+	tooLong := false
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if !tooLong && len(line)+len(chunk) > limit {
+			tooLong, line = true, nil
+		}
+		if !tooLong {
+			line = append(line, chunk...)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if tooLong {
+			return nil, errLineTooLong
+		}
+		return line, err
+	}
+}
+
+// _maxRequestBytes is the maximum size of the messages posted to servers.
+const _maxRequestBytes = 4 << 20
+
+// errTooManySessions is returned when starting a session beyond the maximum.
+var errTooManySessions = errors.New("too many sessions")
+
+// ServeHTTP serves the streamable HTTP transport. Clients post messages to
+// the endpoint, and the responses to requests are returned as JSON. Sessions
+// start with the initialize request and end when the client deletes them or
+// when they are not used for the session timeout.
+//
+// Requests from browsers are refused unless their origin is local or allowed
+// with WithAllowedOrigins, so that web pages can't reach local servers by
+// DNS rebinding.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedOrigin(r.Header.Get("Origin")) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		if !s.endSession(r.Header.Get(sessionIDHeader)) {
+			http.Error(w, "session not found", http.StatusNotFound)
+		}
+		return
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var msg Message
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, _maxRequestBytes)).Decode(&msg); err != nil {
+		status := http.StatusBadRequest
+		if maxErr := new(http.MaxBytesError); errors.As(err, &maxErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeJSON(w, status, NewErrorResponse(nil, CodeParseError, err.Error()))
+		return
+	}
+	if msg.Method == "initialize" {
+		sessionID, err := s.startSession()
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, errTooManySessions) {
+				status = http.StatusServiceUnavailable
+			}
+			writeJSON(w, status, NewErrorResponse(msg.ID, CodeInternalError, err.Error()))
+			return
+		}
+		w.Header().Set(sessionIDHeader, sessionID)
+	} else if sessionID := r.Header.Get(sessionIDHeader); sessionID == "" {
+		http.Error(w, "missing session", http.StatusBadRequest)
+		return
+	} else if !s.useSession(sessionID) {
+		// Clients must start a new session when theirs is not found.
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	resp := s.Handle(r.Context(), &msg)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// allowedOrigin reports whether requests with the Origin header origin are
+// served. Requests without one don't come from browsers.
+func (s *Server) allowedOrigin(origin string) bool {
+	if origin == "" || slices.Contains(s.allowedOrigins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) startSession() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	sessionID := hex.EncodeToString(id)
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxSessions > 0 && len(s.sessions) >= s.maxSessions {
+		for id, used := range s.sessions {
+			if s.expired(used, now) {
+				delete(s.sessions, id)
+			}
+		}
+		if len(s.sessions) >= s.maxSessions {
+			return "", errTooManySessions
+		}
+	}
+	s.sessions[sessionID] = now
+	return sessionID, nil
+}
+
+// useSession reports whether a session exists and has not expired, and
+// records its use.
+func (s *Server) useSession(sessionID string) bool {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	used, ok := s.sessions[sessionID]
+	if !ok {
+		return false
+	}
+	if s.expired(used, now) {
+		delete(s.sessions, sessionID)
+		return false
+	}
+	s.sessions[sessionID] = now
+	return true
+}
+
+func (s *Server) expired(used, now time.Time) bool {
+	return s.sessionTimeout > 0 && now.Sub(used) > s.sessionTimeout
+}
+
+func (s *Server) endSession(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.sessions[sessionID]
+	delete(s.sessions, sessionID)
+	return ok
+}
+
+func writeJSON(w http.ResponseWriter, status int, msg *Message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(msg)
+}
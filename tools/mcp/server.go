@@ -0,0 +1,344 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// supportedVersions are the versions of the protocol supported by servers,
+// latest first.
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// Server serves tools, retrievers and prompt templates to MCP clients, over
+// standard input and output with ServeStdio or over streamable HTTP as an
+// http.Handler. It is safe for concurrent use.
+type Server struct {
+	info           Implementation
+	instructions   string
+	maxSessions    int
+	sessionTimeout time.Duration
+	allowedOrigins []string
+
+	mu       sync.RWMutex
+	tools    map[string]serverEntry
+	prompts  map[string]serverPrompt
+	sessions map[string]time.Time // The time the sessions were last used.
+}
+
+// serverEntry is a tool of a server: a tool or a retriever.
+type serverEntry struct {
+	tool      Tool
+	call      tools.Tool
+	retriever schema.Retriever
+}
+
+type serverPrompt struct {
+	prompt   Prompt
+	template prompts.PromptTemplate
+}
+
+// retrieverInput is the input of the tools of retrievers.
+type retrieverInput struct {
+	Query string `json:"query" jsonschema:"description=The query of the documents to retrieve"`
+}
+
+// ServerOption is an option of a server.
+type ServerOption func(*Server)
+
+// WithInstructions sets the instructions describing how to use the server,
+// which clients may give to models.
+func WithInstructions(instructions string) ServerOption {
+	return func(s *Server) {
+		s.instructions = instructions
+	}
+}
+
+// WithMaxSessions sets the maximum number of HTTP sessions, 1000 by default.
+// Sessions started beyond it are refused until others end or expire.
+func WithMaxSessions(n int) ServerOption {
+	return func(s *Server) {
+		s.maxSessions = n
+	}
+}
+
+// WithSessionTimeout sets how long HTTP sessions are kept without requests,
+// one hour by default.
+func WithSessionTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.sessionTimeout = timeout
+	}
+}
+
+// WithAllowedOrigins sets the origins, such as "https://app.example.com",
+// allowed to send HTTP requests from browsers in addition to the local ones.
+func WithAllowedOrigins(origins ...string) ServerOption {
+	return func(s *Server) {
+		s.allowedOrigins = append(s.allowedOrigins, origins...)
+	}
+}
+
+const (
+	_defaultMaxSessions    = 1000
+	_defaultSessionTimeout = time.Hour
+)
+
+// NewServer returns a server with the given name and version, without
+// tools, retrievers or prompts.
+func NewServer(name, version string, opts ...ServerOption) *Server {
+	s := &Server{
+		info:           Implementation{Name: name, Version: version},
+		maxSessions:    _defaultMaxSessions,
+		sessionTimeout: _defaultSessionTimeout,
+		tools:          make(map[string]serverEntry),
+		prompts:        make(map[string]serverPrompt),
+		sessions:       make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// AddTools adds tools to the server, replacing the tools with the same names.
+// The input schemas of SchemaTools are their schemas. Other tools take their
+// input as a single "__arg1" string, as with tools.FunctionDefinition.
+func (s *Server) AddTools(ts ...tools.Tool) error {
+	entries := make([]serverEntry, 0, len(ts))
+	for _, t := range ts {
+		schema, err := json.Marshal(tools.FunctionDefinition(t).Parameters)
+		if err != nil {
+			return fmt.Errorf("tool %s: encode schema: %w", t.Name(), err)
+		}
+		entries = append(entries, serverEntry{
+			tool: Tool{Name: t.Name(), Description: t.Description(), InputSchema: schema},
+			call: t,
+		})
+	}
+	s.add(entries...)
+	return nil
+}
+
+// AddRetriever adds a retriever to the server as a tool with the given name,
+// taking a query and returning the contents of the relevant documents.
+func (s *Server) AddRetriever(name, description string, retriever schema.Retriever) error {
+	schema, err := json.Marshal(jsonschema.For[retrieverInput]())
+	if err != nil {
+		return fmt.Errorf("retriever %s: encode schema: %w", name, err)
+	}
+	s.add(serverEntry{
+		tool:      Tool{Name: name, Description: description, InputSchema: schema},
+		retriever: retriever,
+	})
+	return nil
+}
+
+// AddPrompt adds a prompt template to the server, replacing the prompt with
+// the same name. Its input variables are the required arguments of the
+// prompt, and it is rendered as a single user message.
+func (s *Server) AddPrompt(name, description string, template prompts.PromptTemplate) {
+	prompt := Prompt{Name: name, Description: description}
+	for _, variable := range template.GetInputVariables() {
+		if _, ok := template.PartialVariables[variable]; ok {
+			continue
+		}
+		prompt.Arguments = append(prompt.Arguments, PromptArgument{Name: variable, Required: true})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prompts[name] = serverPrompt{prompt: prompt, template: template}
+}
+
+func (s *Server) add(entries ...serverEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range entries {
+		s.tools[e.tool.Name] = e
+	}
+}
+
+// Handle handles a message of a client and returns the response to send
+// back, or nil for notifications and responses.
+func (s *Server) Handle(ctx context.Context, msg *Message) *Message {
+	if !msg.IsRequest() || msg.IsNotification() {
+		return nil
+	}
+	result, err := s.handle(ctx, msg)
+	if err != nil {
+		if rpcErr, ok := err.(*Error); ok { //nolint:errorlint
+			return &Message{JSONRPC: "2.0", ID: msg.ID, Error: rpcErr}
+		}
+		return NewErrorResponse(msg.ID, CodeInternalError, err.Error())
+	}
+	resp, err := NewResponse(msg.ID, result)
+	if err != nil {
+		return NewErrorResponse(msg.ID, CodeInternalError, err.Error())
+	}
+	return resp
+}
+
+func (s *Server) handle(ctx context.Context, msg *Message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		var params CallToolParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params)
+	case "prompts/list":
+		return s.listPrompts(), nil
+	case "prompts/get":
+		var params GetPromptParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.getPrompt(params)
+	case "resources/list":
+		return ListResourcesResult{Resources: []Resource{}}, nil
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+}
+
+func (s *Server) initialize(params InitializeParams) InitializeResult {
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, params.ProtocolVersion) {
+		version = params.ProtocolVersion
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var capabilities ServerCapabilities
+	if len(s.tools) > 0 {
+		capabilities.Tools = &Capability{}
+	}
+	if len(s.prompts) > 0 {
+		capabilities.Prompts = &Capability{}
+	}
+	return InitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    capabilities,
+		ServerInfo:      s.info,
+		Instructions:    s.instructions,
+	}
+}
+
+func (s *Server) listTools() ListToolsResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := ListToolsResult{Tools: make([]Tool, 0, len(s.tools))}
+	for _, name := range slices.Sorted(maps.Keys(s.tools)) {
+		res.Tools = append(res.Tools, s.tools[name].tool)
+	}
+	return res
+}
+
+// callTool calls a tool. Errors of the tool are reported in the result, for
+// the model to see them.
+func (s *Server) callTool(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+	s.mu.RLock()
+	entry, ok := s.tools[params.Name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+
+	arguments := params.Arguments
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+	data, err := json.Marshal(arguments)
+	if err != nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+
+	if entry.retriever != nil {
+		var input retrieverInput
+		if err := json.Unmarshal(data, &input); err != nil || input.Query == "" {
+			return errorResult("invalid input: the query is required"), nil
+		}
+		docs, err := entry.retriever.GetRelevantDocuments(ctx, input.Query)
+		if err != nil {
+			return errorResult(err.Error()), nil
+		}
+		result := &CallToolResult{Content: make([]Content, 0, len(docs))}
+		for _, doc := range docs {
+			result.Content = append(result.Content, TextContent(doc.PageContent))
+		}
+		return result, nil
+	}
+
+	output, err := entry.call.Call(ctx, tools.ToolInput(entry.call, string(data)))
+	if err != nil {
+		return errorResult(err.Error()), nil
+	}
+	return &CallToolResult{Content: []Content{TextContent(output)}}, nil
+}
+
+func errorResult(text string) *CallToolResult {
+	return &CallToolResult{Content: []Content{TextContent(text)}, IsError: true}
+}
+
+func (s *Server) listPrompts() ListPromptsResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := ListPromptsResult{Prompts: make([]Prompt, 0, len(s.prompts))}
+	for _, name := range slices.Sorted(maps.Keys(s.prompts)) {
+		res.Prompts = append(res.Prompts, s.prompts[name].prompt)
+	}
+	return res
+}
+
+func (s *Server) getPrompt(params GetPromptParams) (*GetPromptResult, error) {
+	s.mu.RLock()
+	prompt, ok := s.prompts[params.Name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown prompt: " + params.Name}
+	}
+
+	values := make(map[string]any, len(params.Arguments))
+	for _, argument := range prompt.prompt.Arguments {
+		value, ok := params.Arguments[argument.Name]
+		if !ok {
+			return nil, &Error{Code: CodeInvalidParams, Message: "missing argument: " + argument.Name}
+		}
+		values[argument.Name] = value
+	}
+	text, err := prompt.template.Format(values)
+	if err != nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return &GetPromptResult{
+		Description: prompt.prompt.Description,
+		Messages:    []PromptMessage{{Role: "user", Content: TextContent(text)}},
+	}, nil
+}
+
+func decodeParams(msg *Message, params any) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/mcp"
+)
+
+type weatherInput struct {
+	City string `json:"city" jsonschema:"description=The city name"`
+}
+
+// upperTool is a tool without schema.
+type upperTool struct{}
+
+func (upperTool) Name() string        { return "upper" }
+func (upperTool) Description() string { return "Converts text to upper case." }
+func (upperTool) Call(_ context.Context, input string) (string, error) {
+	return strings.ToUpper(input), nil
+}
+
+type reportRetriever struct{}
+
+func (reportRetriever) GetRelevantDocuments(_ context.Context, query string) ([]schema.Document, error) {
+	if query == "error" {
+		return nil, errors.New("index unavailable")
+	}
+	return []schema.Document{{PageContent: "Report 1 on " + query}, {PageContent: "Report 2 on " + query}}, nil
+}
+
+func newTestServer(t *testing.T) *mcp.Server {
+	t.Helper()
+	server := mcp.NewServer("weather", "1.0.0", mcp.WithInstructions("Ask about the weather."))
+	weather := tools.NewFunc("weather", "Returns the weather in a city.",
+		func(_ context.Context, in weatherInput) (string, error) {
+			if in.City == "Atlantis" {
+				return "", errors.New("no station in Atlantis")
+			}
+			return "Sunny in " + in.City, nil
+		})
+	require.NoError(t, server.AddTools(weather, upperTool{}))
+	require.NoError(t, server.AddRetriever("search_reports", "Searches the weather reports.", reportRetriever{}))
+	server.AddPrompt("forecast", "Asks for a forecast.", prompts.PromptTemplate{
+		Template:         "Forecast for {{.city}} in {{.unit}}?",
+		InputVariables:   []string{"city", "unit"},
+		TemplateFormat:   prompts.TemplateFormatGoTemplate,
+		PartialVariables: map[string]any{"unit": "celsius"},
+	})
+	return server
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	transports := map[string]func() mcp.Transport{
+		"stdio": func() mcp.Transport {
+			clientReader, serverWriter := io.Pipe()
+			serverReader, clientWriter := io.Pipe()
+			go func() {
+				defer serverWriter.Close()
+				_ = server.ServeStdio(context.Background(), serverReader, serverWriter)
+			}()
+			return mcp.NewStdioTransport(clientReader, clientWriter)
+		},
+		"http": func() mcp.Transport { return mcp.NewHTTPTransport(srv.URL) },
+	}
+	for name, transport := range transports {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			client, err := mcp.NewClient(ctx, transport())
+			require.NoError(t, err)
+			defer client.Close()
+			require.Equal(t, mcp.Implementation{Name: "weather", Version: "1.0.0"}, client.ServerInfo)
+			require.Equal(t, "Ask about the weather.", client.Instructions)
+			require.NotNil(t, client.Capabilities.Tools)
+			require.NotNil(t, client.Capabilities.Prompts)
+
+			serverTools, err := client.Tools(ctx)
+			require.NoError(t, err)
+			names := make([]string, 0, len(serverTools))
+			for _, tool := range serverTools {
+				names = append(names, tool.Name())
+			}
+			require.Equal(t, []string{"search_reports", "upper", "weather"}, names)
+
+			// The schemas of the tools are kept.
+			search, upper, weather := serverTools[0], serverTools[1], serverTools[2]
+			weatherSchema := weather.(tools.SchemaTool).Schema() //nolint:forcetypeassert
+			require.Equal(t, jsonschema.String, weatherSchema.Properties["city"].Type)
+			require.Equal(t, "The city name", weatherSchema.Properties["city"].Description)
+			require.Equal(t, []string{"city"}, weatherSchema.Required)
+			require.Contains(t, upper.(tools.SchemaTool).Schema().Properties, "__arg1") //nolint:forcetypeassert
+
+			out, err := weather.Call(ctx, `{"city":"Paris"}`)
+			require.NoError(t, err)
+			require.Equal(t, "Sunny in Paris", out)
+			out, err = upper.Call(ctx, "quiet")
+			require.NoError(t, err)
+			require.Equal(t, "QUIET", out)
+			out, err = search.Call(ctx, "storms")
+			require.NoError(t, err)
+			require.Equal(t, "Report 1 on storms\nReport 2 on storms", out)
+
+			// Errors of tools are results with IsError.
+			result, err := client.CallTool(ctx, "weather", map[string]any{"city": "Atlantis"})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			require.Equal(t, []mcp.Content{mcp.TextContent("no station in Atlantis")}, result.Content)
+			_, err = search.Call(ctx, "error")
+			require.ErrorIs(t, err, mcp.ErrToolFailed)
+			require.ErrorContains(t, err, "index unavailable")
+			_, err = client.CallTool(ctx, "missing", nil)
+			var rpcErr *mcp.Error
+			require.ErrorAs(t, err, &rpcErr)
+			require.Equal(t, mcp.CodeInvalidParams, rpcErr.Code)
+
+			prompts, err := client.ListPrompts(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []mcp.Prompt{{
+				Name:        "forecast",
+				Description: "Asks for a forecast.",
+				Arguments:   []mcp.PromptArgument{{Name: "city", Required: true}},
+			}}, prompts)
+			prompt, err := client.GetPrompt(ctx, "forecast", map[string]string{"city": "Oslo"})
+			require.NoError(t, err)
+			require.Equal(t, []mcp.PromptMessage{
+				{Role: "user", Content: mcp.TextContent("Forecast for Oslo in celsius?")},
+			}, prompt.Messages)
+			_, err = client.GetPrompt(ctx, "forecast", nil)
+			require.ErrorContains(t, err, "missing argument: city")
+		})
+	}
+}
+
+func TestServerHTTPSession(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(newTestServer(t))
+	defer srv.Close()
+	post := func(sessionID, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if sessionID != "" {
+			req.Header.Set("Mcp-Session-Id", sessionID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	ping := `{"jsonrpc":"2.0","id":1,"method":"ping"}`
+	require.Equal(t, http.StatusBadRequest, post("", ping).StatusCode)
+	require.Equal(t, http.StatusNotFound, post("unknown", ping).StatusCode)
+
+	resp := post("", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	sessionID := resp.Header.Get("Mcp-Session-Id")
+	require.NotEmpty(t, sessionID)
+	require.Equal(t, http.StatusAccepted, post(sessionID, `{"jsonrpc":"2.0","method":"notifications/initialized"}`).StatusCode)
+	require.Equal(t, http.StatusOK, post(sessionID, ping).StatusCode)
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Mcp-Session-Id", sessionID)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusNotFound, post(sessionID, ping).StatusCode)
+}
+
+func TestServerHTTPLimits(t *testing.T) {
+	t.Parallel()
+
+	server := mcp.NewServer("weather", "1.0.0",
+		mcp.WithMaxSessions(1),
+		mcp.WithSessionTimeout(50*time.Millisecond),
+		mcp.WithAllowedOrigins("https://app.example.com"))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+	post := func(sessionID, origin, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if sessionID != "" {
+			req.Header.Set("Mcp-Session-Id", sessionID)
+		}
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`
+	ping := `{"jsonrpc":"2.0","id":1,"method":"ping"}`
+
+	// Browsers are only allowed from local and allowed origins.
+	require.Equal(t, http.StatusForbidden, post("", "http://attacker.example", initialize).StatusCode)
+	require.Equal(t, http.StatusRequestEntityTooLarge,
+		post("", "", `{"jsonrpc":"2.0","id":1,"method":"ping","params":"`+strings.Repeat("a", 5<<20)+`"}`).StatusCode)
+
+	resp := post("", "https://app.example.com", initialize)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	sessionID := resp.Header.Get("Mcp-Session-Id")
+	require.Equal(t, http.StatusOK, post(sessionID, "http://localhost:6274", ping).StatusCode)
+	require.Equal(t, http.StatusServiceUnavailable, post("", "", initialize).StatusCode)
+
+	// Expired sessions are ended, and make room for new ones.
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, http.StatusNotFound, post(sessionID, "", ping).StatusCode)
+	require.Equal(t, http.StatusOK, post("", "", initialize).StatusCode)
+}
+
+func TestServerStdioLimits(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	in := `{"jsonrpc":"2.0","id":1,"method":"ping","params":"` + strings.Repeat("a", 5<<20) + "\"}\n" +
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}` + "\n"
+	require.NoError(t, mcp.NewServer("weather", "1.0.0").ServeStdio(context.Background(), strings.NewReader(in), &out))
+
+	// Oversized requests are answered with a parse error, and the next ones
+	// are served.
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"request too large"}}`, lines[0])
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":2,"result":{}}`, lines[1])
+}
+
+func TestServerHandle(t *testing.T) {
+	t.Parallel()
+	server := newTestServer(t)
+
+	handle := func(request string) string {
+		var msg mcp.Message
+		require.NoError(t, json.Unmarshal([]byte(request), &msg))
+		resp := server.Handle(context.Background(), &msg)
+		if resp == nil {
+			return ""
+		}
+		data, err := json.Marshal(resp)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	// Older versions of the protocol are accepted, others are answered with
+	// the latest version.
+	require.Contains(t, handle(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`),
+		`"protocolVersion":"2024-11-05"`)
+	require.Contains(t, handle(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`),
+		`"protocolVersion":"`+mcp.ProtocolVersion+`"`)
+	require.Empty(t, handle(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":"a","error":{"code":-32601,"message":"method not found: sampling/createMessage"}}`,
+		handle(`{"jsonrpc":"2.0","id":"a","method":"sampling/createMessage"}`))
+	require.Contains(t, handle(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":1}}`),
+		`"code":-32602`)
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ErrToolFailed is returned by the tools of a server when the result of a
+// call is an error.
+var ErrToolFailed = errors.New("mcp: tool failed")
+
+// ErrInvalidTool is returned by Client.Tools for the tools of a server that
+// have no name or whose input schema is not a JSON object.
+var ErrInvalidTool = errors.New("mcp: invalid tool")
+
+// serverTool is a tool of a server.
+type serverTool struct {
+	client *Client
+	tool   Tool
+	raw    json.RawMessage
+	schema *jsonschema.Definition
+}
+
+var _ tools.RawSchemaTool = (*serverTool)(nil)
+
+func newServerTool(c *Client, t Tool) (*serverTool, error) {
+	if t.Name == "" {
+		return nil, fmt.Errorf("%w: tool without a name", ErrInvalidTool)
+	}
+	raw := t.InputSchema
+	if len(raw) == 0 || string(raw) == "null" {
+		raw = json.RawMessage(`{"type":"object","properties":{}}`)
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("%w: %s: input schema is not a JSON object: %w", ErrInvalidTool, t.Name, err)
+	}
+	// The schema is given to models as is. Its decoded form is only used to
+	// find the arguments of the tool, so schemas using keywords that
+	// jsonschema.Definition can't decode are still accepted.
+	schema := &jsonschema.Definition{}
+	if err := json.Unmarshal(raw, schema); err != nil {
+		schema = &jsonschema.Definition{Type: jsonschema.Object}
+	}
+	return &serverTool{client: c, tool: t, raw: raw, schema: schema}, nil
+}
+
+// Name returns the name of the tool.
+func (t *serverTool) Name() string {
+	return t.tool.Name
+}
+
+// Description returns the description of the tool.
+func (t *serverTool) Description() string {
+	return t.tool.Description
+}
+
+// Schema returns the input schema of the tool.
+func (t *serverTool) Schema() *jsonschema.Definition {
+	return t.schema
+}
+
+// RawSchema returns the input schema of the tool, as sent by the server.
+func (t *serverTool) RawSchema() json.RawMessage {
+	return t.raw
+}
+
+// Call calls the tool with a JSON object input, and returns the text of the
+// result. Inputs that are not JSON objects are passed as the argument of
+// tools with a single string argument.
+func (t *serverTool) Call(ctx context.Context, input string) (string, error) {
+	arguments, err := t.arguments(input)
+	if err != nil {
+		return "", err
+	}
+	result, err := t.client.CallTool(ctx, t.tool.Name, arguments)
+	if err != nil {
+		return "", err
+	}
+	text := resultText(result.Content)
+	if result.IsError {
+		return "", fmt.Errorf("%w: %s: %s", ErrToolFailed, t.tool.Name, text)
+	}
+	return text, nil
+}
+
+func (t *serverTool) arguments(input string) (map[string]any, error) {
+	var arguments map[string]any
+	err := json.Unmarshal([]byte(input), &arguments)
+	if err == nil {
+		return arguments, nil
+	}
+	if len(t.schema.Properties) == 1 {
+		for name, property := range t.schema.Properties {
+			if property.Type == jsonschema.String {
+				return map[string]any{name: input}, nil
+			}
+		}
+	}
+	if strings.TrimSpace(input) == "" {
+		return map[string]any{}, nil
+	}
+	return nil, fmt.Errorf("tool %s: input is not a JSON object: %w", t.tool.Name, err)
+}
+
+// resultText returns the text of the contents of a result. Contents other
+// than text are described.
+func resultText(contents []Content) string {
+	texts := make([]string, 0, len(contents))
+	for _, c := range contents {
+		switch c.Type {
+		case "text":
+			texts = append(texts, c.Text)
+		case "resource":
+			if c.Resource != nil && c.Resource.Text != "" {
+				texts = append(texts, c.Resource.Text)
+			} else if c.Resource != nil {
+				texts = append(texts, fmt.Sprintf("[resource %s]", c.Resource.URI))
+			}
+		case "resource_link":
+			texts = append(texts, fmt.Sprintf("[resource %s]", c.URI))
+		default:
+			texts = append(texts, fmt.Sprintf("[%s %s]", c.Type, c.MimeType))
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,206 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by transports used after they are closed or after the
+// connection to the server is lost.
+var ErrClosed = errors.New("mcp: transport closed")
+
+// Transport sends messages to a server.
+type Transport interface {
+	// RoundTrip sends a message and returns the response of the server to a
+	// request, or nil for a notification.
+	RoundTrip(ctx context.Context, msg *Message) (*Message, error)
+	// Close closes the connection to the server.
+	Close() error
+}
+
+// stdioTransport exchanges newline-delimited JSON messages over streams.
+type stdioTransport struct {
+	w       io.WriteCloser
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string]chan *Message
+	err     error
+	done    chan struct{}
+
+	closeOnce sync.Once
+	closeFn   func() error
+}
+
+var _ Transport = (*stdioTransport)(nil)
+
+// NewStdioTransport returns a transport exchanging newline-delimited JSON
+// messages with a server, reading its messages from r and writing to w.
+// Closing the transport closes w.
+func NewStdioTransport(r io.Reader, w io.WriteCloser) Transport { //nolint:ireturn
+	return newStdioTransport(r, w, w.Close)
+}
+
+func newStdioTransport(r io.Reader, w io.WriteCloser, closeFn func() error) *stdioTransport {
+	t := &stdioTransport{
+		w:       w,
+		pending: make(map[string]chan *Message),
+		done:    make(chan struct{}),
+		closeFn: closeFn,
+	}
+	go t.read(r)
+	return t
+}
+
+// NewCommandTransport starts a command and returns a transport exchanging
+// messages with it over its standard input and output. Closing the transport
+// closes the standard input of the command and waits for it to exit, killing
+// it if it does not exit within five seconds.
+func NewCommandTransport(cmd *exec.Cmd) (Transport, error) { //nolint:ireturn
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start server: %w", err)
+	}
+	return newStdioTransport(stdout, stdin, func() error {
+		stdin.Close()
+		exited := make(chan error, 1)
+		go func() { exited <- cmd.Wait() }()
+		select {
+		case err := <-exited:
+			return err
+		case <-time.After(5 * time.Second):
+			_ = cmd.Process.Kill()
+			return <-exited
+		}
+	}), nil
+}
+
+// RoundTrip sends a message and waits for the response to requests.
+func (t *stdioTransport) RoundTrip(ctx context.Context, msg *Message) (*Message, error) {
+	if !msg.IsRequest() || msg.IsNotification() {
+		return nil, t.write(msg)
+	}
+
+	key := string(msg.ID)
+	ch := make(chan *Message, 1)
+	t.mu.Lock()
+	if t.err != nil {
+		t.mu.Unlock()
+		return nil, t.err
+	}
+	t.pending[key] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, key)
+		t.mu.Unlock()
+	}()
+
+	if err := t.write(msg); err != nil {
+		return nil, err
+	}
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-t.done:
+		return nil, t.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes the transport.
+func (t *stdioTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		err = t.closeFn()
+	})
+	return err
+}
+
+func (t *stdioTransport) write(msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if _, err := t.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("%w: %w", ErrClosed, err)
+	}
+	return nil
+}
+
+// read reads the messages of the server until the stream ends, dispatching
+// responses to the pending requests and answering the requests of the server.
+func (t *stdioTransport) read(r io.Reader) {
+	reader := bufio.NewReader(r)
+	var err error
+	for {
+		var line []byte
+		line, err = reader.ReadBytes('\n')
+		if len(line) > 0 {
+			t.handle(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		err = ErrClosed
+	} else {
+		err = fmt.Errorf("%w: %w", ErrClosed, err)
+	}
+	t.mu.Lock()
+	t.err = err
+	t.mu.Unlock()
+	close(t.done)
+}
+
+func (t *stdioTransport) handle(line []byte) {
+	var msg Message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		// Servers may log to their output. Lines that are not messages are ignored.
+		return
+	}
+	switch {
+	case msg.IsNotification():
+	case msg.IsRequest():
+		go func() {
+			_ = t.write(serverRequestResponse(&msg))
+		}()
+	default:
+		t.mu.Lock()
+		ch, ok := t.pending[string(msg.ID)]
+		t.mu.Unlock()
+		if ok {
+			select {
+			case ch <- &msg:
+			default:
+			}
+		}
+	}
+}
+
+// serverRequestResponse returns the response to a request of the server. Only
+// pings are supported.
+func serverRequestResponse(msg *Message) *Message {
+	if msg.Method == "ping" {
+		return &Message{JSONRPC: "2.0", ID: msg.ID, Result: json.RawMessage("{}")}
+	}
+	return NewErrorResponse(msg.ID, CodeMethodNotFound, "method not found: "+msg.Method)
+}
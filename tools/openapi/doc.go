@@ -0,0 +1,21 @@
+// Package openapi generates tools from OpenAPI 3 documents.
+//
+// Toolkit returns a tool for each operation of a document, whose input schema
+// is derived from the parameters and the JSON request body of the operation.
+// Models call the operations with structured arguments instead of writing
+// URLs, and only reach the operations allowed with WithOperations:
+//
+//	spec, err := os.ReadFile("petstore.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	petTools, err := openapi.Toolkit(spec,
+//		openapi.WithBaseURL("https://petstore.internal"),
+//		openapi.WithBearerToken(os.Getenv("PETSTORE_TOKEN")),
+//		openapi.WithOperations("listPets", "showPetById"),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	agent := agents.NewToolCallingAgent(llm, petTools)
+package openapi
@@ -0,0 +1,238 @@
+package openapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/openapi"
+)
+
+func readSpec(t *testing.T) []byte {
+	t.Helper()
+	spec, err := os.ReadFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+	return spec
+}
+
+func toolsByName(t *testing.T, ts []tools.Tool) map[string]*openapi.Tool {
+	t.Helper()
+	res := make(map[string]*openapi.Tool, len(ts))
+	for _, tool := range ts {
+		opTool, ok := tool.(*openapi.Tool)
+		require.True(t, ok)
+		res[tool.Name()] = opTool
+	}
+	return res
+}
+
+func TestToolkit(t *testing.T) {
+	t.Parallel()
+
+	ts, err := openapi.Toolkit(readSpec(t))
+	require.NoError(t, err)
+	names := make([]string, 0, len(ts))
+	for _, tool := range ts {
+		names = append(names, tool.Name())
+	}
+	require.Equal(t, []string{"listPets", "createPet", "showPetById", "delete_pets_petId"}, names)
+	byName := toolsByName(t, ts)
+
+	list := byName["listPets"]
+	require.Equal(t, "List all pets.", list.Description())
+	require.Equal(t, "GET", list.Method())
+	require.Equal(t, "/pets", list.Path())
+	schema := list.Schema()
+	require.Equal(t, jsonschema.Integer, schema.Properties["limit"].Type)
+	require.Equal(t, "How many pets to return at most.", schema.Properties["limit"].Description)
+	require.Equal(t, jsonschema.String, schema.Properties["tags"].Items.Type)
+	require.Contains(t, schema.Properties, "X-Request-ID")
+	require.Empty(t, schema.Required)
+
+	schema = byName["createPet"].Schema()
+	require.Equal(t, []string{"body"}, schema.Required)
+	require.Equal(t, "#/$defs/Pet", schema.Properties["body"].Ref)
+	pet := schema.Defs["Pet"]
+	require.Equal(t, []string{"name"}, pet.Required)
+	require.True(t, pet.Properties["tag"].Nullable)
+	require.Equal(t, "#/$defs/Pet", pet.Properties["parent"].Ref)
+	require.Equal(t, "The parent of the pet.", pet.Properties["parent"].Description)
+
+	del := byName["delete_pets_petId"]
+	require.Equal(t, "Delete a pet.\n\nThe pet must not be adopted.", del.Description())
+	require.Equal(t, []string{"petId"}, del.Schema().Required)
+	require.Equal(t, "The ID of the pet.", del.Schema().Properties["petId"].Description)
+}
+
+func TestToolCall(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.Join([]string{
+			r.Method, r.URL.RequestURI(), "key=" + r.Header.Get("X-API-Key"), "auth=" + r.Header.Get("Authorization"),
+			"request=" + r.Header.Get("X-Request-ID"), "team=" + r.Header.Get("X-Team"), string(body),
+		}, " "))
+		switch r.Method {
+		case http.MethodDelete:
+			http.Error(w, "pet is adopted", http.StatusConflict)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `[{"name":"Rex"},{"name":"Tom"}]`)
+		}
+	}))
+	defer srv.Close()
+
+	ts, err := openapi.Toolkit(readSpec(t),
+		openapi.WithBaseURL(srv.URL+"/"),
+		openapi.WithAPIKey("secret"),
+		openapi.WithBearerToken("token"),
+		openapi.WithHeader("X-Team", "pets"),
+	)
+	require.NoError(t, err)
+	byName := toolsByName(t, ts)
+
+	out, err := byName["listPets"].Call(ctx, `{"limit":2,"tags":["cat","dog"],"X-Request-ID":"r1"}`)
+	require.NoError(t, err)
+	require.Equal(t, `[{"name":"Rex"},{"name":"Tom"}]`, out)
+	out, err = byName["createPet"].Call(ctx, `{"body":{"name":"Rex","tag":null}}`)
+	require.NoError(t, err)
+	require.Equal(t, "201 Created", out)
+	_, err = byName["showPetById"].Call(ctx, `{"petId":"rex/1"}`)
+	require.NoError(t, err)
+	_, err = byName["delete_pets_petId"].Call(ctx, `{"petId":"rex"}`)
+	var statusErr *openapi.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, http.StatusConflict, statusErr.StatusCode)
+	require.Equal(t, "unexpected status 409 Conflict: pet is adopted\n", err.Error())
+
+	require.Equal(t, []string{
+		"GET /pets?limit=2&tags=cat&tags=dog key=secret auth= request=r1 team=pets ",
+		`POST /pets key=secret auth= request= team=pets {"name":"Rex","tag":null}`,
+		"GET /pets/rex%2F1 key= auth=Bearer token request= team=pets ",
+		"DELETE /pets/rex key= auth= request= team=pets ",
+	}, requests)
+
+	// Invalid inputs are reported to the model without sending requests.
+	out, err = byName["listPets"].Call(ctx, `{"limit":1000}`)
+	require.NoError(t, err)
+	require.Equal(t, "invalid input: limit: must be at most 100", out)
+	out, err = byName["createPet"].Call(ctx, `{"body":{}}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "invalid input:")
+	out, err = byName["showPetById"].Call(ctx, "rex")
+	require.NoError(t, err)
+	require.Equal(t, "invalid input: input is not valid JSON", out)
+	out, err = byName["showPetById"].Call(ctx, `{"petId":".."}`)
+	require.NoError(t, err)
+	require.Equal(t, `invalid input: invalid argument: petId cannot be ".."`, out)
+	require.Len(t, requests, 4)
+}
+
+func TestToolkitOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ts, err := openapi.Toolkit(readSpec(t), openapi.WithOperations("showPetById", "DELETE /pets/{petId}"))
+	require.NoError(t, err)
+	require.Len(t, ts, 2)
+	require.Equal(t, "showPetById", ts[0].Name())
+	require.Equal(t, "delete_pets_petId", ts[1].Name())
+	_, err = openapi.Toolkit(readSpec(t), openapi.WithOperations("listPets", "adoptPet"))
+	require.ErrorIs(t, err, openapi.ErrUnknownOperation)
+	require.ErrorContains(t, err, "adoptPet")
+
+	// The base URL is the URL of the first server, and responses are truncated.
+	var url string
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		url = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader("Rex the café dog")),
+		}, nil
+	})}
+	ts, err = openapi.Toolkit(readSpec(t),
+		openapi.WithHTTPClient(client),
+		openapi.WithMaxResponseLength(12),
+		openapi.WithOperations("listPets"),
+	)
+	require.NoError(t, err)
+	out, err := ts[0].Call(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, "https://eu.petstore.example.com/v1/pets", url)
+	require.Equal(t, "Rex the caf\n[response truncated]", out)
+}
+
+func TestToolkitSchemaKeywords(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	spec := `{"openapi":"3.0.3","servers":[{"url":"https://api.example.com"}],"paths":{"/forecast":{"get":{
+		"operationId":"forecast",
+		"parameters":[
+			{"name":"days","in":"query","schema":{"type":"integer","enum":[1,3,7],"default":3}},
+			{"name":"temperature","in":"query","schema":{"type":"number",
+				"minimum":0,"exclusiveMinimum":true,"maximum":100,"exclusiveMaximum":false}}
+		]}}}}`
+	client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader("sunny"))}, nil
+	})}
+	ts, err := openapi.Toolkit([]byte(spec), openapi.WithHTTPClient(client))
+	require.NoError(t, err)
+	forecast := toolsByName(t, ts)["forecast"]
+
+	// Models are given the keywords of the document, with the OpenAPI 3.0
+	// boolean bounds converted to numeric ones.
+	require.JSONEq(t, `{"type":"object","properties":{
+		"days":{"type":"integer","enum":[1,3,7],"default":3},
+		"temperature":{"type":"number","exclusiveMinimum":0,"maximum":100}
+	}}`, string(forecast.RawSchema()))
+	require.Equal(t, []string{"1", "3", "7"}, forecast.Schema().Properties["days"].Enum)
+
+	out, err := forecast.Call(ctx, `{"days":2}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "invalid input")
+	out, err = forecast.Call(ctx, `{"temperature":0}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "invalid input")
+	out, err = forecast.Call(ctx, `{"days":7,"temperature":21.5}`)
+	require.NoError(t, err)
+	require.Equal(t, "sunny", out)
+}
+
+func TestToolkitInvalidSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"swagger":     `{"swagger":"2.0","paths":{}}`,
+		"relative":    `{"openapi":"3.1.0","servers":[{"url":"/v1"}],"paths":{}}`,
+		"missing ref": `{"openapi":"3.1.0","servers":[{"url":"https://api.example.com"}],"paths":{"/a":{"get":{"parameters":[{"name":"q","in":"query","schema":{"$ref":"#/components/schemas/Q"}}]}}}}`, //nolint:lll
+		"not json":    `{"openapi":`,
+		"collision":   `{"openapi":"3.1.0","servers":[{"url":"https://api.example.com"}],"paths":{"/a":{"get":{"parameters":[{"name":"header_q","in":"query"},{"name":"q","in":"query"},{"name":"q","in":"header"}]}}}}`, //nolint:lll
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := openapi.Toolkit([]byte(spec))
+			require.ErrorIs(t, err, openapi.ErrInvalidSpec)
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
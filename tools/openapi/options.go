@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"net/http"
+)
+
+// _defaultMaxResponseLength is the default maximum length of the responses
+// returned by the tools, in bytes.
+const _defaultMaxResponseLength = 8000
+
+// Option is an option of the toolkit.
+type Option func(*options)
+
+type options struct {
+	baseURL           string
+	client            *http.Client
+	headers           http.Header
+	bearerToken       string
+	apiKey            string
+	username          string
+	password          string
+	editors           []func(*http.Request) error
+	operations        []string
+	maxResponseLength int
+}
+
+func defaultOptions() options {
+	return options{
+		client:            http.DefaultClient,
+		headers:           make(http.Header),
+		maxResponseLength: _defaultMaxResponseLength,
+	}
+}
+
+// WithBaseURL sets the URL the paths of the operations are relative to,
+// instead of the URL of the first server of the document.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client sending the requests. The default is
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithHeader adds a header to all the requests.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.headers.Add(key, value)
+	}
+}
+
+// WithBearerToken sets the token of the operations secured by HTTP bearer,
+// OAuth2 or OpenID Connect security schemes.
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.bearerToken = token
+	}
+}
+
+// WithAPIKey sets the key of the operations secured by API key security
+// schemes. The key is sent in the header, query parameter or cookie named by
+// the scheme.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithBasicAuth sets the credentials of the operations secured by HTTP basic
+// security schemes.
+func WithBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithRequestEditor adds a function editing the requests before they are
+// sent, for instance to sign them.
+func WithRequestEditor(editor func(*http.Request) error) Option {
+	return func(o *options) {
+		o.editors = append(o.editors, editor)
+	}
+}
+
+// WithOperations restricts the tools to the given operations, identified by
+// their operation ID or by their method and path, as in "GET /pets/{petId}".
+func WithOperations(operations ...string) Option {
+	return func(o *options) {
+		o.operations = append(o.operations, operations...)
+	}
+}
+
+// WithMaxResponseLength sets the maximum length of the responses returned by
+// the tools, in bytes. Longer responses are truncated. The default is 8000.
+func WithMaxResponseLength(n int) Option {
+	return func(o *options) {
+		o.maxResponseLength = n
+	}
+}
@@ -0,0 +1,298 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrInvalidSpec is returned for documents that are not valid OpenAPI 3
+// documents, or that use features not supported by the toolkit.
+var ErrInvalidSpec = errors.New("invalid OpenAPI document")
+
+const _schemaRefPrefix = "#/components/schemas/"
+
+// document is an OpenAPI 3 document, limited to what tools need.
+type document struct {
+	OpenAPI    string              `json:"openapi"`
+	Servers    []server            `json:"servers"`
+	Paths      map[string]pathItem `json:"paths"`
+	Components struct {
+		Schemas         map[string]json.RawMessage `json:"schemas"`
+		Parameters      map[string]parameter       `json:"parameters"`
+		RequestBodies   map[string]requestBody     `json:"requestBodies"`
+		SecuritySchemes map[string]securityScheme  `json:"securitySchemes"`
+	} `json:"components"`
+	Security []map[string][]string `json:"security"`
+}
+
+type server struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+type pathItem struct {
+	Get        *operation  `json:"get"`
+	Put        *operation  `json:"put"`
+	Post       *operation  `json:"post"`
+	Delete     *operation  `json:"delete"`
+	Options    *operation  `json:"options"`
+	Head       *operation  `json:"head"`
+	Patch      *operation  `json:"patch"`
+	Trace      *operation  `json:"trace"`
+	Parameters []parameter `json:"parameters"`
+}
+
+// operations returns the operations of the path by method, in a fixed order.
+func (p pathItem) operations() []struct {
+	method string
+	op     *operation
+} {
+	all := []struct {
+		method string
+		op     *operation
+	}{
+		{"GET", p.Get}, {"PUT", p.Put}, {"POST", p.Post}, {"DELETE", p.Delete},
+		{"OPTIONS", p.Options}, {"HEAD", p.Head}, {"PATCH", p.Patch}, {"TRACE", p.Trace},
+	}
+	res := all[:0]
+	for _, o := range all {
+		if o.op != nil {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+type operation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary"`
+	Description string                 `json:"description"`
+	Parameters  []parameter            `json:"parameters"`
+	RequestBody *requestBody           `json:"requestBody"`
+	Security    *[]map[string][]string `json:"security"`
+}
+
+type parameter struct {
+	Ref         string          `json:"$ref"`
+	Name        string          `json:"name"`
+	In          string          `json:"in"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+type requestBody struct {
+	Ref         string `json:"$ref"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Content     map[string]struct {
+		Schema json.RawMessage `json:"schema"`
+	} `json:"content"`
+}
+
+type securityScheme struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	In     string `json:"in"`
+	Scheme string `json:"scheme"`
+}
+
+// parseDocument parses an OpenAPI 3 document in JSON or YAML.
+func parseDocument(data []byte) (*document, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%w: unsupported version %q, want OpenAPI 3", ErrInvalidSpec, doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// baseURL returns the URL of the first server, with the default values of
+// its variables.
+func (d *document) baseURL() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	u := d.Servers[0].URL
+	for name, v := range d.Servers[0].Variables {
+		u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+	}
+	return u
+}
+
+func (d *document) parameter(p parameter) (parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	resolved, found := d.Components.Parameters[name]
+	if !ok || !found || resolved.Ref != "" {
+		return p, fmt.Errorf("%w: unresolved reference %s", ErrInvalidSpec, p.Ref)
+	}
+	return resolved, nil
+}
+
+func (d *document) requestBody(b *requestBody) (*requestBody, error) {
+	if b == nil || b.Ref == "" {
+		return b, nil
+	}
+	name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+	resolved, found := d.Components.RequestBodies[name]
+	if !ok || !found || resolved.Ref != "" {
+		return nil, fmt.Errorf("%w: unresolved reference %s", ErrInvalidSpec, b.Ref)
+	}
+	return &resolved, nil
+}
+
+// schema converts a schema of the document to a JSON schema. The component
+// schemas it references are added to defs, and referenced as "#/$defs/name".
+func (d *document) schema(data json.RawMessage, defs map[string]any) (map[string]any, error) {
+	def := map[string]any{}
+	if len(data) == 0 {
+		return def, nil
+	}
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	var refs []string
+	if _, err := convertSchema(def, &refs); err != nil {
+		return nil, err
+	}
+
+	for _, name := range refs {
+		if _, ok := defs[name]; ok {
+			continue
+		}
+		component, ok := d.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unresolved reference %s%s", ErrInvalidSpec, _schemaRefPrefix, name)
+		}
+		// The placeholder stops the conversion of schemas referencing themselves.
+		defs[name] = map[string]any{}
+		converted, err := d.schema(component, defs)
+		if err != nil {
+			return nil, err
+		}
+		defs[name] = converted
+	}
+	return def, nil
+}
+
+// definition decodes a converted schema as a jsonschema.Definition. Enums of
+// definitions only hold strings, so other values are converted to their
+// string form, as Validate compares them.
+func definition(schema map[string]any) (*jsonschema.Definition, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(stringEnums(value)); err != nil {
+		return nil, err
+	}
+	def := &jsonschema.Definition{}
+	if err := json.Unmarshal(data, def); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	return def, nil
+}
+
+func stringEnums(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for k, v := range value {
+			if enum, ok := v.([]any); ok && k == "enum" {
+				for i, e := range enum {
+					if _, ok := e.(string); !ok {
+						enum[i] = fmt.Sprint(e)
+					}
+				}
+				continue
+			}
+			value[k] = stringEnums(v)
+		}
+	case []any:
+		for i, v := range value {
+			value[i] = stringEnums(v)
+		}
+	}
+	return value
+}
+
+// convertSchema converts a decoded OpenAPI schema to a JSON schema: component
+// references are rewritten to $defs references, the OpenAPI 3.0 nullable
+// keyword to a null type, its boolean exclusiveMinimum and exclusiveMaximum
+// to numeric bounds, and single allOf schemas to the schema itself.
+func convertSchema(value any, refs *[]string) (any, error) {
+	switch value := value.(type) {
+	case map[string]any:
+		if allOf, ok := value["allOf"].([]any); ok && len(allOf) == 1 {
+			if schema, ok := allOf[0].(map[string]any); ok {
+				delete(value, "allOf")
+				for k, v := range schema {
+					if _, exists := value[k]; !exists {
+						value[k] = v
+					}
+				}
+			}
+		}
+		if ref, ok := value["$ref"].(string); ok {
+			name, found := strings.CutPrefix(ref, _schemaRefPrefix)
+			if !found {
+				return nil, fmt.Errorf("%w: unsupported reference %s", ErrInvalidSpec, ref)
+			}
+			*refs = append(*refs, name)
+			value["$ref"] = "#/$defs/" + name
+		}
+		if nullable, ok := value["nullable"].(bool); ok {
+			if typ, ok := value["type"].(string); ok && nullable {
+				value["type"] = []any{typ, "null"}
+			}
+			delete(value, "nullable")
+		}
+		for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+			if excl, ok := value[exclusive].(bool); ok {
+				delete(value, exclusive)
+				if limit, ok := value[bound]; ok && excl {
+					value[exclusive] = limit
+					delete(value, bound)
+				}
+			}
+		}
+		for k, v := range value {
+			converted, err := convertSchema(v, refs)
+			if err != nil {
+				return nil, err
+			}
+			value[k] = converted
+		}
+		return value, nil
+	case []any:
+		for i, v := range value {
+			converted, err := convertSchema(v, refs)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
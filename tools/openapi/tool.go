@@ -0,0 +1,281 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// Tool is a tool calling an operation of an OpenAPI document.
+type Tool struct {
+	name        string
+	description string
+	method      string
+	path        string
+	params      []param
+	contentType string
+	schema      *jsonschema.Definition
+	raw         json.RawMessage
+	security    []securityScheme
+	options     *options
+}
+
+var _ tools.RawSchemaTool = (*Tool)(nil)
+
+// param is a parameter of an operation, given by a property of the input.
+type param struct {
+	name     string
+	in       string
+	property string
+}
+
+// StatusError is returned by tools when the response has an error status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	// Body is the body of the response, truncated like responses.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return "unexpected status " + e.Status
+	}
+	return fmt.Sprintf("unexpected status %s: %s", e.Status, e.Body)
+}
+
+// Name returns the name of the tool.
+func (t *Tool) Name() string {
+	return t.name
+}
+
+// Description returns the summary and the description of the operation.
+func (t *Tool) Description() string {
+	return t.description
+}
+
+// Schema returns the JSON schema of the input of the tool.
+func (t *Tool) Schema() *jsonschema.Definition {
+	return t.schema
+}
+
+// RawSchema returns the JSON schema of the input of the tool as JSON,
+// including the keywords of the document that Schema does not hold.
+func (t *Tool) RawSchema() json.RawMessage {
+	return t.raw
+}
+
+// Method returns the HTTP method of the operation.
+func (t *Tool) Method() string {
+	return t.method
+}
+
+// Path returns the path of the operation.
+func (t *Tool) Path() string {
+	return t.path
+}
+
+// Call sends the request of the operation made from the JSON input, and
+// returns the body of the response, truncated to the maximum length of
+// responses. If the input does not match the schema the error is given in
+// the result to give the agent the ability to retry.
+func (t *Tool) Call(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		input = "{}"
+	}
+	if !json.Valid([]byte(input)) {
+		return "invalid input: input is not valid JSON", nil
+	}
+	if err := jsonschema.Validate(t.schema, []byte(input)); err != nil {
+		return fmt.Sprintf("invalid input: %s", err.Error()), nil
+	}
+	var args map[string]any
+	if err := json.Unmarshal([]byte(input), &args); err != nil {
+		return fmt.Sprintf("invalid input: %s", err.Error()), nil //nolint:nilerr
+	}
+
+	req, err := t.request(ctx, args)
+	if errors.Is(err, errInvalidInput) {
+		return fmt.Sprintf("invalid input: %s", err.Error()), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	resp, err := t.options.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", t.method, t.path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := t.readBody(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: read response: %w", t.method, t.path, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}
+	}
+	if body == "" {
+		return resp.Status, nil
+	}
+	return body, nil
+}
+
+// errInvalidInput is returned by request for arguments that can't be sent.
+var errInvalidInput = errors.New("invalid argument")
+
+// request returns the request of the operation with the given arguments.
+func (t *Tool) request(ctx context.Context, args map[string]any) (*http.Request, error) {
+	path := t.path
+	query := make(url.Values)
+	header := make(http.Header)
+	var cookies []*http.Cookie
+	for _, p := range t.params {
+		value, ok := args[p.property]
+		if !ok || value == nil {
+			continue
+		}
+		switch p.in {
+		case "path":
+			// Dot segments would reach other paths than the operation's.
+			segment := formatValue(value)
+			if segment == "." || segment == ".." {
+				return nil, fmt.Errorf("%w: %s cannot be %q", errInvalidInput, p.property, segment)
+			}
+			path = strings.ReplaceAll(path, "{"+p.name+"}", url.PathEscape(segment))
+		case "query":
+			if values, ok := value.([]any); ok {
+				for _, v := range values {
+					query.Add(p.name, formatValue(v))
+				}
+			} else {
+				query.Set(p.name, formatValue(value))
+			}
+		case "header":
+			header.Set(p.name, formatValue(value))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: p.name, Value: formatValue(value)})
+		}
+	}
+
+	var body io.Reader
+	if value, ok := args[_bodyProperty]; ok && t.contentType != "" {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encode request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, t.method, t.options.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range t.options.headers {
+		req.Header[key] = values
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", t.contentType)
+	}
+	t.authenticate(req, query)
+	req.URL.RawQuery = query.Encode()
+
+	for _, editor := range t.options.editors {
+		if err := editor(req); err != nil {
+			return nil, fmt.Errorf("edit request: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// authenticate adds the credentials of the security schemes of the
+// operation to a request.
+func (t *Tool) authenticate(req *http.Request, query url.Values) {
+	o := t.options
+	for _, scheme := range t.security {
+		switch scheme.Type {
+		case "apiKey":
+			switch scheme.In {
+			case "header":
+				req.Header.Set(scheme.Name, o.apiKey)
+			case "query":
+				query.Set(scheme.Name, o.apiKey)
+			case "cookie":
+				req.AddCookie(&http.Cookie{Name: scheme.Name, Value: o.apiKey})
+			}
+		case "http":
+			if strings.EqualFold(scheme.Scheme, "basic") {
+				req.SetBasicAuth(o.username, o.password)
+			} else {
+				req.Header.Set("Authorization", "Bearer "+o.bearerToken)
+			}
+		case "oauth2", "openIdConnect":
+			req.Header.Set("Authorization", "Bearer "+o.bearerToken)
+		}
+	}
+}
+
+// readBody reads a response body, truncated to the maximum length of
+// responses.
+func (t *Tool) readBody(r io.Reader) (string, error) {
+	limit := t.options.maxResponseLength
+	if limit <= 0 {
+		data, err := io.ReadAll(r)
+		return string(data), err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) <= limit {
+		return string(data), nil
+	}
+	data = data[:limit]
+	// The truncation must not split a character.
+	for i := 1; i < utf8.UTFMax && len(data) > 0; i++ {
+		if c, size := utf8.DecodeLastRune(data); c != utf8.RuneError || size != 1 {
+			break
+		}
+		data = data[:len(data)-1]
+	}
+	return string(data) + "\n[response truncated]", nil
+}
+
+// formatValue formats the value of a parameter.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		// Arrays in paths and headers are comma-separated, as with the simple style.
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, formatValue(e))
+		}
+		return strings.Join(values, ",")
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ErrUnknownOperation is returned when an allowed operation is not in the
+// document.
+var ErrUnknownOperation = errors.New("unknown operation")
+
+// _bodyProperty is the property of the input of tools holding the request
+// body.
+const _bodyProperty = "body"
+
+var _invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Toolkit reads an OpenAPI 3 document, in JSON or YAML, and returns a tool
+// for each of its operations.
+//
+// The input of a tool is a JSON object whose properties are the path, query,
+// header and cookie parameters of the operation, and "body" for its JSON
+// request body. Tools are named after the IDs of the operations, or after
+// their methods and paths when they have no ID. Credentials are not part of
+// the input: they are added to the requests according to the security
+// requirements of the operations.
+func Toolkit(spec []byte, opts ...Option) ([]tools.Tool, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	doc, err := parseDocument(spec)
+	if err != nil {
+		return nil, err
+	}
+	if o.baseURL == "" {
+		o.baseURL = doc.baseURL()
+	}
+	if !strings.HasPrefix(o.baseURL, "http://") && !strings.HasPrefix(o.baseURL, "https://") {
+		return nil, fmt.Errorf("%w: no absolute server URL %q, use WithBaseURL", ErrInvalidSpec, o.baseURL)
+	}
+	o.baseURL = strings.TrimSuffix(o.baseURL, "/")
+
+	allowed := make(map[string]bool, len(o.operations))
+	for _, op := range o.operations {
+		allowed[op] = false
+	}
+
+	var res []tools.Tool
+	names := make(map[string]bool)
+	for _, path := range slices.Sorted(maps.Keys(doc.Paths)) {
+		item := doc.Paths[path]
+		for _, m := range item.operations() {
+			key := m.method + " " + path
+			if len(allowed) > 0 {
+				_, byID := allowed[m.op.OperationID]
+				_, byPath := allowed[key]
+				if !byID && !byPath {
+					continue
+				}
+				if byID {
+					allowed[m.op.OperationID] = true
+				}
+				if byPath {
+					allowed[key] = true
+				}
+			}
+
+			t, err := newTool(doc, &o, m.method, path, item, m.op)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s: %w", key, err)
+			}
+			if names[t.name] {
+				return nil, fmt.Errorf("%w: duplicate tool name %s", ErrInvalidSpec, t.name)
+			}
+			names[t.name] = true
+			res = append(res, t)
+		}
+	}
+	for _, op := range o.operations {
+		if !allowed[op] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownOperation, op)
+		}
+	}
+	return res, nil
+}
+
+func newTool(doc *document, o *options, method, path string, item pathItem, op *operation) (*Tool, error) {
+	t := &Tool{
+		name:        toolName(method, path, op.OperationID),
+		description: strings.TrimSpace(op.Summary + "\n\n" + op.Description),
+		method:      method,
+		path:        path,
+		options:     o,
+	}
+	if t.description == "" {
+		t.description = method + " " + path
+	}
+
+	defs := make(map[string]any)
+	properties := make(map[string]any)
+	var required []string
+	params, err := operationParameters(doc, item.Parameters, op.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range params {
+		def, err := doc.schema(p.Schema, defs)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		if def["description"] == nil && p.Description != "" {
+			def["description"] = p.Description
+		}
+		property := p.Name
+		if _, exists := properties[property]; exists || property == _bodyProperty {
+			property = p.In + "_" + p.Name
+			if _, exists := properties[property]; exists || property == _bodyProperty {
+				return nil, fmt.Errorf("%w: parameter %s: property %s already exists", ErrInvalidSpec, p.Name, property)
+			}
+		}
+		properties[property] = def
+		if p.Required || p.In == "path" {
+			required = append(required, property)
+		}
+		t.params = append(t.params, param{name: p.Name, in: p.In, property: property})
+	}
+
+	body, err := doc.requestBody(op.RequestBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		contentType, bodySchema, ok := jsonContent(body)
+		if !ok && body.Required {
+			return nil, fmt.Errorf("%w: no JSON request body", ErrInvalidSpec)
+		}
+		if ok {
+			def, err := doc.schema(bodySchema, defs)
+			if err != nil {
+				return nil, fmt.Errorf("request body: %w", err)
+			}
+			if def["description"] == nil && body.Description != "" {
+				def["description"] = body.Description
+			}
+			properties[_bodyProperty] = def
+			if body.Required {
+				required = append(required, _bodyProperty)
+			}
+			t.contentType = contentType
+		}
+	}
+	// The schema is given to models as is, and decoded to validate inputs.
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+	if t.raw, err = json.Marshal(schema); err != nil {
+		return nil, err
+	}
+	if t.schema, err = definition(schema); err != nil {
+		return nil, err
+	}
+
+	security := doc.Security
+	if op.Security != nil {
+		security = *op.Security
+	}
+	t.security = securitySchemes(doc, o, security)
+	return t, nil
+}
+
+// operationParameters returns the parameters of an operation, including the
+// parameters of its path it does not override.
+func operationParameters(doc *document, pathParams, opParams []parameter) ([]parameter, error) {
+	var res []parameter
+	for _, p := range slices.Concat(opParams, pathParams) {
+		p, err := doc.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(res, func(q parameter) bool { return q.Name == p.Name && q.In == p.In }) {
+			continue
+		}
+		// These headers are set by the tools, and ignored by OpenAPI.
+		if p.In == "header" && slices.Contains([]string{"accept", "content-type", "authorization"}, strings.ToLower(p.Name)) {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// jsonContent returns the JSON content type of a request body and its
+// schema.
+func jsonContent(body *requestBody) (string, []byte, bool) {
+	for _, contentType := range slices.Sorted(maps.Keys(body.Content)) {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			continue
+		}
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return contentType, body.Content[contentType].Schema, true
+		}
+	}
+	return "", nil, false
+}
+
+// securitySchemes returns the schemes of the first security requirement the
+// options have credentials for.
+func securitySchemes(doc *document, o *options, requirements []map[string][]string) []securityScheme {
+	for _, requirement := range requirements {
+		var schemes []securityScheme
+		for _, name := range slices.Sorted(maps.Keys(requirement)) {
+			scheme, ok := doc.Components.SecuritySchemes[name]
+			if !ok || !o.hasCredentials(scheme) {
+				schemes = nil
+				break
+			}
+			schemes = append(schemes, scheme)
+		}
+		if len(schemes) > 0 {
+			return schemes
+		}
+	}
+	return nil
+}
+
+func (o *options) hasCredentials(scheme securityScheme) bool {
+	switch scheme.Type {
+	case "apiKey":
+		return o.apiKey != ""
+	case "http":
+		if strings.EqualFold(scheme.Scheme, "basic") {
+			return o.username != ""
+		}
+		return strings.EqualFold(scheme.Scheme, "bearer") && o.bearerToken != ""
+	case "oauth2", "openIdConnect":
+		return o.bearerToken != ""
+	default:
+		return false
+	}
+}
+
+// toolName returns the name of the tool of an operation, made of the
+// characters allowed in the names of tools by model providers.
+func toolName(method, path, operationID string) string {
+	name := operationID
+	if name == "" {
+		name = strings.ToLower(method) + path
+	}
+	name = strings.Trim(_invalidNameChars.ReplaceAllString(name, "_"), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}